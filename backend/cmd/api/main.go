@@ -1,13 +1,17 @@
 package main
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
 	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
@@ -15,13 +19,31 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/alex/opengov-go/internal/config"
+	"github.com/alex/opengov-go/internal/constants"
 	"github.com/alex/opengov-go/internal/db"
+	"github.com/alex/opengov-go/internal/repository"
 )
 
 func corsMiddleware(cfg *config.Config) gin.HandlerFunc {
 	corsConfig := cors.DefaultConfig()
 	if cfg.CORSEnabled {
-		corsConfig.AllowOrigins = cfg.AllowedOrigins
+		var patterns []string
+		for _, o := range cfg.AllowedOrigins {
+			if strings.Contains(o, "*") {
+				patterns = append(patterns, o)
+			} else {
+				corsConfig.AllowOrigins = append(corsConfig.AllowOrigins, o)
+			}
+		}
+		// Exact origins are checked first; the func only sees origins they rejected.
+		corsConfig.AllowOriginFunc = func(origin string) bool {
+			for _, p := range patterns {
+				if originMatches(p, origin) {
+					return true
+				}
+			}
+			return false
+		}
 	} else {
 		corsConfig.AllowAllOrigins = true
 	}
@@ -31,19 +53,102 @@ func corsMiddleware(cfg *config.Config) gin.HandlerFunc {
 	return cors.New(corsConfig)
 }
 
+// originMatches reports whether origin fits pattern, whose single "*" stands for one
+// non-empty DNS label: https://*.example.com matches https://app.example.com but not
+// https://example.com, https://a.b.example.com or https://app.example.com:8080.
+func originMatches(pattern, origin string) bool {
+	prefix, suffix, ok := strings.Cut(pattern, "*")
+	if !ok {
+		return pattern == origin
+	}
+	if len(origin) <= len(prefix)+len(suffix) || !strings.HasPrefix(origin, prefix) || !strings.HasSuffix(origin, suffix) {
+		return false
+	}
+	label := origin[len(prefix) : len(origin)-len(suffix)]
+	return !strings.ContainsAny(label, "./:@?#")
+}
+
+// trustedProxies returns the proxies whose forwarding headers c.ClientIP believes.
+// Outside a proxy there are none, so a client cannot spoof its IP with X-Forwarded-For.
+func trustedProxies(cfg *config.Config) []string {
+	if !cfg.BehindProxy {
+		return nil
+	}
+	return cfg.TrustedProxies
+}
+
+var errBodyReadTimeout = errors.New("request body read timed out")
+
+// deadlineReader fails any read that starts or completes after deadline, so a client
+// trickling bytes cannot keep a handler reading past it.
+type deadlineReader struct {
+	io.ReadCloser
+	deadline time.Time
+}
+
+func (r *deadlineReader) Read(p []byte) (int, error) {
+	if !time.Now().Before(r.deadline) {
+		return 0, errBodyReadTimeout
+	}
+	n, err := r.ReadCloser.Read(p)
+	if err == nil && !time.Now().Before(r.deadline) {
+		return n, errBodyReadTimeout
+	}
+	return n, err
+}
+
+// requestSizeLimitMiddleware rejects bodies over cfg.MaxRequestSizeBytes with a 413 and
+// bounds how long a body may take to arrive.
 func requestSizeLimitMiddleware(cfg *config.Config) gin.HandlerFunc {
+	maxMB := cfg.MaxRequestSizeBytes / (1024 * 1024)
+	tooLarge := func(c *gin.Context) {
+		c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{
+			"detail": fmt.Sprintf("Request body too large (max %d MB)", maxMB),
+		})
+	}
+
 	return func(c *gin.Context) {
 		contentLength := c.GetHeader("Content-Length")
 		if contentLength != "" {
 			length, err := strconv.ParseInt(contentLength, 10, 64)
 			if err == nil && length > int64(cfg.MaxRequestSizeBytes) {
-				maxMB := cfg.MaxRequestSizeBytes / (1024 * 1024)
-				c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{
-					"detail": fmt.Sprintf("Request body too large (max %d MB)", maxMB),
-				})
+				tooLarge(c)
 				return
 			}
 		}
+
+		if c.Request.Body != nil && c.Request.Body != http.NoBody {
+			if cfg.BodyReadTimeout > 0 {
+				deadline := time.Now().Add(time.Duration(cfg.BodyReadTimeout) * time.Second)
+				// The connection deadline also unblocks a client that stops sending entirely;
+				// it is unsupported on some writers (e.g. in tests), where the reader still applies.
+				_ = http.NewResponseController(c.Writer).SetReadDeadline(deadline)
+				c.Request.Body = &deadlineReader{ReadCloser: c.Request.Body, deadline: deadline}
+			}
+
+			// The header can be missing (chunked uploads), so the body itself is capped too.
+			c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, int64(cfg.MaxRequestSizeBytes))
+
+			// Without a length up front, read the body here so an oversized one still gets
+			// the 413 rather than whatever a handler reports for a failed bind.
+			if c.Request.ContentLength < 0 {
+				body, err := io.ReadAll(c.Request.Body)
+				var maxBytesErr *http.MaxBytesError
+				switch {
+				case errors.As(err, &maxBytesErr):
+					tooLarge(c)
+					return
+				case errors.Is(err, errBodyReadTimeout):
+					c.AbortWithStatusJSON(http.StatusRequestTimeout, gin.H{"detail": "Request body read timed out"})
+					return
+				case err != nil:
+					c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"detail": "Failed to read request body"})
+					return
+				}
+				c.Request.Body = io.NopCloser(bytes.NewReader(body))
+			}
+		}
+
 		c.Next()
 	}
 }
@@ -60,20 +165,25 @@ func main() {
 	}
 	defer database.Close()
 
-	deps, err := wireDependencies(cfg, database)
+	// ctx is cancelled on SIGINT/SIGTERM, stopping background jobs along with the server.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	deps, err := wireDependencies(ctx, cfg, database)
 	if err != nil {
 		log.Fatalf("Failed to wire dependencies: %v", err)
 	}
 
 	log.Println("Starting OpenGov API")
 
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
-
 	log.Println("Running database migrations...")
-	if err := database.RunMigrations(); err != nil {
+	applied, err := database.MigrateUp(ctx)
+	if err != nil {
 		log.Fatalf("Failed to run migrations: %v", err)
 	}
+	for _, name := range applied {
+		log.Printf("Applied migration %s", name)
+	}
 
 	log.Println("Checking database schema...")
 	var tableCount int
@@ -83,21 +193,30 @@ func main() {
 	}
 	log.Println("Database schema check passed")
 
+	if err := deps.Flags.Load(ctx); err != nil {
+		log.Printf("Failed to load persisted feature flags, using seed values: %v", err)
+	}
+
+	// Jobs this process left pending or running were cut off by a restart or crash.
+	if n, err := repository.NewJobRepository(database).FailUnfinished(ctx, constants.JobOriginAPI, "interrupted by API restart"); err != nil {
+		log.Printf("Failed to fail interrupted jobs: %v", err)
+	} else if n > 0 {
+		log.Printf("Marked %d interrupted job(s) as failed", n)
+	}
+
 	if !cfg.Debug {
 		gin.SetMode(gin.ReleaseMode)
 	}
 
 	router := gin.New()
+	if err := router.SetTrustedProxies(trustedProxies(cfg)); err != nil {
+		log.Fatalf("Failed to set trusted proxies: %v", err)
+	}
 	router.Use(gin.Recovery())
 	router.Use(gin.Logger())
 
 	router.Use(corsMiddleware(cfg))
 
-	router.Use(func(c *gin.Context) {
-		c.Header("Cache-Control", "no-store, no-cache, must-revalidate, private")
-		c.Next()
-	})
-
 	router.Use(requestSizeLimitMiddleware(cfg))
 
 	setupRoutes(router, cfg, deps)
@@ -118,9 +237,15 @@ func main() {
 		Handler: router,
 	}
 
+	// ListenAndServe returns as soon as Shutdown starts, so wait for Shutdown itself
+	// to finish draining in-flight requests before main exits.
+	shutdownDone := make(chan struct{})
 	go func() {
+		defer close(shutdownDone)
 		<-ctx.Done()
-		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+		grace := time.Duration(cfg.ShutdownTimeout) * time.Second
+		log.Printf("Waiting up to %s for in-flight requests...", grace)
+		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), grace)
 		defer shutdownCancel()
 		if err := srv.Shutdown(shutdownCtx); err != nil {
 			log.Printf("Server shutdown error: %v", err)
@@ -130,6 +255,7 @@ func main() {
 	if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 		log.Fatalf("Server failed: %v", err)
 	}
+	<-shutdownDone
 
 	log.Println("API server stopped")
 }
@@ -0,0 +1,264 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/alex/opengov-go/internal/config"
+	"github.com/alex/opengov-go/internal/constants"
+	"github.com/alex/opengov-go/internal/openapi"
+	"github.com/alex/opengov-go/internal/services"
+)
+
+// slowBody yields one byte per interval, like a client trickling an upload.
+type slowBody struct {
+	remaining int
+	interval  time.Duration
+}
+
+func (b *slowBody) Read(p []byte) (int, error) {
+	if b.remaining == 0 {
+		return 0, io.EOF
+	}
+	time.Sleep(b.interval)
+	b.remaining--
+	p[0] = 'x'
+	return 1, nil
+}
+
+func (b *slowBody) Close() error { return nil }
+
+func TestRequestSizeLimitMiddleware_AbortsSlowBody(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cfg := &config.Config{MaxRequestSizeBytes: 1024, BodyReadTimeout: 1}
+	router := gin.New()
+	router.Use(requestSizeLimitMiddleware(cfg))
+
+	var readErr error
+	var elapsed time.Duration
+	router.POST("/upload", func(c *gin.Context) {
+		start := time.Now()
+		_, readErr = io.ReadAll(c.Request.Body)
+		elapsed = time.Since(start)
+		c.Status(http.StatusOK)
+	})
+
+	// 100 bytes at 50ms each would take 5s; the 1s deadline must cut it off.
+	req := httptest.NewRequest(http.MethodPost, "/upload", nil)
+	req.Body = &slowBody{remaining: 100, interval: 50 * time.Millisecond}
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	if !errors.Is(readErr, errBodyReadTimeout) {
+		t.Fatalf("read error = %v, want %v", readErr, errBodyReadTimeout)
+	}
+	if elapsed > 1500*time.Millisecond {
+		t.Fatalf("read took %v, want it aborted near the 1s deadline", elapsed)
+	}
+}
+
+func TestRequestSizeLimitMiddleware_AllowsBodyWithinDeadline(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cfg := &config.Config{MaxRequestSizeBytes: 1024, BodyReadTimeout: 1}
+	router := gin.New()
+	router.Use(requestSizeLimitMiddleware(cfg))
+
+	var body []byte
+	var readErr error
+	router.POST("/upload", func(c *gin.Context) {
+		body, readErr = io.ReadAll(c.Request.Body)
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/upload", nil)
+	req.Body = &slowBody{remaining: 5, interval: time.Millisecond}
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	if readErr != nil || len(body) != 5 {
+		t.Fatalf("ReadAll() = %d bytes, %v; want 5 bytes, nil", len(body), readErr)
+	}
+}
+
+// A chunked upload carries no Content-Length, so only the body cap can catch it.
+func TestRequestSizeLimitMiddleware_RejectsOversizedChunkedBody(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cfg := &config.Config{MaxRequestSizeBytes: 1024 * 1024}
+	router := gin.New()
+	router.Use(requestSizeLimitMiddleware(cfg))
+	reached := false
+	router.POST("/upload", func(c *gin.Context) {
+		reached = true
+		c.Status(http.StatusOK)
+	})
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	post := func(size int) *http.Response {
+		// Hiding the reader's type keeps the client from computing a Content-Length.
+		body := io.MultiReader(strings.NewReader(strings.Repeat("x", size)))
+		resp, err := http.Post(server.URL+"/upload", "application/octet-stream", body)
+		if err != nil {
+			t.Fatalf("POST error: %v", err)
+		}
+		return resp
+	}
+
+	resp := post(cfg.MaxRequestSizeBytes + 1)
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusRequestEntityTooLarge || reached {
+		t.Fatalf("status = %d (handler reached: %v), want %d", resp.StatusCode, reached, http.StatusRequestEntityTooLarge)
+	}
+	var got map[string]string
+	if err := json.NewDecoder(resp.Body).Decode(&got); err != nil || got["detail"] != "Request body too large (max 1 MB)" {
+		t.Fatalf("body = %v, %v", got, err)
+	}
+
+	ok := post(cfg.MaxRequestSizeBytes)
+	ok.Body.Close()
+	if ok.StatusCode != http.StatusOK || !reached {
+		t.Fatalf("at the limit: status = %d, want %d", ok.StatusCode, http.StatusOK)
+	}
+}
+
+// Every operation in the OpenAPI document must be a route the router serves.
+func TestOpenAPIOperationsAreRouted(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	setupRoutes(router, &config.Config{}, RouteDeps{})
+
+	routes := map[string]bool{}
+	for _, r := range router.Routes() {
+		routes[r.Method+" "+r.Path] = true
+	}
+	for _, op := range openapi.Operations() {
+		if !routes[op.Method+" "+op.Path] {
+			t.Errorf("%s %s is documented but not routed", op.Method, op.Path)
+		}
+	}
+}
+
+// With personalization off its routes and leaning=opposite answer 404 before auth runs.
+func TestPersonalizationRoutesRequireFeature(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	flags := services.NewFeatureFlags(map[string]bool{constants.FeaturePersonalization: false}, nil)
+	router := gin.New()
+	setupRoutes(router, &config.Config{}, RouteDeps{Flags: flags})
+
+	get := func(path string) int {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w.Code
+	}
+
+	paths := []string{"/api/feed/following", "/api/feed/recommended", "/api/feed?leaning=opposite"}
+	for _, path := range paths {
+		if code := get(path); code != http.StatusNotFound {
+			t.Errorf("flag off: %s status = %d, want %d", path, code, http.StatusNotFound)
+		}
+	}
+
+	if err := flags.Set(t.Context(), constants.FeaturePersonalization, true); err != nil {
+		t.Fatalf("Set() error: %v", err)
+	}
+	for _, path := range paths {
+		if code := get(path); code != http.StatusUnauthorized {
+			t.Errorf("flag on: %s status = %d, want %d", path, code, http.StatusUnauthorized)
+		}
+	}
+}
+
+func TestOriginMatches(t *testing.T) {
+	cases := []struct {
+		pattern, origin string
+		want            bool
+	}{
+		{"https://opengov.example", "https://opengov.example", true},
+		{"https://opengov.example", "https://opengov.example.evil.com", false},
+		{"https://*.vercel.app", "https://opengov-git-main-team.vercel.app", true},
+		{"https://*.vercel.app", "https://vercel.app", false},
+		{"https://*.vercel.app", "https://.vercel.app", false},
+		{"https://*.vercel.app", "https://a.b.vercel.app", false},
+		{"https://*.vercel.app", "http://preview.vercel.app", false},
+		{"https://*.vercel.app", "https://preview.vercel.app:8443", false},
+		{"https://*.vercel.app", "https://evil.com/.vercel.app", false},
+		{"https://*.vercel.app", "https://user@evil.com#.vercel.app", false},
+		{"https://opengov-*.vercel.app", "https://opengov-pr-12.vercel.app", true},
+		{"https://opengov-*.vercel.app", "https://other-pr-12.vercel.app", false},
+	}
+	for _, tc := range cases {
+		if got := originMatches(tc.pattern, tc.origin); got != tc.want {
+			t.Errorf("originMatches(%q, %q) = %v, want %v", tc.pattern, tc.origin, got, tc.want)
+		}
+	}
+}
+
+func TestCORSMiddleware_Origins(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(corsMiddleware(&config.Config{
+		CORSEnabled:    true,
+		AllowedOrigins: []string{"http://localhost:5173", "https://*.vercel.app"},
+	}))
+	router.GET("/api/feed", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	for origin, allowed := range map[string]bool{
+		"http://localhost:5173":      true,
+		"https://preview.vercel.app": true,
+		"http://localhost:3000":      false,
+		"https://vercel.app.evil":    false,
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/api/feed", nil)
+		req.Header.Set("Origin", origin)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		got := w.Header().Get("Access-Control-Allow-Origin") == origin
+		if got != allowed || (!allowed && w.Code != http.StatusForbidden) {
+			t.Errorf("%s: allowed = %v (status %d), want %v", origin, got, w.Code, allowed)
+		}
+	}
+}
+
+func TestTrustedProxies_ClientIP(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cases := []struct {
+		name string
+		cfg  config.Config
+		peer string
+		want string
+	}{
+		{"not behind proxy", config.Config{TrustedProxies: []string{"10.0.0.0/8"}}, "10.0.0.5:4000", "10.0.0.5"},
+		{"trusted proxy", config.Config{BehindProxy: true, TrustedProxies: []string{"10.0.0.0/8"}}, "10.0.0.5:4000", "203.0.113.7"},
+		{"untrusted peer", config.Config{BehindProxy: true, TrustedProxies: []string{"10.0.0.0/8"}}, "198.51.100.9:4000", "198.51.100.9"},
+	}
+	for _, tc := range cases {
+		router := gin.New()
+		if err := router.SetTrustedProxies(trustedProxies(&tc.cfg)); err != nil {
+			t.Fatalf("%s: SetTrustedProxies() error: %v", tc.name, err)
+		}
+		var got string
+		router.GET("/ip", func(c *gin.Context) { got = c.ClientIP() })
+
+		req := httptest.NewRequest(http.MethodGet, "/ip", nil)
+		req.RemoteAddr = tc.peer
+		req.Header.Set("X-Forwarded-For", "203.0.113.7")
+		router.ServeHTTP(httptest.NewRecorder(), req)
+		if got != tc.want {
+			t.Errorf("%s: ClientIP() = %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
@@ -2,10 +2,12 @@ package main
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
 	"github.com/alex/opengov-go/internal/config"
+	"github.com/alex/opengov-go/internal/constants"
 	"github.com/alex/opengov-go/internal/db"
 	"github.com/alex/opengov-go/internal/handlers"
 	"github.com/alex/opengov-go/internal/middleware"
@@ -13,17 +15,33 @@ import (
 )
 
 type RouteDeps struct {
-	DB              *db.DB
-	AuthService     *services.AuthService
-	FeedHandler     *handlers.FeedHandler
-	BookmarkHandler *handlers.BookmarkHandler
-	LikeHandler     *handlers.LikeHandler
-	AuthHandler     *handlers.AuthHandler
-	AdminHandler    *handlers.AdminHandler
-	OAuthHandler    *handlers.OAuthHandler
+	DB                      *db.DB
+	AuthService             *services.AuthService
+	FeedHandler             *handlers.FeedHandler
+	BookmarkHandler         *handlers.BookmarkHandler
+	LikeHandler             *handlers.LikeHandler
+	ActivityHandler         *handlers.ActivityHandler
+	AuthHandler             *handlers.AuthHandler
+	AdminHandler            *handlers.AdminHandler
+	AdminUserHandler        *handlers.AdminUserHandler
+	AdminJobHandler         *handlers.AdminJobHandler
+	AdminRawDocumentHandler *handlers.AdminRawDocumentHandler
+	AdminReprocessHandler   *handlers.AdminReprocessHandler
+	OAuthHandler            *handlers.OAuthHandler
+	SavedSearchHandler      *handlers.SavedSearchHandler
+	AgencyHandler           *handlers.AgencyHandler
+	CategoryHandler         *handlers.CategoryHandler
+	DocsHandler             *handlers.DocsHandler
+	HealthHandler           *handlers.HealthHandler
+	FlagHandler             *handlers.FlagHandler
+	Flags                   *services.FeatureFlags
+	Idempotency             *services.IdempotencyStore
 }
 
-func setupRoutes(router *gin.Engine, _ *config.Config, deps RouteDeps) {
+// feedCacheMaxAge is how long shared caches may keep anonymous feed and article reads.
+const feedCacheMaxAge = 5 * time.Minute
+
+func setupRoutes(router *gin.Engine, cfg *config.Config, deps RouteDeps) {
 	router.GET("/health", func(c *gin.Context) {
 		c.Header("Cache-Control", "public, max-age=60")
 		if err := deps.DB.HealthCheck(); err != nil {
@@ -49,7 +67,16 @@ func setupRoutes(router *gin.Engine, _ *config.Config, deps RouteDeps) {
 		})
 	})
 
+	router.GET("/health/scraper", deps.HealthHandler.ScraperHealth)
+
+	router.GET("/openapi.json", deps.DocsHandler.OpenAPI)
+	router.GET("/docs", deps.DocsHandler.SwaggerUI)
+
+	// API responses are uncacheable unless a group opts anonymous reads into caching.
+	// Every API handler runs under a deadline. Streaming exports and synchronous AI calls
+	// get a longer one.
 	api := router.Group("/api")
+	api.Use(middleware.NoStore(), middleware.Timeout(cfg.RequestTimeout))
 	{
 		auth := api.Group("/auth")
 		{
@@ -77,17 +104,27 @@ func setupRoutes(router *gin.Engine, _ *config.Config, deps RouteDeps) {
 			testAuth.GET("/login", deps.OAuthHandler.TestLogin)
 		}
 
+		personalization := middleware.RequireFeature(deps.Flags, constants.FeaturePersonalization)
 		feed := api.Group("/feed")
-		feed.Use(middleware.OptionalAuthMiddleware(deps.AuthService))
+		feed.Use(middleware.OptionalAuthMiddleware(deps.AuthService), middleware.PublicCache(feedCacheMaxAge))
 		{
-			feed.GET("", deps.FeedHandler.GetFeed)
+			feed.GET("", middleware.RequireFeatureForQuery(deps.Flags, constants.FeaturePersonalization, "leaning"), deps.FeedHandler.GetFeed)
+			feed.GET("/following", middleware.NoStore(), personalization, middleware.AuthMiddleware(deps.AuthService), deps.FeedHandler.GetFollowing)
+			feed.GET("/recommended", middleware.NoStore(), personalization, middleware.AuthMiddleware(deps.AuthService), deps.FeedHandler.GetRecommended)
+			feed.GET("/themes", deps.FeedHandler.GetThemes)
+			feed.GET("/export.csv", middleware.Timeout(cfg.LongRequestTimeout), deps.FeedHandler.ExportCSV)
+			feed.GET("/export.json", middleware.Timeout(cfg.LongRequestTimeout), deps.FeedHandler.ExportJSON)
+			feed.GET("/archive", deps.FeedHandler.GetArchive)
+			feed.GET("/archive/:year/:month", deps.FeedHandler.GetArchiveMonth)
 			feed.GET("/:id", deps.FeedHandler.GetItem)
+			feed.GET("/:id/full", deps.FeedHandler.GetItemFull)
+			feed.GET("/:id/related", deps.FeedHandler.GetRelated)
 		}
 
 		bookmarks := api.Group("/bookmarks")
 		bookmarks.Use(middleware.AuthMiddleware(deps.AuthService))
 		{
-			bookmarks.POST("/:feed_entry_id", deps.BookmarkHandler.Toggle)
+			bookmarks.POST("/:feed_entry_id", middleware.Idempotency(deps.Idempotency), deps.BookmarkHandler.Toggle)
 			bookmarks.GET("", deps.BookmarkHandler.GetBookmarks)
 			bookmarks.DELETE("/:feed_entry_id", deps.BookmarkHandler.Remove)
 			bookmarks.GET("/status/:feed_entry_id", deps.BookmarkHandler.GetStatus)
@@ -96,16 +133,72 @@ func setupRoutes(router *gin.Engine, _ *config.Config, deps RouteDeps) {
 		likes := api.Group("/likes")
 		likes.Use(middleware.AuthMiddleware(deps.AuthService))
 		{
-			likes.POST("/:feed_entry_id", deps.LikeHandler.Toggle)
+			likes.POST("/:feed_entry_id", middleware.Idempotency(deps.Idempotency), deps.LikeHandler.Toggle)
+			likes.GET("/counts", deps.LikeHandler.GetCountsBatch)
 			likes.GET("/counts/:feed_entry_id", deps.LikeHandler.GetCounts)
 			likes.DELETE("/:feed_entry_id", deps.LikeHandler.Remove)
 			likes.GET("/status/:feed_entry_id", deps.LikeHandler.GetStatus)
 		}
 
+		me := api.Group("/me")
+		me.Use(middleware.AuthMiddleware(deps.AuthService))
+		{
+			me.GET("/activity", deps.ActivityHandler.GetActivity)
+		}
+
+		agencies := api.Group("/agencies")
+		{
+			agencies.GET("/tree", deps.AgencyHandler.GetTree)
+			agencies.GET("/engagement", deps.AgencyHandler.GetEngagement)
+			agencies.GET("/:slug", deps.AgencyHandler.GetAgency)
+			agencies.GET("/:slug/stats", deps.AgencyHandler.GetStats)
+			agencies.POST("/:slug/follow", middleware.AuthMiddleware(deps.AuthService), deps.AgencyHandler.Follow)
+			agencies.DELETE("/:slug/follow", middleware.AuthMiddleware(deps.AuthService), deps.AgencyHandler.Unfollow)
+		}
+
+		api.GET("/categories", deps.CategoryHandler.List)
+
+		savedSearches := api.Group("/saved-searches")
+		savedSearches.Use(middleware.AuthMiddleware(deps.AuthService))
+		{
+			savedSearches.GET("", deps.SavedSearchHandler.List)
+			savedSearches.POST("", deps.SavedSearchHandler.Create)
+			savedSearches.GET("/:id", deps.SavedSearchHandler.Get)
+			savedSearches.PUT("/:id", deps.SavedSearchHandler.Update)
+			savedSearches.DELETE("/:id", deps.SavedSearchHandler.Delete)
+			savedSearches.GET("/:id/results", deps.SavedSearchHandler.Results)
+		}
+
 		admin := api.Group("/admin")
+		admin.Use(middleware.AuthMiddleware(deps.AuthService), middleware.RequireSuperuser())
 		{
 			admin.GET("/stats", deps.AdminHandler.GetStats)
+			admin.GET("/stats/external", deps.AdminHandler.GetExternalCalls)
 			admin.GET("/agencies", deps.AdminHandler.GetAgencies)
+			admin.POST("/backfill", deps.AdminHandler.TriggerBackfill)
+			admin.GET("/agencies/diff", deps.AdminHandler.GetAgencyDiff)
+			admin.GET("/ai-usage", deps.AdminHandler.GetAIUsage)
+			admin.PATCH("/documents/:id", deps.AdminHandler.EditDocument)
+			admin.POST("/documents/:id/hide", deps.AdminHandler.HideDocument)
+			admin.POST("/documents/:id/reprocess", middleware.Timeout(cfg.LongRequestTimeout), deps.AdminHandler.ReprocessDocument)
+			admin.GET("/documents/:id/raw", deps.AdminRawDocumentHandler.GetForDocument)
+			admin.POST("/documents/:id/unhide", deps.AdminHandler.UnhideDocument)
+			admin.GET("/federal-register/agencies", deps.AdminHandler.GetUpstreamAgencies)
+			admin.GET("/flags", deps.FlagHandler.List)
+			admin.GET("/jobs", deps.AdminJobHandler.List)
+			admin.GET("/jobs/:id", deps.AdminJobHandler.Get)
+			admin.PUT("/flags/:name", deps.FlagHandler.Update)
+			admin.POST("/reprocess-all", deps.AdminReprocessHandler.Start)
+			admin.DELETE("/reprocess-all", deps.AdminReprocessHandler.Cancel)
+			admin.POST("/scrape", deps.AdminHandler.TriggerScrape)
+			admin.GET("/raw-documents/failed", deps.AdminRawDocumentHandler.ListFailed)
+			admin.POST("/raw-documents/:id/retry", deps.AdminRawDocumentHandler.Retry)
+			admin.GET("/scraper/config", deps.AdminHandler.GetScraperConfig)
+			admin.GET("/settings/analysis-prompt", deps.AdminHandler.GetAnalysisPrompt)
+			admin.PUT("/settings/analysis-prompt", deps.AdminHandler.UpdateAnalysisPrompt)
+			admin.GET("/users", deps.AdminUserHandler.List)
+			admin.GET("/users/:id", deps.AdminUserHandler.Get)
+			admin.PATCH("/users/:id", deps.AdminUserHandler.Update)
 		}
 	}
 }
@@ -1,44 +1,107 @@
 package main
 
 import (
+	"context"
+	"fmt"
+	"log"
+
 	"github.com/alex/opengov-go/internal/client"
 	"github.com/alex/opengov-go/internal/config"
+	"github.com/alex/opengov-go/internal/constants"
 	"github.com/alex/opengov-go/internal/db"
 	"github.com/alex/opengov-go/internal/handlers"
 	"github.com/alex/opengov-go/internal/repository"
 	"github.com/alex/opengov-go/internal/services"
 )
 
-func wireDependencies(cfg *config.Config, database *db.DB) (RouteDeps, error) {
+// wireDependencies builds the handlers. Background work (triggered scrapes, backfills,
+// bulk reprocesses) runs under ctx, so cancelling it at shutdown stops that work.
+func wireDependencies(ctx context.Context, cfg *config.Config, database *db.DB) (RouteDeps, error) {
 	feedRepo := repository.NewFeedRepository(database)
 	docRepo := repository.NewPolicyDocumentRepository(database)
 	userRepo := repository.NewUserRepository(database)
 	agencyRepo := repository.NewAgencyRepository(database)
 	bookmarkRepo := repository.NewBookmarkRepository(database)
 	likeRepo := repository.NewLikeRepository(database)
+	savedSearchRepo := repository.NewSavedSearchRepository(database)
+	followRepo := repository.NewAgencyFollowRepository(database)
+	settingsRepo := repository.NewSettingsRepository(database)
+	aiUsageRepo := repository.NewAIUsageRepository(database)
+	rawRepo := repository.NewRawPolicyDocumentRepository(database)
+	stateRepo := repository.NewScrapeStateRepository(database)
+	jobRepo := repository.NewJobRepository(database)
+	jobRunner := services.NewJobRunner(ctx, constants.JobOriginAPI, jobRepo)
+
+	flags := services.NewFeatureFlags(cfg.FeatureFlags, settingsRepo)
 
-	feedService := services.NewFeedService(feedRepo)
+	feedService := services.NewFeedService(feedRepo, followRepo, userRepo, cfg.PublicationLocation)
 	authService := services.NewAuthService(cfg, userRepo)
+	savedSearchService := services.NewSavedSearchService(savedSearchRepo, feedService)
 
-	feedHandler := handlers.NewFeedHandler(feedService)
-	bookmarkHandler := handlers.NewBookmarkHandler(bookmarkRepo, feedService)
-	likeHandler := handlers.NewLikeHandler(likeRepo)
+	feedCache := services.NewFeedCache(cfg.FeedCacheTTL)
+	feedHandler := handlers.NewFeedHandler(feedService, feedCache)
+	bookmarkHandler := handlers.NewBookmarkHandler(bookmarkRepo, feedService, feedRepo)
+	likeHandler := handlers.NewLikeHandler(likeRepo, feedRepo)
+	activityHandler := handlers.NewActivityHandler(feedService)
 	authHandler := handlers.NewAuthHandler(authService, userRepo)
+	savedSearchHandler := handlers.NewSavedSearchHandler(savedSearchService)
+	agencyHandler := handlers.NewAgencyHandler(agencyRepo, followRepo, docRepo)
+	categoryHandler := handlers.NewCategoryHandler(docRepo)
+	flagHandler := handlers.NewFlagHandler(flags)
+	healthHandler := handlers.NewHealthHandler(docRepo, stateRepo, cfg.ScraperStaleAfter())
+	docsHandler, err := handlers.NewDocsHandler()
+	if err != nil {
+		return RouteDeps{}, fmt.Errorf("failed to encode OpenAPI spec: %w", err)
+	}
 
-	frClient := client.NewFederalRegisterClient(cfg)
+	externalCalls := client.NewCallLog(client.DefaultCallLogSize)
+	frClient := client.NewFederalRegisterClient(cfg, externalCalls)
 	agencySync := services.NewAgencySyncService(frClient, agencyRepo)
+	jobs := services.NewJobsService(cfg, database, agencyRepo, rawRepo, docRepo, feedRepo, stateRepo, jobRunner, frClient, externalCalls)
+	jobs.OnFeedChanged(feedCache.Invalidate)
+	scrapeTrigger := services.NewScrapeTrigger(ctx, cfg.ScraperCooldown(), func(ctx context.Context) error {
+		m, err := jobs.Pipeline(ctx)
+		log.Printf("Triggered pipeline: %+v", m)
+		return err
+	})
+	backfill := services.NewBackfillRunner(cfg.BackfillMaxDays, jobRunner, jobs.Backfill)
+
+	// The API can run without AI credentials; reprocessing is simply unavailable then.
+	var summarizer services.Summarizer
+	if cfg.HasSummarizerCredentials() {
+		summarizer = services.NewSummarizer(cfg, settingsRepo, aiUsageRepo, externalCalls)
+	}
+	docService := services.NewPolicyDocumentService(cfg, database, docRepo, feedRepo, summarizer)
 
-	adminHandler := handlers.NewAdminHandler(docRepo, agencyRepo, agencySync)
+	adminHandler := handlers.NewAdminHandler(cfg, docRepo, agencyRepo, settingsRepo, aiUsageRepo, agencySync, scrapeTrigger, backfill, frClient, docService, externalCalls)
+	adminUserHandler := handlers.NewAdminUserHandler(userRepo)
+	adminJobHandler := handlers.NewAdminJobHandler(jobRepo)
+	adminRawDocumentHandler := handlers.NewAdminRawDocumentHandler(rawRepo)
+	reprocess := services.NewReprocessRunner(docRepo, docService, jobRunner, cfg.ReprocessInterval)
+	adminReprocessHandler := handlers.NewAdminReprocessHandler(reprocess, cfg.PublicationLocation)
 	oauthHandler := handlers.NewOAuthHandler(authService, userRepo, cfg)
 
 	return RouteDeps{
-		DB:              database,
-		AuthService:     authService,
-		FeedHandler:     feedHandler,
-		BookmarkHandler: bookmarkHandler,
-		LikeHandler:     likeHandler,
-		AuthHandler:     authHandler,
-		AdminHandler:    adminHandler,
-		OAuthHandler:    oauthHandler,
+		DB:                      database,
+		AuthService:             authService,
+		FeedHandler:             feedHandler,
+		BookmarkHandler:         bookmarkHandler,
+		LikeHandler:             likeHandler,
+		ActivityHandler:         activityHandler,
+		AuthHandler:             authHandler,
+		AdminHandler:            adminHandler,
+		AdminUserHandler:        adminUserHandler,
+		AdminJobHandler:         adminJobHandler,
+		AdminRawDocumentHandler: adminRawDocumentHandler,
+		AdminReprocessHandler:   adminReprocessHandler,
+		OAuthHandler:            oauthHandler,
+		SavedSearchHandler:      savedSearchHandler,
+		AgencyHandler:           agencyHandler,
+		CategoryHandler:         categoryHandler,
+		HealthHandler:           healthHandler,
+		DocsHandler:             docsHandler,
+		FlagHandler:             flagHandler,
+		Flags:                   flags,
+		Idempotency:             services.NewIdempotencyStore(cfg.IdempotencyTTL),
 	}, nil
 }
@@ -11,13 +11,14 @@ import (
 
 	"github.com/alex/opengov-go/internal/client"
 	"github.com/alex/opengov-go/internal/config"
+	"github.com/alex/opengov-go/internal/constants"
 	"github.com/alex/opengov-go/internal/db"
 	"github.com/alex/opengov-go/internal/repository"
 	"github.com/alex/opengov-go/internal/services"
 )
 
 func main() {
-	job := flag.String("job", "", "job to run (migrate|sync-agencies|scrape|canonicalize|enrich|materialize|pipeline)")
+	job := flag.String("job", "", "job to run (migrate|sync-agencies|scrape|canonicalize|dedupe|retry-failed|enrich|materialize|translate|pipeline)")
 	flag.Parse()
 
 	if *job == "" {
@@ -41,13 +42,16 @@ func main() {
 	feedRepo := repository.NewFeedRepository(database)
 	agencyRepo := repository.NewAgencyRepository(database)
 	rawRepo := repository.NewRawPolicyDocumentRepository(database)
-
-	frClient := client.NewFederalRegisterClient(cfg)
-	jobs := services.NewJobsService(cfg, database, agencyRepo, rawRepo, docRepo, feedRepo, frClient)
+	stateRepo := repository.NewScrapeStateRepository(database)
+	jobRepo := repository.NewJobRepository(database)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	jobRunner := services.NewJobRunner(ctx, constants.JobOriginJobs, jobRepo)
+	frClient := client.NewFederalRegisterClient(cfg, nil)
+	jobs := services.NewJobsService(cfg, database, agencyRepo, rawRepo, docRepo, feedRepo, stateRepo, jobRunner, frClient, nil)
+
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
 	go func() {
@@ -58,11 +62,18 @@ func main() {
 
 	switch *job {
 	case "migrate":
-		if err := jobs.Migrate(); err != nil {
+		applied, err := jobs.Migrate(ctx)
+		if err != nil {
 			log.Fatalf("Failed to run migrations: %v", err)
 		}
-		log.Println("Migrations completed successfully")
-		return
+		if len(applied) == 0 {
+			log.Println("migrate completed: schema already up to date")
+			return
+		}
+		for _, name := range applied {
+			log.Printf("applied migration %s", name)
+		}
+		log.Printf("migrate completed: applied=%d", len(applied))
 	case "sync-agencies":
 		n, err := jobs.SyncAgencies(ctx)
 		if err != nil {
@@ -75,12 +86,24 @@ func main() {
 			log.Fatalf("scrape failed: %v", err)
 		}
 		log.Printf("scrape completed: inserted=%d skipped=%d", processed, skipped)
+	case "retry-failed":
+		n, err := jobs.RetryFailed(ctx)
+		if err != nil {
+			log.Fatalf("retry-failed failed: %v", err)
+		}
+		log.Printf("retry-failed completed: requeued=%d", n)
 	case "canonicalize":
-		linked, err := jobs.Canonicalize(ctx, 200)
+		linked, failed, err := jobs.Canonicalize(ctx, 200)
 		if err != nil {
 			log.Fatalf("canonicalize failed: %v", err)
 		}
-		log.Printf("canonicalize completed: linked=%d", linked)
+		log.Printf("canonicalize completed: linked=%d failed=%d", linked, failed)
+	case "dedupe":
+		merged, err := jobs.Dedupe(ctx, 200)
+		if err != nil {
+			log.Fatalf("dedupe failed: %v", err)
+		}
+		log.Printf("dedupe completed: merged=%d", merged)
 	case "enrich":
 		wouldEnrich, err := jobs.Enrich(ctx, 200)
 		if err != nil {
@@ -93,11 +116,30 @@ func main() {
 			log.Fatalf("materialize failed: %v", err)
 		}
 		log.Printf("materialize completed: upserted=%d", upserted)
+	case "translate":
+		if len(cfg.TranslationLanguages) == 0 {
+			log.Println("translate skipped: TRANSLATION_LANGUAGES is not set")
+			return
+		}
+		if !cfg.HasSummarizerCredentials() {
+			log.Fatalf("translate failed: no credentials for summarizer provider %q", cfg.SummarizerProvider)
+		}
+		settingsRepo := repository.NewSettingsRepository(database)
+		aiUsageRepo := repository.NewAIUsageRepository(database)
+		summarizer := services.NewSummarizer(cfg, settingsRepo, aiUsageRepo, nil)
+		translated, failed, err := services.NewTranslationService(docRepo, summarizer, cfg.TranslationLanguages).Translate(ctx, 50)
+		if err != nil {
+			log.Fatalf("translate failed: %v", err)
+		}
+		log.Printf("translate completed: translated=%d failed=%d", translated, failed)
 	case "pipeline":
-		if err := jobs.Pipeline(ctx); err != nil {
-			log.Fatalf("pipeline failed: %v", err)
+		m, err := jobs.Pipeline(ctx)
+		if err != nil {
+			log.Fatalf("pipeline failed: %v (agencies_synced=%d raw_inserted=%d raw_skipped=%d canonicalized=%d canonicalize_failed=%d deduped=%d would_enrich=%d materialized=%d)",
+				err, m.AgenciesSynced, m.RawInserted, m.RawSkipped, m.Canonicalized, m.CanonicalizeFailed, m.Deduped, m.Enriched, m.Materialized)
 		}
-		log.Println("pipeline completed")
+		log.Printf("pipeline completed: agencies_synced=%d raw_inserted=%d raw_skipped=%d canonicalized=%d canonicalize_failed=%d deduped=%d would_enrich=%d materialized=%d",
+			m.AgenciesSynced, m.RawInserted, m.RawSkipped, m.Canonicalized, m.CanonicalizeFailed, m.Deduped, m.Enriched, m.Materialized)
 	default:
 		log.Fatalf("unknown job: %q", *job)
 	}
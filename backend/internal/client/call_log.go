@@ -0,0 +1,115 @@
+package client
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ExternalCall is the outcome of one outbound HTTP request.
+type ExternalCall struct {
+	Service  string
+	Method   string
+	Endpoint string // host and path; the query string is dropped so keys never leak
+	Status   int    // 0 when no response was received
+	Latency  time.Duration
+	Error    string
+	At       time.Time
+}
+
+// DefaultCallLogSize is how many external calls the API keeps for /api/admin/stats/external.
+const DefaultCallLogSize = 100
+
+// CallLog keeps the most recent external calls in a fixed-size ring buffer. A nil
+// *CallLog records nothing, so clients can be built without one.
+type CallLog struct {
+	mu    sync.Mutex
+	calls []ExternalCall
+	next  int
+	full  bool
+}
+
+func NewCallLog(size int) *CallLog {
+	if size < 1 {
+		size = 1
+	}
+	return &CallLog{calls: make([]ExternalCall, size)}
+}
+
+func (l *CallLog) Record(call ExternalCall) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.calls[l.next] = call
+	l.next = (l.next + 1) % len(l.calls)
+	if l.next == 0 {
+		l.full = true
+	}
+}
+
+// Recent returns up to limit calls, newest first. limit <= 0 returns everything held.
+func (l *CallLog) Recent(limit int) []ExternalCall {
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	n := l.next
+	if l.full {
+		n = len(l.calls)
+	}
+	if limit <= 0 || limit > n {
+		limit = n
+	}
+
+	out := make([]ExternalCall, 0, limit)
+	for i := 1; i <= limit; i++ {
+		idx := (l.next - i + len(l.calls)) % len(l.calls)
+		out = append(out, l.calls[idx])
+	}
+	return out
+}
+
+// Transport wraps base (http.DefaultTransport when nil) so every round trip is recorded
+// under service. It returns base unchanged when l is nil.
+func (l *CallLog) Transport(service string, base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if l == nil {
+		return base
+	}
+	return &recordingTransport{log: l, service: service, base: base}
+}
+
+type recordingTransport struct {
+	log     *CallLog
+	service string
+	base    http.RoundTripper
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+
+	call := ExternalCall{
+		Service:  t.service,
+		Method:   req.Method,
+		Endpoint: req.URL.Host + req.URL.Path,
+		Latency:  time.Since(start),
+		At:       start.UTC(),
+	}
+	if resp != nil {
+		call.Status = resp.StatusCode
+	}
+	if err != nil {
+		call.Error = err.Error()
+	}
+	t.log.Record(call)
+
+	return resp, err
+}
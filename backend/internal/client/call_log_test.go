@@ -0,0 +1,96 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCallLog_RecordsThroughTransport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	calls := NewCallLog(10)
+	c := &http.Client{Transport: calls.Transport("federal_register", nil)}
+
+	for _, path := range []string{"/ok?api_key=secret", "/fail"} {
+		resp, err := c.Get(srv.URL + path)
+		if err != nil {
+			t.Fatalf("GET %s: %v", path, err)
+		}
+		resp.Body.Close()
+	}
+
+	got := calls.Recent(0)
+	if len(got) != 2 {
+		t.Fatalf("recorded %d calls, want 2", len(got))
+	}
+	if got[0].Status != http.StatusBadGateway || got[0].Endpoint != srv.Listener.Addr().String()+"/fail" {
+		t.Fatalf("newest call = %+v, want the /fail request", got[0])
+	}
+	if got[1].Status != http.StatusOK || got[1].Service != "federal_register" || got[1].Method != http.MethodGet {
+		t.Fatalf("oldest call = %+v", got[1])
+	}
+	if got[1].Endpoint != srv.Listener.Addr().String()+"/ok" {
+		t.Fatalf("endpoint %q should drop the query string", got[1].Endpoint)
+	}
+}
+
+type failingTransport struct{}
+
+func (failingTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestCallLog_RecordsTransportErrors(t *testing.T) {
+	calls := NewCallLog(10)
+	c := &http.Client{Transport: calls.Transport("xai", failingTransport{})}
+
+	if _, err := c.Get("http://api.example/v1/chat/completions"); err == nil {
+		t.Fatal("expected error")
+	}
+
+	got := calls.Recent(1)
+	if len(got) != 1 || got[0].Status != 0 || got[0].Error != "connection refused" {
+		t.Fatalf("recorded %+v", got)
+	}
+}
+
+func TestCallLog_RingKeepsNewest(t *testing.T) {
+	calls := NewCallLog(3)
+	for i := 1; i <= 5; i++ {
+		calls.Record(ExternalCall{Status: i})
+	}
+
+	got := calls.Recent(0)
+	want := []int{5, 4, 3}
+	if len(got) != len(want) {
+		t.Fatalf("got %d calls, want %d", len(got), len(want))
+	}
+	for i, status := range want {
+		if got[i].Status != status {
+			t.Fatalf("call %d status = %d, want %d", i, got[i].Status, status)
+		}
+	}
+	if got := calls.Recent(2); len(got) != 2 || got[0].Status != 5 {
+		t.Fatalf("Recent(2) = %+v", got)
+	}
+}
+
+func TestCallLog_NilIsNoop(t *testing.T) {
+	var calls *CallLog
+	calls.Record(ExternalCall{})
+	if got := calls.Recent(0); got != nil {
+		t.Fatalf("Recent() on nil log = %v", got)
+	}
+	if calls.Transport("x", nil) != http.DefaultTransport {
+		t.Fatal("nil log should not wrap the transport")
+	}
+}
@@ -0,0 +1,216 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alex/opengov-go/internal/config"
+	"github.com/alex/opengov-go/internal/timeformat"
+)
+
+// congressPageSize is the most bills the Congress.gov API returns per page.
+const congressPageSize = 250
+
+// CongressBill is a bill as listed by the Congress.gov /bill endpoint.
+type CongressBill struct {
+	Congress      int                 `json:"congress"`
+	Type          string              `json:"type"` // HR, S, HJRES, SJRES, HCONRES, SCONRES, HRES or SRES
+	Number        string              `json:"number"`
+	Title         string              `json:"title"`
+	OriginChamber string              `json:"originChamber"`
+	LatestAction  *CongressBillAction `json:"latestAction"`
+	UpdateDate    string              `json:"updateDate"`
+	URL           string              `json:"url"`
+}
+
+type CongressBillAction struct {
+	ActionDate string `json:"actionDate"`
+	Text       string `json:"text"`
+}
+
+// congressBillTypes maps a bill type to its congress.gov URL segment and the document
+// type it is stored as.
+var congressBillTypes = map[string]struct{ slug, docType string }{
+	"HR":      {"house-bill", "Bill"},
+	"S":       {"senate-bill", "Bill"},
+	"HJRES":   {"house-joint-resolution", "Joint Resolution"},
+	"SJRES":   {"senate-joint-resolution", "Joint Resolution"},
+	"HCONRES": {"house-concurrent-resolution", "Concurrent Resolution"},
+	"SCONRES": {"senate-concurrent-resolution", "Concurrent Resolution"},
+	"HRES":    {"house-resolution", "Simple Resolution"},
+	"SRES":    {"senate-resolution", "Simple Resolution"},
+}
+
+// ExternalID identifies the bill across congresses, e.g. "119-hr-1234".
+func (b CongressBill) ExternalID() string {
+	return fmt.Sprintf("%d-%s-%s", b.Congress, strings.ToLower(b.Type), b.Number)
+}
+
+// DocumentType is "Bill" or the kind of resolution.
+func (b CongressBill) DocumentType() string {
+	if t, ok := congressBillTypes[strings.ToUpper(b.Type)]; ok {
+		return t.docType
+	}
+	return "Bill"
+}
+
+// WebURL is the bill's public congress.gov page, e.g.
+// https://www.congress.gov/bill/119th-congress/house-bill/1234.
+func (b CongressBill) WebURL() string {
+	slug := strings.ToLower(b.Type)
+	if t, ok := congressBillTypes[strings.ToUpper(b.Type)]; ok {
+		slug = t.slug
+	}
+	return fmt.Sprintf("https://www.congress.gov/bill/%s-congress/%s/%s", ordinal(b.Congress), slug, b.Number)
+}
+
+// PublicationDate is the date of the bill's latest action, or of its last update when
+// no action is listed, as YYYY-MM-DD.
+func (b CongressBill) PublicationDate() string {
+	if b.LatestAction != nil && b.LatestAction.ActionDate != "" {
+		return b.LatestAction.ActionDate
+	}
+	date, _, _ := strings.Cut(b.UpdateDate, "T")
+	return date
+}
+
+func ordinal(n int) string {
+	suffix := "th"
+	if n%100 < 11 || n%100 > 13 {
+		switch n % 10 {
+		case 1:
+			suffix = "st"
+		case 2:
+			suffix = "nd"
+		case 3:
+			suffix = "rd"
+		}
+	}
+	return strconv.Itoa(n) + suffix
+}
+
+// CongressBillsResponse keeps each bill as raw JSON so it can be stored exactly as
+// returned before being decoded.
+type CongressBillsResponse struct {
+	Bills      []json.RawMessage `json:"bills"`
+	Pagination struct {
+		Count int    `json:"count"`
+		Next  string `json:"next"`
+	} `json:"pagination"`
+}
+
+type CongressBillWithRaw struct {
+	Bill    CongressBill
+	RawJSON []byte
+}
+
+type CongressClient struct {
+	baseURL  string
+	apiKey   string
+	maxPages int
+	loc      *time.Location
+	client   *http.Client
+}
+
+// NewCongressClient builds a client. calls may be nil; when set, every request is
+// recorded in it.
+func NewCongressClient(cfg *config.Config, calls *CallLog) *CongressClient {
+	return &CongressClient{
+		baseURL:  cfg.CongressAPIURL,
+		apiKey:   cfg.CongressAPIKey,
+		maxPages: cfg.CongressMaxPages,
+		loc:      cfg.PublicationLocation,
+		client: &http.Client{
+			Timeout:   time.Duration(cfg.CongressTimeout) * time.Second,
+			Transport: calls.Transport("congress", nil),
+		},
+	}
+}
+
+// Scrape fetches bills updated from since's calendar day through now, stopping after the
+// configured maximum number of pages.
+func (s *CongressClient) Scrape(ctx context.Context, since time.Time) ([]CongressBillWithRaw, error) {
+	return s.scrapeRange(ctx, since, time.Now(), s.maxPages)
+}
+
+// ScrapeRange fetches every bill updated between start's and end's calendar days, both
+// inclusive. Unlike Scrape it ignores the page limit, so callers must bound the range
+// themselves.
+func (s *CongressClient) ScrapeRange(ctx context.Context, start, end time.Time) ([]CongressBillWithRaw, error) {
+	return s.scrapeRange(ctx, start, end, 0)
+}
+
+// scrapeRange pages through the bill endpoint, oldest update first; maxPages <= 0 means
+// no limit. The API filters on update time, which it takes in UTC.
+func (s *CongressClient) scrapeRange(ctx context.Context, start, end time.Time, maxPages int) ([]CongressBillWithRaw, error) {
+	loc := s.loc
+	if loc == nil {
+		loc = time.UTC
+	}
+	from := start.In(loc).Format(timeformat.Date) + "T00:00:00Z"
+	to := end.In(loc).Format(timeformat.Date) + "T23:59:59Z"
+
+	params := url.Values{
+		"format":       {"json"},
+		"limit":        {strconv.Itoa(congressPageSize)},
+		"sort":         {"updateDate asc"},
+		"fromDateTime": {from},
+		"toDateTime":   {to},
+	}
+
+	var allBills []CongressBillWithRaw
+
+	for page := 0; maxPages <= 0 || page < maxPages; page++ {
+		params.Set("offset", strconv.Itoa(page*congressPageSize))
+
+		reqURL := fmt.Sprintf("%s/bill?%s", s.baseURL, params.Encode())
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+		// Sent as a header rather than api_key so it never appears in a logged URL.
+		req.Header.Set("X-Api-Key", s.apiKey)
+
+		resp, err := s.client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("request failed: %w", err)
+		}
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, string(bodyBytes))
+		}
+
+		var result CongressBillsResponse
+		if err := json.Unmarshal(bodyBytes, &result); err != nil {
+			return nil, fmt.Errorf("failed to decode response: %w", err)
+		}
+
+		for _, raw := range result.Bills {
+			var bill CongressBill
+			if err := json.Unmarshal(raw, &bill); err != nil {
+				return nil, fmt.Errorf("failed to decode bill: %w", err)
+			}
+			allBills = append(allBills, CongressBillWithRaw{
+				Bill:    bill,
+				RawJSON: raw,
+			})
+		}
+
+		if len(result.Bills) < congressPageSize || result.Pagination.Next == "" {
+			break
+		}
+
+		time.Sleep(500 * time.Millisecond)
+	}
+
+	return allBills, nil
+}
@@ -0,0 +1,84 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alex/opengov-go/internal/config"
+)
+
+func TestCongressScrape_SendsKeyAndKeepsRawJSON(t *testing.T) {
+	var gotKey string
+	var gotQuery []string
+	bill := `{"congress":119,"type":"SJRES","number":"7","title":"A joint resolution","updateDate":"2025-03-05"}`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotKey = r.Header.Get("X-Api-Key")
+		gotQuery = append(gotQuery, r.URL.RawQuery)
+		w.Write([]byte(`{"bills":[` + bill + `],"pagination":{"count":1}}`))
+	}))
+	defer srv.Close()
+
+	c := NewCongressClient(&config.Config{
+		CongressAPIURL:      srv.URL,
+		CongressAPIKey:      "secret",
+		CongressTimeout:     5,
+		CongressMaxPages:    2,
+		PublicationLocation: time.UTC,
+	}, nil)
+
+	bills, err := c.Scrape(t.Context(), time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Scrape() error: %v", err)
+	}
+	if gotKey != "secret" {
+		t.Fatalf("X-Api-Key = %q, want the configured key", gotKey)
+	}
+	if len(gotQuery) != 1 || strings.Contains(gotQuery[0], "secret") || !strings.Contains(gotQuery[0], "fromDateTime=2025-03-01T00%3A00%3A00Z") {
+		t.Fatalf("queries = %v, want one page from 2025-03-01 without the key", gotQuery)
+	}
+	if len(bills) != 1 || string(bills[0].RawJSON) != bill {
+		t.Fatalf("bills = %+v, want the upstream payload unchanged", bills)
+	}
+
+	b := bills[0].Bill
+	if b.ExternalID() != "119-sjres-7" || b.DocumentType() != "Joint Resolution" || b.PublicationDate() != "2025-03-05" {
+		t.Fatalf("external id %q, type %q, date %q", b.ExternalID(), b.DocumentType(), b.PublicationDate())
+	}
+	if want := "https://www.congress.gov/bill/119th-congress/senate-joint-resolution/7"; b.WebURL() != want {
+		t.Fatalf("WebURL() = %q, want %q", b.WebURL(), want)
+	}
+}
+
+func TestOrdinal(t *testing.T) {
+	for n, want := range map[int]string{1: "1st", 2: "2nd", 3: "3rd", 11: "11th", 112: "112th", 113: "113th", 101: "101st", 119: "119th", 122: "122nd"} {
+		if got := ordinal(n); got != want {
+			t.Errorf("ordinal(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
+
+func TestCongressScrape_StopsAtMaxPages(t *testing.T) {
+	pages := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pages++
+		bills := make([]string, congressPageSize)
+		for i := range bills {
+			bills[i] = fmt.Sprintf(`{"congress":119,"type":"HR","number":"%d"}`, pages*congressPageSize+i)
+		}
+		w.Write([]byte(`{"bills":[` + strings.Join(bills, ",") + `],"pagination":{"next":"more"}}`))
+	}))
+	defer srv.Close()
+
+	c := NewCongressClient(&config.Config{CongressAPIURL: srv.URL, CongressTimeout: 5, CongressMaxPages: 1}, nil)
+	bills, err := c.Scrape(t.Context(), time.Now())
+	if err != nil {
+		t.Fatalf("Scrape() error: %v", err)
+	}
+	if pages != 1 || len(bills) != congressPageSize {
+		t.Fatalf("fetched %d pages and %d bills, want 1 page of %d", pages, len(bills), congressPageSize)
+	}
+}
@@ -3,6 +3,7 @@ package client
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -10,6 +11,7 @@ import (
 	"time"
 
 	"github.com/alex/opengov-go/internal/config"
+	"github.com/alex/opengov-go/internal/timeformat"
 )
 
 type FederalRegisterDocument struct {
@@ -24,6 +26,13 @@ type FederalRegisterDocument struct {
 	PublicInspectionPDFURL *string    `json:"public_inspection_pdf_url"`
 	Excerpts               *string    `json:"excerpts"`
 	Agencies               []FRAgency `json:"agencies"`
+	CFRReferences          []FRCFRRef `json:"cfr_references"`
+}
+
+// FRCFRRef is one Code of Federal Regulations title/part a document affects.
+type FRCFRRef struct {
+	Title int `json:"title"`
+	Part  int `json:"part"`
 }
 
 type FRAgency struct {
@@ -38,12 +47,14 @@ type FRAgency struct {
 	JSONURL     string  `json:"json_url"`
 }
 
+// FederalRegisterRecordsResponse keeps each result as raw JSON so it can be stored
+// exactly as returned before being decoded.
 type FederalRegisterRecordsResponse struct {
-	Description string                    `json:"description"`
-	Count       int                       `json:"count"`
-	TotalPages  int                       `json:"total_pages"`
-	NextPageURL string                    `json:"next_page_url,omitempty"`
-	Results     []FederalRegisterDocument `json:"results"`
+	Description string            `json:"description"`
+	Count       int               `json:"count"`
+	TotalPages  int               `json:"total_pages"`
+	NextPageURL string            `json:"next_page_url,omitempty"`
+	Results     []json.RawMessage `json:"results"`
 }
 
 type FederalRegisterDocumentWithRaw struct {
@@ -53,70 +64,97 @@ type FederalRegisterDocumentWithRaw struct {
 
 type FRAgenciesResponse []FRAgency
 
+// ErrUpstreamTimeout reports that the Federal Register API did not answer within the
+// client's per-request timeout. When the caller's context ends first, the error wraps
+// that context's error instead.
+var ErrUpstreamTimeout = errors.New("federal register request timed out")
+
+// FederalRegisterClient has no client-wide timeout: each request gets its own, derived
+// from the caller's context, so cancelling a scrape stops it mid-request.
 type FederalRegisterClient struct {
 	baseURL  string
+	fields   []string
 	timeout  time.Duration
 	perPage  int
 	maxPages int
+	loc      *time.Location
 	client   *http.Client
 }
 
-func NewFederalRegisterClient(cfg *config.Config) *FederalRegisterClient {
+// NewFederalRegisterClient builds a client. calls may be nil; when set, every request is
+// recorded in it.
+func NewFederalRegisterClient(cfg *config.Config, calls *CallLog) *FederalRegisterClient {
 	return &FederalRegisterClient{
 		baseURL:  cfg.FederalRegisterAPIURL,
+		fields:   cfg.FederalRegisterFields,
 		timeout:  time.Duration(cfg.FederalRegisterTimeout) * time.Second,
 		perPage:  cfg.FederalRegisterPerPage,
 		maxPages: cfg.FederalRegisterMaxPages,
+		loc:      cfg.PublicationLocation,
 		client: &http.Client{
-			Timeout: time.Duration(cfg.FederalRegisterTimeout) * time.Second,
+			Transport: calls.Transport("federal_register", nil),
 		},
 	}
 }
 
-func (s *FederalRegisterClient) Scrape(ctx context.Context, days int) ([]FederalRegisterDocumentWithRaw, error) {
-	endDate := time.Now().UTC()
-	startDate := endDate.AddDate(0, 0, -days)
+// Scrape fetches documents published from since's calendar day through today, both
+// inclusive, stopping after the configured maximum number of pages.
+func (s *FederalRegisterClient) Scrape(ctx context.Context, since time.Time) ([]FederalRegisterDocumentWithRaw, error) {
+	return s.scrapeRange(ctx, since, time.Now(), s.maxPages)
+}
+
+// ScrapeRange fetches every document published between start's and end's calendar
+// days, both inclusive. Unlike Scrape it ignores the page limit, so callers must bound
+// the range themselves.
+func (s *FederalRegisterClient) ScrapeRange(ctx context.Context, start, end time.Time) ([]FederalRegisterDocumentWithRaw, error) {
+	return s.scrapeRange(ctx, start, end, 0)
+}
+
+// scrapeRange pages through the documents endpoint; maxPages <= 0 means no limit.
+func (s *FederalRegisterClient) scrapeRange(ctx context.Context, start, end time.Time, maxPages int) ([]FederalRegisterDocumentWithRaw, error) {
+	// Publication dates are days in the source's timezone, so dates are taken there.
+	loc := s.loc
+	if loc == nil {
+		loc = time.UTC
+	}
+	startDate := start.In(loc)
+	endDate := end.In(loc)
 
 	params := url.Values{
 		"per_page":                      {fmt.Sprintf("%d", s.perPage)},
 		"page":                          {"1"},
-		"filter[publication_date][gte]": {startDate.Format("2006-01-02")},
-		"filter[publication_date][lte]": {endDate.Format("2006-01-02")},
+		"filter[publication_date][gte]": {startDate.Format(timeformat.Date)},
+		"filter[publication_date][lte]": {endDate.Format(timeformat.Date)},
+		"fields[]":                      s.fields,
 	}
 
 	var allDocs []FederalRegisterDocumentWithRaw
 
-	for page := 1; page <= s.maxPages; page++ {
+	for page := 1; maxPages <= 0 || page <= maxPages; page++ {
 		params.Set("page", fmt.Sprintf("%d", page))
 
 		reqURL := fmt.Sprintf("%s/documents?%s", s.baseURL, params.Encode())
-		req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+		status, bodyBytes, err := s.get(ctx, reqURL)
 		if err != nil {
-			return nil, fmt.Errorf("failed to create request: %w", err)
+			return nil, err
 		}
-
-		resp, err := s.client.Do(req)
-		if err != nil {
-			return nil, fmt.Errorf("request failed: %w", err)
+		if status != http.StatusOK {
+			return nil, fmt.Errorf("unexpected status %d: %s", status, string(bodyBytes))
 		}
-		defer resp.Body.Close()
 
-		if resp.StatusCode != http.StatusOK {
-			body, _ := io.ReadAll(resp.Body)
-			return nil, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, string(body))
-		}
-
-		bodyBytes, _ := io.ReadAll(resp.Body)
 		var result FederalRegisterRecordsResponse
 		if err := json.Unmarshal(bodyBytes, &result); err != nil {
 			return nil, fmt.Errorf("failed to decode response: %w", err)
 		}
 
-		for _, frDoc := range result.Results {
-			docRaw, _ := json.Marshal(frDoc)
+		for _, raw := range result.Results {
+			var frDoc FederalRegisterDocument
+			if err := json.Unmarshal(raw, &frDoc); err != nil {
+				return nil, fmt.Errorf("failed to decode document: %w", err)
+			}
 			allDocs = append(allDocs, FederalRegisterDocumentWithRaw{
 				Document: frDoc,
-				RawJSON:  docRaw,
+				RawJSON:  raw,
 			})
 		}
 
@@ -124,7 +162,11 @@ func (s *FederalRegisterClient) Scrape(ctx context.Context, days int) ([]Federal
 			break
 		}
 
-		time.Sleep(500 * time.Millisecond)
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("request canceled: %w", ctx.Err())
+		case <-time.After(500 * time.Millisecond):
+		}
 	}
 
 	return allDocs, nil
@@ -132,26 +174,49 @@ func (s *FederalRegisterClient) Scrape(ctx context.Context, days int) ([]Federal
 
 func (s *FederalRegisterClient) FetchAgencies(ctx context.Context) ([]FRAgency, error) {
 	reqURL := fmt.Sprintf("%s/agencies", s.baseURL)
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	resp, err := s.client.Do(req)
+	status, body, err := s.get(ctx, reqURL)
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, string(body))
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d: %s", status, string(body))
 	}
 
 	var result FRAgenciesResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+	if err := json.Unmarshal(body, &result); err != nil {
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
 	return result, nil
 }
+
+// get fetches reqURL and reads the whole body under a per-request timeout derived from
+// ctx. The body is closed before returning, so paging loops never hold a connection
+// across pages.
+func (s *FederalRegisterClient) get(ctx context.Context, reqURL string) (status int, body []byte, err error) {
+	reqCtx, cancel := ctx, context.CancelFunc(func() {})
+	if s.timeout > 0 {
+		reqCtx, cancel = context.WithTimeout(ctx, s.timeout)
+	}
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := s.client.Do(req)
+	if err == nil {
+		body, err = io.ReadAll(resp.Body)
+		resp.Body.Close()
+	}
+	switch {
+	case err == nil:
+		return resp.StatusCode, body, nil
+	case ctx.Err() != nil:
+		return 0, nil, fmt.Errorf("request canceled: %w", ctx.Err())
+	case errors.Is(reqCtx.Err(), context.DeadlineExceeded):
+		return 0, nil, fmt.Errorf("%w after %s", ErrUpstreamTimeout, s.timeout)
+	}
+	return 0, nil, fmt.Errorf("request failed: %w", err)
+}
@@ -0,0 +1,183 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/alex/opengov-go/internal/config"
+)
+
+func TestScrape_RequestsConfiguredFieldsAndKeepsRawJSON(t *testing.T) {
+	var gotFields []string
+	doc := `{"document_number":"2025-01234","title":"Ozone","type":"Rule","html_url":"https://example","publication_date":"2025-03-03"}`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotFields = r.URL.Query()["fields[]"]
+		w.Write([]byte(`{"count":1,"results":[` + doc + `]}`))
+	}))
+	defer srv.Close()
+
+	fields := []string{"document_number", "html_url", "publication_date", "title", "type"}
+	c := NewFederalRegisterClient(&config.Config{
+		FederalRegisterAPIURL:   srv.URL,
+		FederalRegisterFields:   fields,
+		FederalRegisterTimeout:  5,
+		FederalRegisterPerPage:  100,
+		FederalRegisterMaxPages: 1,
+		PublicationLocation:     time.UTC,
+	}, nil)
+
+	docs, err := c.Scrape(t.Context(), time.Now().AddDate(0, 0, -1))
+	if err != nil {
+		t.Fatalf("Scrape() error: %v", err)
+	}
+	if !reflect.DeepEqual(gotFields, fields) {
+		t.Fatalf("fields[] = %v, want %v", gotFields, fields)
+	}
+	if len(docs) != 1 {
+		t.Fatalf("got %d documents, want 1", len(docs))
+	}
+	if string(docs[0].RawJSON) != doc {
+		t.Fatalf("RawJSON = %s, want the upstream payload unchanged", docs[0].RawJSON)
+	}
+	if docs[0].Document.DocumentNumber != "2025-01234" {
+		t.Fatalf("DocumentNumber = %q", docs[0].Document.DocumentNumber)
+	}
+}
+
+// Weekends and holidays come back as a successful page with no results.
+func TestScrape_EmptyResults(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"count":0,"description":"Documents published on 03/08/2025","results":[]}`))
+	}))
+	defer srv.Close()
+
+	c := NewFederalRegisterClient(&config.Config{
+		FederalRegisterAPIURL:   srv.URL,
+		FederalRegisterTimeout:  5,
+		FederalRegisterPerPage:  100,
+		FederalRegisterMaxPages: 3,
+		PublicationLocation:     time.UTC,
+	}, nil)
+
+	docs, err := c.Scrape(t.Context(), time.Now())
+	if err != nil {
+		t.Fatalf("Scrape() error: %v", err)
+	}
+	if len(docs) != 0 || requests != 1 {
+		t.Fatalf("got %d documents over %d requests, want 0 over 1", len(docs), requests)
+	}
+}
+
+// bodyTracker counts response bodies handed out and closed, and records how many were
+// still open each time a new request started.
+type bodyTracker struct {
+	next          http.RoundTripper
+	opened        int
+	closed        int
+	openAtRequest []int
+}
+
+func (b *bodyTracker) RoundTrip(req *http.Request) (*http.Response, error) {
+	b.openAtRequest = append(b.openAtRequest, b.opened-b.closed)
+	resp, err := b.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	b.opened++
+	resp.Body = trackedBody{resp.Body, b}
+	return resp, nil
+}
+
+type trackedBody struct {
+	io.ReadCloser
+	b *bodyTracker
+}
+
+func (t trackedBody) Close() error {
+	t.b.closed++
+	return t.ReadCloser.Close()
+}
+
+func TestScrape_ClosesEachPageBody(t *testing.T) {
+	doc := `{"document_number":"2025-01234","title":"Ozone","type":"Rule","html_url":"https://example","publication_date":"2025-03-03"}`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"count":3,"results":[` + doc + `]}`))
+	}))
+	defer srv.Close()
+
+	c := NewFederalRegisterClient(&config.Config{
+		FederalRegisterAPIURL:   srv.URL,
+		FederalRegisterTimeout:  5,
+		FederalRegisterPerPage:  1,
+		FederalRegisterMaxPages: 3,
+		PublicationLocation:     time.UTC,
+	}, nil)
+	tracker := &bodyTracker{next: http.DefaultTransport}
+	c.client.Transport = tracker
+
+	docs, err := c.Scrape(t.Context(), time.Now())
+	if err != nil {
+		t.Fatalf("Scrape() error: %v", err)
+	}
+	if len(docs) != 3 {
+		t.Fatalf("got %d documents, want 3", len(docs))
+	}
+	if want := []int{0, 0, 0}; !reflect.DeepEqual(tracker.openAtRequest, want) {
+		t.Fatalf("open bodies when each page was requested = %v, want %v", tracker.openAtRequest, want)
+	}
+	if tracker.closed != tracker.opened {
+		t.Fatalf("closed %d of %d bodies", tracker.closed, tracker.opened)
+	}
+}
+
+// hangingServer accepts requests and never answers them until the client gives up.
+func hangingServer(t *testing.T) *FederalRegisterClient {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	t.Cleanup(srv.Close)
+
+	return NewFederalRegisterClient(&config.Config{
+		FederalRegisterAPIURL:   srv.URL,
+		FederalRegisterTimeout:  30,
+		FederalRegisterPerPage:  100,
+		FederalRegisterMaxPages: 1,
+		PublicationLocation:     time.UTC,
+	}, nil)
+}
+
+func TestScrape_CallerDeadlineCancelsRequest(t *testing.T) {
+	c := hangingServer(t)
+	ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := c.Scrape(ctx, time.Now())
+	if !errors.Is(err, context.DeadlineExceeded) || errors.Is(err, ErrUpstreamTimeout) {
+		t.Fatalf("Scrape() error = %v, want the caller's deadline", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("Scrape() took %s after the caller's deadline", elapsed)
+	}
+}
+
+func TestScrape_PerRequestTimeout(t *testing.T) {
+	c := hangingServer(t)
+	c.timeout = 50 * time.Millisecond
+
+	_, err := c.Scrape(t.Context(), time.Now())
+	if !errors.Is(err, ErrUpstreamTimeout) {
+		t.Fatalf("Scrape() error = %v, want ErrUpstreamTimeout", err)
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Scrape() error = %v should not look like the caller's deadline", err)
+	}
+}
@@ -2,24 +2,101 @@ package config
 
 import (
 	"fmt"
+	"log"
+	"maps"
 	"net"
 	"net/url"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
+	_ "time/tzdata" // PUBLICATION_TIMEZONE must resolve even without system zoneinfo
+
+	"github.com/alex/opengov-go/internal/constants"
+)
+
+// Summarizer providers accepted by SUMMARIZER_PROVIDER.
+const (
+	SummarizerProviderXAI    = "xai"
+	SummarizerProviderOpenAI = "openai"
+	SummarizerProviderMock   = "mock"
+)
+
+// Enrichment modes accepted by ENRICH_MODE.
+const (
+	EnrichModeAll       = "all"
+	EnrichModeSelective = "selective"
 )
 
+// DatabaseDriverPostgres is the only driver accepted by DB_DRIVER. Every repository
+// and migration is written for Postgres ($N placeholders, JSONB, ON CONFLICT), and both
+// cmd/api and cmd/jobs open the database through db.New, so there is no SQLite path.
+const DatabaseDriverPostgres = "postgres"
+
+// FederalRegisterRequiredFields must be in FEDERAL_REGISTER_FIELDS because
+// canonicalization cannot build a document without them.
+var FederalRegisterRequiredFields = []string{"document_number", "html_url", "publication_date", "title", "type"}
+
+// defaultFederalRegisterFields are the fields the pipeline reads from each document.
+var defaultFederalRegisterFields = []string{
+	"abstract", "agencies", "cfr_references", "document_number", "excerpts", "html_url",
+	"pdf_url", "public_inspection_pdf_url", "publication_date", "title", "type",
+}
+
+// FederalRegisterMaxPerPage is the largest per_page the Federal Register API accepts.
+const FederalRegisterMaxPerPage = 1000
+
 type Config struct {
 	// API Keys
-	GrokAPIKey string
+	GrokAPIKey   string
+	OpenAIAPIKey string
+	// CongressAPIKey enables the Congress.gov bill scraper; it is skipped when empty.
+	CongressAPIKey string
 
 	// External APIs
 	FederalRegisterAPIURL string
+	// FederalRegisterFields are the fields[] requested from the documents search; the
+	// API returns only these, and raw_policy_documents stores exactly what it returns.
+	FederalRegisterFields []string
+	CongressAPIURL        string
 	GrokAPIURL            string
 	GrokModel             string
+	OpenAIAPIURL          string
+	OpenAIModel           string
+
+	// SummarizerProvider selects the AI backend: xai, openai or mock.
+	SummarizerProvider string
+
+	// EnrichMode "selective" limits full AI analysis to documents whose type is in
+	// EnrichAnalyzeTypes; the rest get the fallback summary. "all" analyzes everything.
+	EnrichMode         string
+	EnrichAnalyzeTypes []string
+
+	// After BreakerThreshold consecutive summarizer failures, AI calls are skipped (and
+	// the fallback summary used) for BreakerCooldown. A threshold of 0 disables it.
+	BreakerThreshold int
+	BreakerCooldown  time.Duration
+
+	// ReprocessInterval is the pause between analyses when an admin reprocesses documents
+	// in bulk, keeping the run under the AI provider's rate limits. 0 does not pause.
+	ReprocessInterval time.Duration
+
+	// FallbackSummaryTemplate renders the placeholder summary written at ingest and kept
+	// when AI analysis fails.
+	// Supports {agency}, {type} and {title} placeholders.
+	FallbackSummaryTemplate string
+
+	// TranslationLanguages lists the language codes (see constants.Languages) the
+	// translate job generates summaries in. Empty disables translation.
+	TranslationLanguages []string
+
+	// SummaryMaxChars caps AI summaries. Longer ones are cut back to the last whole
+	// sentence that fits, or the last whole word when no sentence does.
+	SummaryMaxChars int
 
 	// Database
+	DatabaseDriver string
 	DatabaseURLEnv string // Direct URL from DB_URL env var
 	DatabaseHost   string
 	DatabasePort   string
@@ -28,29 +105,70 @@ type Config struct {
 	DatabaseName   string
 	DatabaseSSL    string
 
+	// Connection pool. The feed page fans out several queries per request, so the pool
+	// has to be sized for concurrent requests times that fan-out.
+	DBMaxOpenConns    int
+	DBMaxIdleConns    int
+	DBConnMaxLifetime time.Duration
+
+	// PublicationTimezone is the timezone publication dates are issued in. Bare dates
+	// from the source are read as midnight here, and day/month boundaries use it too.
+	PublicationTimezone string
+	PublicationLocation *time.Location
+
 	// Scraper settings
 	ScraperIntervalMinutes int
 	ScraperDaysLookback    int
+	ScraperCooldownMinutes int // minimum gap between admin-triggered scrapes
+	ScraperStaleMinutes    int // /health/scraper fails past this; 0 means 2x the interval
+	BackfillMaxDays        int // longest date range an admin backfill may cover
 
 	// CORS
-	CORSEnabled    bool
+	CORSEnabled bool
+	// AllowedOrigins are exact origins, or patterns with one "*" in the host standing
+	// for a single DNS label, like https://*.vercel.app.
 	AllowedOrigins []string
 
 	// Timeouts (seconds)
 	FederalRegisterTimeout int
+	CongressTimeout        int
 	GrokTimeout            int
+	ShutdownTimeout        int // grace period for in-flight requests on SIGTERM
+	BodyReadTimeout        int // max time to read a request body, independent of handler time
 
 	// Limits
 	MaxRequestSizeBytes     int
 	FederalRegisterPerPage  int
 	FederalRegisterMaxPages int
+	CongressMaxPages        int // pages of bills per scrape; backfills ignore it
+	AdminMaxKeypoints       int // most keypoints an admin edit may set
+	AdminKeypointMaxChars   int // longest keypoint an admin edit may set, in characters
+
+	// FeedCacheTTL is how long an anonymous feed page is served from memory. 0 disables
+	// the cache.
+	FeedCacheTTL time.Duration
+	// IdempotencyTTL is how long a like or bookmark response is kept for replay to a
+	// retry with the same Idempotency-Key. 0 disables replay.
+	IdempotencyTTL time.Duration
+	// RequestTimeout bounds how long an API handler may run before it gets a 503.
+	// LongRequestTimeout replaces it for routes expected to run long: the streaming
+	// exports and single-document AI reprocessing. 0 disables either.
+	RequestTimeout     time.Duration
+	LongRequestTimeout time.Duration
 
 	// Environment
 	Debug       bool
 	Environment string
 	BehindProxy bool
-	UseMockGrok bool
-	Port        string
+	// TrustedProxies are the IPs and CIDRs whose X-Forwarded-For is believed when
+	// BehindProxy is set. Otherwise no proxy is trusted and the client IP is the peer.
+	TrustedProxies []string
+	UseMockGrok    bool
+	Port           string
+
+	// FeatureFlags seeds runtime feature flags. FEATURE_FLAGS="personalization=false"
+	// overrides the defaults.
+	FeatureFlags map[string]bool
 
 	// Authentication Security
 	CookieSecure bool
@@ -66,6 +184,10 @@ type Config struct {
 
 	// Frontend URL
 	FrontendURL string
+	// OAuthSuccessPath and OAuthErrorPath are the frontend pages OAuth redirects to. They
+	// must be relative paths so a redirect can never leave FrontendURL.
+	OAuthSuccessPath string
+	OAuthErrorPath   string
 }
 
 func parseBool(v string) bool {
@@ -73,28 +195,88 @@ func parseBool(v string) bool {
 	return l == "true" || l == "1" || l == "t" || l == "yes"
 }
 
+// parseFeatureFlags reads "name=bool" pairs separated by commas. A bare name is enabled.
+func parseFeatureFlags(v string) map[string]bool {
+	flags := map[string]bool{}
+	for _, part := range strings.Split(v, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, value, hasValue := strings.Cut(part, "=")
+		name = strings.ToLower(strings.TrimSpace(name))
+		flags[name] = !hasValue || parseBool(value)
+	}
+	return flags
+}
+
+// validateRelativePath rejects anything but a plain absolute path such as "/login". Hosts,
+// schemes, protocol-relative "//host" forms, queries and fragments are all refused so the
+// value cannot be used for an open redirect.
+func validateRelativePath(name, p string) error {
+	u, err := url.Parse(p)
+	if err != nil || u.Scheme != "" || u.Host != "" || u.User != nil ||
+		!strings.HasPrefix(p, "/") || strings.HasPrefix(p, "//") || strings.Contains(p, "\\") ||
+		u.RawQuery != "" || u.Fragment != "" {
+		return fmt.Errorf("%s must be a relative path starting with /, got %q", name, p)
+	}
+	return nil
+}
+
 func Load() (*Config, error) {
 	c := &Config{
 		// Defaults
 		FederalRegisterAPIURL:   "https://www.federalregister.gov/api/v1",
+		CongressAPIURL:          "https://api.congress.gov/v3",
+		FederalRegisterFields:   slices.Clone(defaultFederalRegisterFields),
 		GrokAPIURL:              "https://api.x.ai/v1",
+		OpenAIAPIURL:            "https://api.openai.com/v1",
+		OpenAIModel:             "gpt-4o-mini",
+		DatabaseDriver:          DatabaseDriverPostgres,
+		DBMaxOpenConns:          25,
+		DBMaxIdleConns:          5,
+		DBConnMaxLifetime:       5 * time.Minute,
+		SummarizerProvider:      SummarizerProviderXAI,
+		EnrichMode:              EnrichModeAll,
+		EnrichAnalyzeTypes:      []string{"Rule", "Proposed Rule", "Presidential Document"},
+		BreakerThreshold:        5,
+		BreakerCooldown:         time.Minute,
+		ReprocessInterval:       time.Second,
+		PublicationTimezone:     "America/New_York",
 		ScraperIntervalMinutes:  15,
 		ScraperDaysLookback:     1,
+		ScraperCooldownMinutes:  10,
+		BackfillMaxDays:         366,
 		CORSEnabled:             true,
 		AllowedOrigins:          []string{"http://localhost:5173", "http://localhost:3000"},
 		FederalRegisterTimeout:  30,
+		CongressTimeout:         30,
 		GrokTimeout:             60,
+		ShutdownTimeout:         15,
+		BodyReadTimeout:         10,
 		MaxRequestSizeBytes:     10 * 1024 * 1024, // 10 MB
 		FederalRegisterPerPage:  100,
 		FederalRegisterMaxPages: 2,
+		CongressMaxPages:        2,
+		AdminMaxKeypoints:       10,
+		AdminKeypointMaxChars:   300,
+		FeedCacheTTL:            30 * time.Second,
+		IdempotencyTTL:          10 * time.Minute,
+		RequestTimeout:          30 * time.Second,
+		LongRequestTimeout:      5 * time.Minute,
 		Debug:                   false,
 		Environment:             "development",
 		BehindProxy:             false,
+		TrustedProxies:          []string{"127.0.0.0/8", "10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16", "::1/128", "fc00::/7"},
 		UseMockGrok:             false,
 		CookieSecure:            false,
 		JWTAccessTokenExpireMin: 60,
 		FrontendURL:             "http://localhost:5173",
+		OAuthSuccessPath:        "/auth/callback",
+		OAuthErrorPath:          "/login",
 		GrokModel:               "grok-4-1-fast-non-reasoning",
+		FallbackSummaryTemplate: "{agency} issued {type}: {title}. Read more.",
+		SummaryMaxChars:         280,
 		Port:                    "8000",
 	}
 
@@ -107,10 +289,36 @@ func Load() (*Config, error) {
 		c.GrokAPIURL = v
 	}
 
+	if v := os.Getenv("OPENAI_API_KEY"); v != "" {
+		c.OpenAIAPIKey = v
+	}
+
+	if v := os.Getenv("OPENAI_API_URL"); v != "" {
+		c.OpenAIAPIURL = v
+	}
+
+	if v := os.Getenv("OPENAI_MODEL"); v != "" {
+		c.OpenAIModel = v
+	}
+
 	if v := os.Getenv("FEDERAL_REGISTER_API_URL"); v != "" {
 		c.FederalRegisterAPIURL = v
 	}
 
+	if v := os.Getenv("CONGRESS_API_KEY"); v != "" {
+		c.CongressAPIKey = v
+	}
+	if v := os.Getenv("CONGRESS_API_URL"); v != "" {
+		c.CongressAPIURL = v
+	}
+
+	if v := os.Getenv("DB_DRIVER"); v != "" {
+		c.DatabaseDriver = strings.ToLower(strings.TrimSpace(v))
+	}
+	if c.DatabaseDriver != DatabaseDriverPostgres {
+		return nil, fmt.Errorf("unsupported DB_DRIVER %q (only postgres is supported)", c.DatabaseDriver)
+	}
+
 	// Database URL (takes precedence if set)
 	if v := os.Getenv("DB_URL"); v != "" {
 		c.DatabaseURLEnv = v
@@ -151,6 +359,39 @@ func Load() (*Config, error) {
 		c.DatabaseSSL = "disable"
 	}
 
+	if v := os.Getenv("DB_MAX_OPEN_CONNS"); v != "" {
+		if iv, err := strconv.Atoi(v); err == nil && iv > 0 {
+			c.DBMaxOpenConns = iv
+		}
+	}
+
+	if v := os.Getenv("DB_MAX_IDLE_CONNS"); v != "" {
+		if iv, err := strconv.Atoi(v); err == nil && iv >= 0 {
+			c.DBMaxIdleConns = iv
+		}
+	}
+	if c.DBMaxIdleConns > c.DBMaxOpenConns {
+		c.DBMaxIdleConns = c.DBMaxOpenConns
+	}
+
+	if v := os.Getenv("DB_CONN_MAX_LIFETIME"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d < 0 {
+			return nil, fmt.Errorf("invalid DB_CONN_MAX_LIFETIME %q (want a duration such as 5m)", v)
+		}
+		c.DBConnMaxLifetime = d
+	}
+
+	if v := os.Getenv("PUBLICATION_TIMEZONE"); v != "" {
+		c.PublicationTimezone = v
+	}
+
+	loc, err := time.LoadLocation(c.PublicationTimezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid PUBLICATION_TIMEZONE %q: %w", c.PublicationTimezone, err)
+	}
+	c.PublicationLocation = loc
+
 	if v := os.Getenv("SCRAPER_INTERVAL_MINUTES"); v != "" {
 		if iv, err := strconv.Atoi(v); err == nil {
 			c.ScraperIntervalMinutes = iv
@@ -163,12 +404,44 @@ func Load() (*Config, error) {
 		}
 	}
 
+	if v := os.Getenv("SCRAPER_COOLDOWN_MINUTES"); v != "" {
+		if iv, err := strconv.Atoi(v); err == nil {
+			c.ScraperCooldownMinutes = iv
+		}
+	}
+
+	if v := os.Getenv("BACKFILL_MAX_DAYS"); v != "" {
+		if iv, err := strconv.Atoi(v); err == nil {
+			c.BackfillMaxDays = iv
+		}
+	}
+
+	if v := os.Getenv("SCRAPER_STALE_MINUTES"); v != "" {
+		if iv, err := strconv.Atoi(v); err == nil {
+			c.ScraperStaleMinutes = iv
+		}
+	}
+
 	if v := os.Getenv("CORS_ENABLED"); v != "" {
 		c.CORSEnabled = parseBool(v)
 	}
 
 	if v := os.Getenv("ALLOWED_ORIGINS"); v != "" {
-		c.AllowedOrigins = strings.Split(v, ",")
+		c.AllowedOrigins = nil
+		for _, o := range strings.Split(v, ",") {
+			if o = strings.TrimSpace(o); o != "" {
+				c.AllowedOrigins = append(c.AllowedOrigins, o)
+			}
+		}
+	}
+	for _, o := range c.AllowedOrigins {
+		if !strings.Contains(o, "*") {
+			continue
+		}
+		_, host, ok := strings.Cut(o, "://")
+		if !ok || strings.Count(o, "*") != 1 || !strings.Contains(host, "*") || strings.Contains(host, "/") {
+			return nil, fmt.Errorf("invalid ALLOWED_ORIGINS pattern %q (want one * in the host, such as https://*.example.com)", o)
+		}
 	}
 
 	if v := os.Getenv("FEDERAL_REGISTER_TIMEOUT"); v != "" {
@@ -177,12 +450,74 @@ func Load() (*Config, error) {
 		}
 	}
 
+	if v := os.Getenv("CONGRESS_TIMEOUT"); v != "" {
+		if iv, err := strconv.Atoi(v); err == nil {
+			c.CongressTimeout = iv
+		}
+	}
+
 	if v := os.Getenv("GROK_TIMEOUT"); v != "" {
 		if iv, err := strconv.Atoi(v); err == nil {
 			c.GrokTimeout = iv
 		}
 	}
 
+	if v := os.Getenv("SHUTDOWN_TIMEOUT"); v != "" {
+		if iv, err := strconv.Atoi(v); err == nil {
+			c.ShutdownTimeout = iv
+		}
+	}
+
+	if v := os.Getenv("BODY_READ_TIMEOUT"); v != "" {
+		if iv, err := strconv.Atoi(v); err == nil {
+			c.BodyReadTimeout = iv
+		}
+	}
+
+	if v := os.Getenv("ADMIN_MAX_KEYPOINTS"); v != "" {
+		if iv, err := strconv.Atoi(v); err == nil && iv > 0 {
+			c.AdminMaxKeypoints = iv
+		}
+	}
+
+	if v := os.Getenv("ADMIN_KEYPOINT_MAX_CHARS"); v != "" {
+		if iv, err := strconv.Atoi(v); err == nil && iv > 0 {
+			c.AdminKeypointMaxChars = iv
+		}
+	}
+
+	if v := os.Getenv("FEED_CACHE_TTL"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d < 0 {
+			return nil, fmt.Errorf("invalid FEED_CACHE_TTL %q (want a duration such as 30s)", v)
+		}
+		c.FeedCacheTTL = d
+	}
+
+	if v := os.Getenv("IDEMPOTENCY_TTL"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d < 0 {
+			return nil, fmt.Errorf("invalid IDEMPOTENCY_TTL %q (want a duration such as 10m)", v)
+		}
+		c.IdempotencyTTL = d
+	}
+
+	if v := os.Getenv("REQUEST_TIMEOUT"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d < 0 {
+			return nil, fmt.Errorf("invalid REQUEST_TIMEOUT %q (want a duration such as 30s)", v)
+		}
+		c.RequestTimeout = d
+	}
+
+	if v := os.Getenv("LONG_REQUEST_TIMEOUT"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d < 0 {
+			return nil, fmt.Errorf("invalid LONG_REQUEST_TIMEOUT %q (want a duration such as 5m)", v)
+		}
+		c.LongRequestTimeout = d
+	}
+
 	if v := os.Getenv("MAX_REQUEST_SIZE_BYTES"); v != "" {
 		if iv, err := strconv.Atoi(v); err == nil {
 			c.MaxRequestSizeBytes = iv
@@ -195,12 +530,37 @@ func Load() (*Config, error) {
 		}
 	}
 
+	if c.FederalRegisterPerPage > FederalRegisterMaxPerPage {
+		log.Printf("WARNING: FEDERAL_REGISTER_PER_PAGE=%d exceeds API maximum; clamping to %d", c.FederalRegisterPerPage, FederalRegisterMaxPerPage)
+		c.FederalRegisterPerPage = FederalRegisterMaxPerPage
+	}
+
 	if v := os.Getenv("FEDERAL_REGISTER_MAX_PAGES"); v != "" {
 		if iv, err := strconv.Atoi(v); err == nil {
 			c.FederalRegisterMaxPages = iv
 		}
 	}
 
+	if v := os.Getenv("CONGRESS_MAX_PAGES"); v != "" {
+		if iv, err := strconv.Atoi(v); err == nil {
+			c.CongressMaxPages = iv
+		}
+	}
+
+	if v := os.Getenv("FEDERAL_REGISTER_FIELDS"); v != "" {
+		c.FederalRegisterFields = nil
+		for _, f := range strings.Split(v, ",") {
+			if f = strings.TrimSpace(f); f != "" {
+				c.FederalRegisterFields = append(c.FederalRegisterFields, f)
+			}
+		}
+	}
+	for _, req := range FederalRegisterRequiredFields {
+		if !slices.Contains(c.FederalRegisterFields, req) {
+			return nil, fmt.Errorf("FEDERAL_REGISTER_FIELDS must include %q", req)
+		}
+	}
+
 	if v := os.Getenv("DEBUG"); v != "" {
 		c.Debug = parseBool(v)
 	}
@@ -213,10 +573,95 @@ func Load() (*Config, error) {
 		c.BehindProxy = parseBool(v)
 	}
 
+	if v := os.Getenv("TRUSTED_PROXIES"); v != "" {
+		c.TrustedProxies = nil
+		for _, p := range strings.Split(v, ",") {
+			if p = strings.TrimSpace(p); p != "" {
+				c.TrustedProxies = append(c.TrustedProxies, p)
+			}
+		}
+	}
+	for _, p := range c.TrustedProxies {
+		if _, _, err := net.ParseCIDR(p); err != nil && net.ParseIP(p) == nil {
+			return nil, fmt.Errorf("invalid TRUSTED_PROXIES entry %q (want an IP or CIDR)", p)
+		}
+	}
+
 	if v := os.Getenv("USE_MOCK_GROK"); v != "" {
 		c.UseMockGrok = parseBool(v)
 	}
 
+	if v := os.Getenv("SUMMARIZER_PROVIDER"); v != "" {
+		c.SummarizerProvider = strings.ToLower(strings.TrimSpace(v))
+	}
+
+	// USE_MOCK_GROK predates SUMMARIZER_PROVIDER and still forces the mock.
+	if c.UseMockGrok {
+		c.SummarizerProvider = SummarizerProviderMock
+	}
+
+	switch c.SummarizerProvider {
+	case SummarizerProviderXAI, SummarizerProviderOpenAI, SummarizerProviderMock:
+	default:
+		return nil, fmt.Errorf("unknown SUMMARIZER_PROVIDER %q (want xai, openai or mock)", c.SummarizerProvider)
+	}
+
+	if v := os.Getenv("ENRICH_MODE"); v != "" {
+		c.EnrichMode = strings.ToLower(strings.TrimSpace(v))
+	}
+	if c.EnrichMode != EnrichModeAll && c.EnrichMode != EnrichModeSelective {
+		return nil, fmt.Errorf("unknown ENRICH_MODE %q (want all or selective)", c.EnrichMode)
+	}
+
+	if v := os.Getenv("ENRICH_ANALYZE_TYPES"); v != "" {
+		c.EnrichAnalyzeTypes = nil
+		for _, t := range strings.Split(v, ",") {
+			if t = strings.TrimSpace(t); t != "" {
+				c.EnrichAnalyzeTypes = append(c.EnrichAnalyzeTypes, t)
+			}
+		}
+	}
+
+	if v := os.Getenv("TRANSLATION_LANGUAGES"); v != "" {
+		for _, lang := range strings.Split(v, ",") {
+			lang = strings.ToLower(strings.TrimSpace(lang))
+			if lang == "" {
+				continue
+			}
+			if _, ok := constants.Languages[lang]; !ok {
+				return nil, fmt.Errorf("unknown language %q in TRANSLATION_LANGUAGES", lang)
+			}
+			c.TranslationLanguages = append(c.TranslationLanguages, lang)
+		}
+	}
+
+	if v := os.Getenv("SUMMARIZER_BREAKER_THRESHOLD"); v != "" {
+		if iv, err := strconv.Atoi(v); err == nil && iv >= 0 {
+			c.BreakerThreshold = iv
+		}
+	}
+
+	if v := os.Getenv("SUMMARIZER_BREAKER_COOLDOWN"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("invalid SUMMARIZER_BREAKER_COOLDOWN %q (want a duration such as 1m)", v)
+		}
+		c.BreakerCooldown = d
+	}
+
+	if v := os.Getenv("REPROCESS_INTERVAL"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d < 0 {
+			return nil, fmt.Errorf("invalid REPROCESS_INTERVAL %q (want a duration such as 1s)", v)
+		}
+		c.ReprocessInterval = d
+	}
+
+	c.FeatureFlags = map[string]bool{constants.FeaturePersonalization: true}
+	if v := os.Getenv("FEATURE_FLAGS"); v != "" {
+		maps.Copy(c.FeatureFlags, parseFeatureFlags(v))
+	}
+
 	if v := os.Getenv("COOKIE_SECURE"); v != "" {
 		c.CookieSecure = parseBool(v)
 	}
@@ -251,10 +696,34 @@ func Load() (*Config, error) {
 		c.FrontendURL = v
 	}
 
+	if v := os.Getenv("OAUTH_SUCCESS_PATH"); v != "" {
+		c.OAuthSuccessPath = v
+	}
+	if err := validateRelativePath("OAUTH_SUCCESS_PATH", c.OAuthSuccessPath); err != nil {
+		return nil, err
+	}
+
+	if v := os.Getenv("OAUTH_ERROR_PATH"); v != "" {
+		c.OAuthErrorPath = v
+	}
+	if err := validateRelativePath("OAUTH_ERROR_PATH", c.OAuthErrorPath); err != nil {
+		return nil, err
+	}
+
 	if v := os.Getenv("GROK_MODEL"); v != "" {
 		c.GrokModel = v
 	}
 
+	if v := os.Getenv("FALLBACK_SUMMARY_TEMPLATE"); v != "" {
+		c.FallbackSummaryTemplate = v
+	}
+
+	if v := os.Getenv("SUMMARY_MAX_CHARS"); v != "" {
+		if iv, err := strconv.Atoi(v); err == nil && iv > 0 {
+			c.SummaryMaxChars = iv
+		}
+	}
+
 	if v := os.Getenv("PORT"); v != "" {
 		c.Port = v
 	}
@@ -299,6 +768,32 @@ func (c *Config) ScraperInterval() time.Duration {
 	return time.Duration(c.ScraperIntervalMinutes) * time.Minute
 }
 
+// ScraperStaleAfter is how old the newest document may be before the scraper is
+// considered stalled.
+func (c *Config) ScraperStaleAfter() time.Duration {
+	if c.ScraperStaleMinutes > 0 {
+		return time.Duration(c.ScraperStaleMinutes) * time.Minute
+	}
+	return 2 * c.ScraperInterval()
+}
+
+func (c *Config) ScraperCooldown() time.Duration {
+	return time.Duration(c.ScraperCooldownMinutes) * time.Minute
+}
+
+// HasSummarizerCredentials reports whether the selected summarizer provider has the API key
+// it needs. The mock provider needs none.
+func (c *Config) HasSummarizerCredentials() bool {
+	switch c.SummarizerProvider {
+	case SummarizerProviderMock:
+		return true
+	case SummarizerProviderOpenAI:
+		return c.OpenAIAPIKey != ""
+	default:
+		return c.GrokAPIKey != ""
+	}
+}
+
 func (c *Config) ValidateOAuth() bool {
 	hasClientID := c.GoogleClientID != ""
 	hasClientSecret := c.GoogleClientSecret != ""
@@ -1,6 +1,10 @@
 package config
 
-import "testing"
+import (
+	"slices"
+	"testing"
+	"time"
+)
 
 func TestDatabaseURL_EncodesPassword(t *testing.T) {
 	cfg := &Config{
@@ -34,3 +38,266 @@ func TestDatabaseURL_NoPassword(t *testing.T) {
 		t.Fatalf("DatabaseURL() = %q, want %q", got, want)
 	}
 }
+
+func TestLoad_ClampsFederalRegisterPerPage(t *testing.T) {
+	tests := []struct {
+		name string
+		env  string
+		want int
+	}{
+		{name: "above max is clamped", env: "5000", want: FederalRegisterMaxPerPage},
+		{name: "max passes through", env: "1000", want: 1000},
+		{name: "valid value passes through", env: "250", want: 250},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("ENVIRONMENT", "development")
+			t.Setenv("FEDERAL_REGISTER_PER_PAGE", tc.env)
+
+			cfg, err := Load()
+			if err != nil {
+				t.Fatalf("Load() error: %v", err)
+			}
+			if cfg.FederalRegisterPerPage != tc.want {
+				t.Fatalf("FederalRegisterPerPage = %d, want %d", cfg.FederalRegisterPerPage, tc.want)
+			}
+		})
+	}
+}
+
+func TestLoad_SummarizerProvider(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider string
+		mock     string
+		want     string
+		wantErr  bool
+	}{
+		{name: "defaults to xai", want: SummarizerProviderXAI},
+		{name: "openai", provider: "OpenAI", want: SummarizerProviderOpenAI},
+		{name: "legacy mock flag wins", provider: "openai", mock: "true", want: SummarizerProviderMock},
+		{name: "unknown provider", provider: "bard", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("ENVIRONMENT", "development")
+			t.Setenv("SUMMARIZER_PROVIDER", tc.provider)
+			t.Setenv("USE_MOCK_GROK", tc.mock)
+
+			cfg, err := Load()
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("Load() succeeded, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load() error: %v", err)
+			}
+			if cfg.SummarizerProvider != tc.want {
+				t.Fatalf("SummarizerProvider = %q, want %q", cfg.SummarizerProvider, tc.want)
+			}
+		})
+	}
+}
+
+func TestLoad_PublicationTimezone(t *testing.T) {
+	t.Setenv("ENVIRONMENT", "development")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if cfg.PublicationLocation.String() != "America/New_York" {
+		t.Fatalf("PublicationLocation = %q, want America/New_York", cfg.PublicationLocation)
+	}
+
+	t.Setenv("PUBLICATION_TIMEZONE", "Not/AZone")
+	if _, err := Load(); err == nil {
+		t.Fatal("Load() accepted an invalid PUBLICATION_TIMEZONE")
+	}
+}
+
+func TestParseFeatureFlags(t *testing.T) {
+	got := parseFeatureFlags(" Comments=true, reactions=false ,sse_stream,,personalization=1")
+	want := map[string]bool{"comments": true, "reactions": false, "sse_stream": true, "personalization": true}
+	if len(got) != len(want) {
+		t.Fatalf("parseFeatureFlags() = %v, want %v", got, want)
+	}
+	for name, enabled := range want {
+		if got[name] != enabled {
+			t.Fatalf("parseFeatureFlags()[%q] = %v, want %v", name, got[name], enabled)
+		}
+	}
+}
+
+func TestLoad_OAuthPaths(t *testing.T) {
+	t.Setenv("OAUTH_SUCCESS_PATH", "/signin/done")
+	t.Setenv("OAUTH_ERROR_PATH", "/signin")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if cfg.OAuthSuccessPath != "/signin/done" || cfg.OAuthErrorPath != "/signin" {
+		t.Fatalf("paths = %q, %q", cfg.OAuthSuccessPath, cfg.OAuthErrorPath)
+	}
+}
+
+func TestLoad_RejectsNonRelativeOAuthPaths(t *testing.T) {
+	for _, p := range []string{
+		"https://evil.example/login",
+		"//evil.example/login",
+		"/\\evil.example",
+		"login",
+		"/login?next=https://evil.example",
+	} {
+		t.Run(p, func(t *testing.T) {
+			t.Setenv("OAUTH_ERROR_PATH", p)
+			if _, err := Load(); err == nil {
+				t.Fatalf("Load() accepted OAUTH_ERROR_PATH=%q", p)
+			}
+		})
+	}
+}
+
+func TestLoad_DatabaseDriver(t *testing.T) {
+	t.Setenv("DB_DRIVER", "Postgres")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if cfg.DatabaseDriver != DatabaseDriverPostgres {
+		t.Fatalf("DatabaseDriver = %q, want %q", cfg.DatabaseDriver, DatabaseDriverPostgres)
+	}
+
+	t.Setenv("DB_DRIVER", "sqlite3")
+	if _, err := Load(); err == nil {
+		t.Fatal("Load() accepted DB_DRIVER=sqlite3")
+	}
+}
+
+func TestLoad_ConnectionPool(t *testing.T) {
+	t.Setenv("DB_MAX_OPEN_CONNS", "40")
+	t.Setenv("DB_MAX_IDLE_CONNS", "60")
+	t.Setenv("DB_CONN_MAX_LIFETIME", "90s")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if cfg.DBMaxOpenConns != 40 {
+		t.Fatalf("DBMaxOpenConns = %d, want 40", cfg.DBMaxOpenConns)
+	}
+	if cfg.DBMaxIdleConns != 40 {
+		t.Fatalf("DBMaxIdleConns = %d, want it capped at 40", cfg.DBMaxIdleConns)
+	}
+	if cfg.DBConnMaxLifetime != 90*time.Second {
+		t.Fatalf("DBConnMaxLifetime = %v, want 90s", cfg.DBConnMaxLifetime)
+	}
+
+	t.Setenv("DB_CONN_MAX_LIFETIME", "five minutes")
+	if _, err := Load(); err == nil {
+		t.Fatal("Load() accepted an invalid DB_CONN_MAX_LIFETIME")
+	}
+}
+
+func TestLoad_FeedCacheTTL(t *testing.T) {
+	t.Setenv("FEED_CACHE_TTL", "0")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if cfg.FeedCacheTTL != 0 {
+		t.Fatalf("FeedCacheTTL = %v, want 0 (disabled)", cfg.FeedCacheTTL)
+	}
+
+	t.Setenv("FEED_CACHE_TTL", "-5s")
+	if _, err := Load(); err == nil {
+		t.Fatal("Load() accepted a negative FEED_CACHE_TTL")
+	}
+}
+
+func TestLoad_RequestTimeout(t *testing.T) {
+	t.Setenv("REQUEST_TIMEOUT", "10s")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if cfg.RequestTimeout != 10*time.Second || cfg.LongRequestTimeout != 5*time.Minute {
+		t.Fatalf("timeouts = %v, %v; want 10s, 5m", cfg.RequestTimeout, cfg.LongRequestTimeout)
+	}
+
+	t.Setenv("LONG_REQUEST_TIMEOUT", "forever")
+	if _, err := Load(); err == nil {
+		t.Fatal("Load() accepted an invalid LONG_REQUEST_TIMEOUT")
+	}
+}
+
+func TestLoad_AllowedOrigins(t *testing.T) {
+	t.Setenv("ALLOWED_ORIGINS", "https://opengov.example, https://*.vercel.app,")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if len(cfg.AllowedOrigins) != 2 || cfg.AllowedOrigins[1] != "https://*.vercel.app" {
+		t.Fatalf("AllowedOrigins = %q", cfg.AllowedOrigins)
+	}
+
+	for _, bad := range []string{"*", "*://example.com", "https://*.*.example.com", "https://example.com/*"} {
+		t.Setenv("ALLOWED_ORIGINS", bad)
+		if _, err := Load(); err == nil {
+			t.Errorf("Load() accepted ALLOWED_ORIGINS=%q", bad)
+		}
+	}
+}
+
+func TestLoad_TrustedProxies(t *testing.T) {
+	t.Setenv("TRUSTED_PROXIES", "10.1.0.0/16, 192.0.2.10")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if len(cfg.TrustedProxies) != 2 || cfg.TrustedProxies[1] != "192.0.2.10" {
+		t.Fatalf("TrustedProxies = %q", cfg.TrustedProxies)
+	}
+
+	t.Setenv("TRUSTED_PROXIES", "10.1.0.0/33")
+	if _, err := Load(); err == nil {
+		t.Fatal("Load() accepted an invalid TRUSTED_PROXIES entry")
+	}
+}
+
+func TestLoad_FederalRegisterFields(t *testing.T) {
+	t.Setenv("FEDERAL_REGISTER_FIELDS", "document_number, html_url,publication_date,title,type")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if len(cfg.FederalRegisterFields) != 5 || cfg.FederalRegisterFields[1] != "html_url" {
+		t.Fatalf("FederalRegisterFields = %v", cfg.FederalRegisterFields)
+	}
+
+	t.Setenv("FEDERAL_REGISTER_FIELDS", "title,abstract")
+	if _, err := Load(); err == nil {
+		t.Fatal("Load() accepted fields without document_number")
+	}
+}
+
+func TestLoad_TranslationLanguages(t *testing.T) {
+	t.Setenv("TRANSLATION_LANGUAGES", " ES, fr,")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if !slices.Equal(cfg.TranslationLanguages, []string{"es", "fr"}) {
+		t.Fatalf("TranslationLanguages = %v, want [es fr]", cfg.TranslationLanguages)
+	}
+
+	t.Setenv("TRANSLATION_LANGUAGES", "es,klingon")
+	if _, err := Load(); err == nil {
+		t.Fatal("Load() accepted an unknown language")
+	}
+}
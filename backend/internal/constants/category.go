@@ -0,0 +1,22 @@
+package constants
+
+// Categories is the allow-list of topic categories AI analysis may assign to a document.
+// The analysis prompt lists the same values.
+var Categories = []string{
+	"agriculture",
+	"defense",
+	"education",
+	"energy",
+	"environment",
+	"finance",
+	"health",
+	"housing",
+	"immigration",
+	"justice",
+	"labor",
+	"science",
+	"taxes",
+	"technology",
+	"trade",
+	"transportation",
+}
@@ -0,0 +1,8 @@
+package constants
+
+// Feature flags gating behaviors that can be toggled at runtime.
+const (
+	// FeaturePersonalization covers the following and recommended feeds and
+	// leaning=opposite. On unless FEATURE_FLAGS turns it off.
+	FeaturePersonalization string = "personalization"
+)
@@ -0,0 +1,23 @@
+package constants
+
+// Job types recorded in the jobs table.
+const (
+	JobTypeScrape    string = "scrape"
+	JobTypePipeline  string = "pipeline"
+	JobTypeBackfill  string = "backfill"
+	JobTypeReprocess string = "reprocess"
+)
+
+// Job origins: the binary that recorded a job.
+const (
+	JobOriginAPI  string = "api"
+	JobOriginJobs string = "jobs"
+)
+
+// Job statuses. A job moves pending -> running -> succeeded or failed.
+const (
+	JobStatusPending   string = "pending"
+	JobStatusRunning   string = "running"
+	JobStatusSucceeded string = "succeeded"
+	JobStatusFailed    string = "failed"
+)
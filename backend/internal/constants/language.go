@@ -0,0 +1,10 @@
+package constants
+
+// Languages maps the language codes summaries can be translated into to the language
+// names used in the analysis prompt. English is the source language and not listed.
+var Languages = map[string]string{
+	"es": "Spanish",
+	"fr": "French",
+	"vi": "Vietnamese",
+	"zh": "Chinese",
+}
@@ -2,4 +2,13 @@ package constants
 
 const (
 	SourceTypeFederalRegister string = "federal_register"
+	SourceTypeCongress        string = "congress"
 )
+
+// SourceAliases maps the short names the API accepts for a source to its source key.
+// Every source key also maps to itself.
+var SourceAliases = map[string]string{
+	SourceTypeFederalRegister: SourceTypeFederalRegister,
+	"fedreg":                  SourceTypeFederalRegister,
+	SourceTypeCongress:        SourceTypeCongress,
+}
@@ -3,7 +3,6 @@ package db
 import (
 	"database/sql"
 	"fmt"
-	"time"
 
 	_ "github.com/lib/pq"
 
@@ -12,23 +11,26 @@ import (
 
 type DB struct {
 	*sql.DB
+	// publicationTimezone is exposed to migrations that rewrite stored publication
+	// dates, as the opengov.publication_timezone setting.
+	publicationTimezone string
 }
 
 func New(cfg *config.Config) (*DB, error) {
-	db, err := sql.Open("postgres", cfg.DatabaseURL())
+	db, err := sql.Open(cfg.DatabaseDriver, cfg.DatabaseURL())
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
 
-	db.SetMaxOpenConns(25)
-	db.SetMaxIdleConns(5)
-	db.SetConnMaxLifetime(5 * time.Minute)
+	db.SetMaxOpenConns(cfg.DBMaxOpenConns)
+	db.SetMaxIdleConns(cfg.DBMaxIdleConns)
+	db.SetConnMaxLifetime(cfg.DBConnMaxLifetime)
 
 	if err := db.Ping(); err != nil {
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
-	return &DB{db}, nil
+	return &DB{DB: db, publicationTimezone: cfg.PublicationTimezone}, nil
 }
 
 func (db *DB) Close() error {
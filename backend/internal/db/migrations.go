@@ -1,38 +1,110 @@
 package db
 
 import (
+	"context"
+	"database/sql"
 	"fmt"
 	"strings"
 
 	"github.com/alex/opengov-go/migration"
 )
 
-func (db *DB) RunMigrations() error {
-	tx, err := db.Begin()
+// migrationLockID is the advisory lock key held while migrating, so the API and a
+// migrate job starting together don't apply the same version twice.
+const migrationLockID = 7_310_442_001
+
+// legacyBaselineVersion is the last migration shipped with the old runner, which
+// replayed every file on each start and kept no record. A database that already has a
+// schema but no schema_migrations table is assumed to be at this version; replaying
+// everything is not safe because 007 reads columns it then drops. Later migrations run
+// normally, so they must tolerate a database from a build that replayed some of them.
+const legacyBaselineVersion = 8
+
+// MigrateUp applies every embedded migration not yet recorded in schema_migrations, in
+// version order and each in its own transaction, and returns the names of those it
+// applied.
+func (db *DB) MigrateUp(ctx context.Context) ([]string, error) {
+	conn, err := db.Conn(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
+		return nil, fmt.Errorf("failed to get connection: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", migrationLockID); err != nil {
+		return nil, fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+	defer conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", migrationLockID)
+
+	if db.publicationTimezone != "" {
+		if _, err := conn.ExecContext(ctx, "SELECT set_config('opengov.publication_timezone', $1, false)", db.publicationTimezone); err != nil {
+			return nil, fmt.Errorf("failed to set publication timezone: %w", err)
+		}
 	}
-	defer tx.Rollback()
 
 	files, err := migration.List()
 	if err != nil {
-		return fmt.Errorf("failed to list migration files: %w", err)
+		return nil, fmt.Errorf("failed to list migration files: %w", err)
 	}
 
-	for _, file := range files {
-		content, err := migration.ReadFile(file)
-		if err != nil {
-			return err
+	if err := ensureMigrationTable(ctx, conn, files); err != nil {
+		return nil, err
+	}
+
+	applied, err := appliedVersions(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	var ran []string
+	for _, file := range pendingMigrations(files, applied) {
+		if err := applyMigration(ctx, conn, file); err != nil {
+			return ran, err
 		}
+		ran = append(ran, file)
+	}
+	return ran, nil
+}
+
+// ensureMigrationTable creates schema_migrations, baselining databases migrated by the
+// old replay runner.
+func ensureMigrationTable(ctx context.Context, conn *sql.Conn, files []string) error {
+	var tracked, legacy bool
+	err := conn.QueryRowContext(ctx, `
+		SELECT
+			to_regclass('public.schema_migrations') IS NOT NULL,
+			to_regclass('public.users') IS NOT NULL
+	`).Scan(&tracked, &legacy)
+	if err != nil {
+		return fmt.Errorf("failed to inspect schema: %w", err)
+	}
+	if tracked {
+		return nil
+	}
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
 
-		statements := splitStatements(string(content))
-		for _, stmt := range statements {
-			stmt = strings.TrimSpace(stmt)
-			if stmt == "" {
-				continue
+	_, err = tx.ExecContext(ctx, `
+		CREATE TABLE schema_migrations (
+			version INTEGER PRIMARY KEY,
+			name TEXT NOT NULL,
+			applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+
+	if legacy {
+		for _, file := range files {
+			if migration.Version(file) > legacyBaselineVersion {
+				break
 			}
-			if _, err := tx.Exec(stmt); err != nil {
-				return fmt.Errorf("failed to run migration %s: %w", file, err)
+			if err := recordMigration(ctx, tx, file); err != nil {
+				return err
 			}
 		}
 	}
@@ -40,6 +112,75 @@ func (db *DB) RunMigrations() error {
 	return tx.Commit()
 }
 
+func appliedVersions(ctx context.Context, conn *sql.Conn) (map[int]bool, error) {
+	rows, err := conn.QueryContext(ctx, "SELECT version FROM schema_migrations")
+	if err != nil {
+		return nil, fmt.Errorf("failed to query applied migrations: %w", err)
+	}
+	defer rows.Close()
+
+	applied := map[int]bool{}
+	for rows.Next() {
+		var v int
+		if err := rows.Scan(&v); err != nil {
+			return nil, fmt.Errorf("failed to scan migration version: %w", err)
+		}
+		applied[v] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating applied migrations: %w", err)
+	}
+	return applied, nil
+}
+
+// pendingMigrations returns the files whose version is not in applied, keeping the
+// sorted order from migration.List.
+func pendingMigrations(files []string, applied map[int]bool) []string {
+	var pending []string
+	for _, f := range files {
+		if !applied[migration.Version(f)] {
+			pending = append(pending, f)
+		}
+	}
+	return pending
+}
+
+func applyMigration(ctx context.Context, conn *sql.Conn, file string) error {
+	content, err := migration.ReadFile(file)
+	if err != nil {
+		return err
+	}
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, stmt := range splitStatements(string(content)) {
+		stmt = strings.TrimSpace(stmt)
+		if stmt == "" {
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("failed to run migration %s: %w", file, err)
+		}
+	}
+
+	if err := recordMigration(ctx, tx, file); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func recordMigration(ctx context.Context, tx *sql.Tx, file string) error {
+	_, err := tx.ExecContext(ctx, "INSERT INTO schema_migrations (version, name) VALUES ($1, $2)", migration.Version(file), file)
+	if err != nil {
+		return fmt.Errorf("failed to record migration %s: %w", file, err)
+	}
+	return nil
+}
+
 func splitStatements(sql string) []string {
 	var statements []string
 	var current strings.Builder
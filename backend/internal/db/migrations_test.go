@@ -0,0 +1,130 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/alex/opengov-go/migration"
+)
+
+func TestPendingMigrations(t *testing.T) {
+	files := []string{"000_users.sql", "001_agencies.sql", "002_docs.sql", "003_feed.sql"}
+
+	got := pendingMigrations(files, map[int]bool{0: true, 2: true})
+	want := []string{"001_agencies.sql", "003_feed.sql"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("pending = %v, want %v", got, want)
+	}
+
+	if got := pendingMigrations(files, map[int]bool{0: true, 1: true, 2: true, 3: true}); len(got) != 0 {
+		t.Fatalf("pending = %v, want none", got)
+	}
+}
+
+func TestSplitStatements(t *testing.T) {
+	sql := `-- header; with a semicolon
+CREATE TABLE t (a TEXT DEFAULT 'x;y');
+INSERT INTO t (a) VALUES ('z');
+`
+	got := splitStatements(sql)
+	if len(got) != 2 {
+		t.Fatalf("got %d statements, want 2: %q", len(got), got)
+	}
+}
+
+func TestEnsureMigrationTable_LegacySchema(t *testing.T) {
+	files, err := migration.List()
+	if err != nil {
+		t.Fatalf("list migrations: %v", err)
+	}
+	fake := &schemaConn{legacy: true}
+	conn := openFakeConn(t, fake)
+
+	if err := ensureMigrationTable(context.Background(), conn, files); err != nil {
+		t.Fatalf("ensureMigrationTable: %v", err)
+	}
+
+	// Only 000-008 shipped with the replay runner; 009 onward must still run.
+	want := []int64{0, 1, 2, 3, 4, 5, 6, 7, 8}
+	if !reflect.DeepEqual(fake.recorded, want) {
+		t.Fatalf("recorded versions = %v, want %v", fake.recorded, want)
+	}
+	if !fake.committed {
+		t.Fatal("baseline was not committed")
+	}
+}
+
+func TestEnsureMigrationTable_FreshDatabase(t *testing.T) {
+	fake := &schemaConn{}
+	conn := openFakeConn(t, fake)
+
+	if err := ensureMigrationTable(context.Background(), conn, []string{"000_create_users.sql"}); err != nil {
+		t.Fatalf("ensureMigrationTable: %v", err)
+	}
+	if len(fake.recorded) != 0 {
+		t.Fatalf("recorded versions = %v, want none", fake.recorded)
+	}
+}
+
+func openFakeConn(t *testing.T, fake *schemaConn) *sql.Conn {
+	t.Helper()
+	db := sql.OpenDB(fakeConnector{fake})
+	t.Cleanup(func() { db.Close() })
+	conn, err := db.Conn(context.Background())
+	if err != nil {
+		t.Fatalf("conn: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+type fakeConnector struct{ conn *schemaConn }
+
+func (c fakeConnector) Connect(context.Context) (driver.Conn, error) { return c.conn, nil }
+func (c fakeConnector) Driver() driver.Driver                        { return nil }
+
+// schemaConn answers the schema inspection query and records the versions inserted into
+// schema_migrations.
+type schemaConn struct {
+	legacy    bool
+	recorded  []int64
+	committed bool
+}
+
+func (c *schemaConn) Prepare(string) (driver.Stmt, error) { return nil, driver.ErrSkip }
+func (c *schemaConn) Close() error                        { return nil }
+func (c *schemaConn) Begin() (driver.Tx, error)           { return c, nil }
+func (c *schemaConn) Commit() error                       { c.committed = true; return nil }
+func (c *schemaConn) Rollback() error                     { return nil }
+
+func (c *schemaConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if strings.Contains(query, "INSERT INTO schema_migrations") {
+		c.recorded = append(c.recorded, args[0].Value.(int64))
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (c *schemaConn) QueryContext(context.Context, string, []driver.NamedValue) (driver.Rows, error) {
+	return &boolRows{values: []driver.Value{false, c.legacy}}, nil
+}
+
+type boolRows struct {
+	values []driver.Value
+	done   bool
+}
+
+func (r *boolRows) Columns() []string { return []string{"tracked", "legacy"} }
+func (r *boolRows) Close() error      { return nil }
+func (r *boolRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	copy(dest, r.values)
+	return nil
+}
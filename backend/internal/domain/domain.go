@@ -53,6 +53,14 @@ type Agency struct {
 	UpdatedAt   time.Time
 }
 
+type AgencyFollow struct {
+	ID        int64
+	UserID    int64
+	AgencyID  int64
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
 type PolicyDocument struct {
 	ID             int64
 	SourceKey      string
@@ -60,7 +68,9 @@ type PolicyDocument struct {
 	FetchedAt      time.Time
 	Title          string
 	Agency         *string
+	AgencyID       *int64
 	Summary        string
+	Abstract       *string
 	Keypoints      []string
 	ImpactScore    *string
 	PoliticalScore *int
@@ -72,6 +82,19 @@ type PolicyDocument struct {
 	UpdatedAt      time.Time
 }
 
+// DocumentAgency is one of the agencies a document lists. AgencyID is nil when the
+// agency has not been synced into agencies.
+type DocumentAgency struct {
+	AgencyID *int64
+	Name     string
+}
+
+// CFRRef is a Code of Federal Regulations title and part a document affects.
+type CFRRef struct {
+	Title int
+	Part  int
+}
+
 type Bookmark struct {
 	ID          int64
 	UserID      int64
@@ -98,3 +121,59 @@ type RawPolicyDocument struct {
 	PolicyDocumentID *int64
 	CreatedAt        time.Time
 }
+
+type SavedSearch struct {
+	ID        int64
+	UserID    int64
+	Name      string
+	Filters   dbtypes.JSONMap
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type Setting struct {
+	ID        int64
+	Key       string
+	Value     string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type AIUsage struct {
+	ID               int64
+	PolicyDocumentID *int64
+	Provider         string
+	Model            string
+	PromptTokens     int
+	CompletionTokens int
+	TotalTokens      int
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// ScrapeState is the per-source high-water mark for raw ingestion.
+type ScrapeState struct {
+	SourceKey       string
+	LastPublishedAt time.Time
+	LastRunAt       time.Time
+	// LastRunDocuments is how many documents the source returned on the last run; 0
+	// means it succeeded with nothing new. Nil for runs recorded before it was tracked.
+	LastRunDocuments *int
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// Job records one run of a long-running operation such as a scrape or backfill.
+type Job struct {
+	ID         int64
+	Type       string
+	Status     string
+	Processed  int
+	Skipped    int
+	Total      *int // nil when the job's size isn't known up front
+	Error      *string
+	StartedAt  *time.Time
+	FinishedAt *time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/alex/opengov-go/internal/middleware"
+	"github.com/alex/opengov-go/internal/services"
+)
+
+type ActivityHandler struct {
+	feedService *services.FeedService
+}
+
+func NewActivityHandler(feedService *services.FeedService) *ActivityHandler {
+	return &ActivityHandler{feedService: feedService}
+}
+
+// GetActivity lists the user's likes, dislikes and bookmarks, most recent first.
+func (h *ActivityHandler) GetActivity(c *gin.Context) {
+	userID, hasAuth := middleware.GetUserID(c)
+	if !hasAuth {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	page, limit, ok := parsePagination(c, 20)
+	if !ok {
+		return
+	}
+
+	resp, err := h.feedService.GetActivity(c.Request.Context(), userID, page, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch activity"})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,29 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestGetActivity_Validation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	h := NewActivityHandler(nil)
+	router := gin.New()
+	router.GET("/anon", h.GetActivity)
+	router.GET("/me", func(c *gin.Context) { c.Set("user_id", int64(7)) }, h.GetActivity)
+
+	for path, want := range map[string]int{
+		"/anon":          http.StatusUnauthorized,
+		"/me?page=10000": http.StatusBadRequest,
+	} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		if w.Code != want {
+			t.Errorf("%s: status = %d, want %d", path, w.Code, want)
+		}
+	}
+}
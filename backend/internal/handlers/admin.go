@@ -1,29 +1,53 @@
 package handlers
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"math"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/alex/opengov-go/internal/client"
+	"github.com/alex/opengov-go/internal/config"
+	"github.com/alex/opengov-go/internal/domain"
 	"github.com/alex/opengov-go/internal/repository"
 	"github.com/alex/opengov-go/internal/services"
+	"github.com/alex/opengov-go/internal/timeformat"
 	"github.com/alex/opengov-go/internal/transport"
 )
 
 type AdminHandler struct {
-	docRepo    *repository.PolicyDocumentRepository
-	agencyRepo *repository.AgencyRepository
-	agencySync *services.AgencySyncService
+	cfg           *config.Config
+	docRepo       *repository.PolicyDocumentRepository
+	agencyRepo    *repository.AgencyRepository
+	settingsRepo  *repository.SettingsRepository
+	aiUsageRepo   *repository.AIUsageRepository
+	agencySync    *services.AgencySyncService
+	scrapeTrigger *services.ScrapeTrigger
+	backfill      *services.BackfillRunner
+	frClient      *client.FederalRegisterClient
+	docService    *services.PolicyDocumentService
+	externalCalls *client.CallLog
 }
 
-func NewAdminHandler(docRepo *repository.PolicyDocumentRepository, agencyRepo *repository.AgencyRepository, agencySync *services.AgencySyncService) *AdminHandler {
+func NewAdminHandler(cfg *config.Config, docRepo *repository.PolicyDocumentRepository, agencyRepo *repository.AgencyRepository, settingsRepo *repository.SettingsRepository, aiUsageRepo *repository.AIUsageRepository, agencySync *services.AgencySyncService, scrapeTrigger *services.ScrapeTrigger, backfill *services.BackfillRunner, frClient *client.FederalRegisterClient, docService *services.PolicyDocumentService, externalCalls *client.CallLog) *AdminHandler {
 	return &AdminHandler{
-		docRepo:    docRepo,
-		agencyRepo: agencyRepo,
-		agencySync: agencySync,
+		cfg:           cfg,
+		docRepo:       docRepo,
+		agencyRepo:    agencyRepo,
+		settingsRepo:  settingsRepo,
+		aiUsageRepo:   aiUsageRepo,
+		agencySync:    agencySync,
+		scrapeTrigger: scrapeTrigger,
+		backfill:      backfill,
+		frClient:      frClient,
+		docService:    docService,
+		externalCalls: externalCalls,
 	}
 }
 
@@ -34,10 +58,22 @@ func (h *AdminHandler) GetStats(c *gin.Context) {
 		return
 	}
 
+	stats, err := h.docRepo.GetStats(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get stats"})
+		return
+	}
+
 	lastArticle, _ := h.docRepo.GetLatest(c.Request.Context())
 
 	resp := transport.StatsResponse{
-		TotalArticles: total,
+		TotalArticles:  total,
+		ByDocumentType: stats.ByDocumentType,
+		ByImpactScore:  stats.ByImpactScore,
+		AddedLast24h:   stats.AddedLast24h,
+		AddedLast7d:    stats.AddedLast7d,
+		Enriched:       stats.Enriched,
+		Unenriched:     stats.Unenriched,
 	}
 
 	if lastArticle != nil {
@@ -49,6 +85,102 @@ func (h *AdminHandler) GetStats(c *gin.Context) {
 	c.JSON(http.StatusOK, resp)
 }
 
+// GetExternalCalls lists the most recent outbound calls to the Federal Register and AI
+// providers, newest first, for spotting intermittent upstream failures.
+func (h *AdminHandler) GetExternalCalls(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil || limit < 1 || limit > client.DefaultCallLogSize {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("limit must be between 1 and %d", client.DefaultCallLogSize)})
+		return
+	}
+
+	recent := h.externalCalls.Recent(limit)
+	calls := make([]transport.ExternalCall, 0, len(recent))
+	for _, call := range recent {
+		calls = append(calls, transport.ExternalCall{
+			Service:   call.Service,
+			Method:    call.Method,
+			Endpoint:  call.Endpoint,
+			Status:    call.Status,
+			LatencyMS: call.Latency.Milliseconds(),
+			Error:     call.Error,
+			At:        call.At,
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{"calls": calls})
+}
+
+func (h *AdminHandler) TriggerScrape(c *gin.Context) {
+	remaining, err := h.scrapeTrigger.Trigger()
+	switch {
+	case errors.Is(err, services.ErrScrapeCooldown):
+		retryAfter := int(math.Ceil(remaining.Seconds()))
+		c.Header("Retry-After", strconv.Itoa(retryAfter))
+		c.JSON(http.StatusTooManyRequests, gin.H{
+			"error":               "Scrape triggered too recently",
+			"retry_after_seconds": retryAfter,
+		})
+		return
+	case errors.Is(err, services.ErrScrapeRunning):
+		c.JSON(http.StatusConflict, gin.H{"error": "Scrape already running"})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to trigger scrape"})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"status":  "started",
+		"message": "Scrape started",
+	})
+}
+
+// TriggerBackfill starts a background scrape of every document published between
+// start_date and end_date, inclusive, and returns the job id.
+func (h *AdminHandler) TriggerBackfill(c *gin.Context) {
+	var req transport.BackfillRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "start_date and end_date are required"})
+		return
+	}
+	start, err := timeformat.ParsePublicationDate(req.StartDate, h.cfg.PublicationLocation)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "start_date must be YYYY-MM-DD"})
+		return
+	}
+	end, err := timeformat.ParsePublicationDate(req.EndDate, h.cfg.PublicationLocation)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "end_date must be YYYY-MM-DD"})
+		return
+	}
+
+	id, err := h.backfill.Start(c.Request.Context(), start, end)
+	switch {
+	case errors.Is(err, services.ErrBackfillRangeInvalid):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "end_date must not be before start_date"})
+		return
+	case errors.Is(err, services.ErrBackfillRangeInFuture):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "end_date must not be in the future"})
+		return
+	case errors.Is(err, services.ErrBackfillRangeTooLong):
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("backfill range must not exceed %d days", h.cfg.BackfillMaxDays)})
+		return
+	case errors.Is(err, services.ErrBackfillRunning):
+		c.JSON(http.StatusConflict, gin.H{"error": "Backfill already running"})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start backfill"})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"status":  "started",
+		"message": "Backfill started",
+		"job_id":  id,
+	})
+}
+
 func (h *AdminHandler) SyncAgencies(c *gin.Context) {
 	count, err := h.agencySync.SyncAgencies(c.Request.Context())
 	if err != nil {
@@ -102,3 +234,294 @@ func (h *AdminHandler) GetAgencies(c *gin.Context) {
 		"offset":   offset,
 	})
 }
+
+// GetUpstreamAgencies returns the live Federal Register agency list, not our synced copy.
+func (h *AdminHandler) GetUpstreamAgencies(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), time.Duration(h.cfg.FederalRegisterTimeout)*time.Second)
+	defer cancel()
+
+	agencies, err := h.frClient.FetchAgencies(ctx)
+	if err != nil {
+		c.JSON(http.StatusBadGateway, gin.H{"error": "Failed to fetch agencies from Federal Register", "detail": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"agencies": agencies,
+		"total":    len(agencies),
+	})
+}
+
+// GetAgencyDiff compares the live Federal Register agency list with our synced copy.
+func (h *AdminHandler) GetAgencyDiff(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), time.Duration(h.cfg.FederalRegisterTimeout)*time.Second)
+	defer cancel()
+
+	upstream, err := h.frClient.FetchAgencies(ctx)
+	if err != nil {
+		c.JSON(http.StatusBadGateway, gin.H{"error": "Failed to fetch agencies from Federal Register", "detail": err.Error()})
+		return
+	}
+
+	local, err := h.agencyRepo.ListAll(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get agencies"})
+		return
+	}
+
+	diff := services.DiffAgencies(upstream, local)
+
+	resp := transport.AgencyDiffResponse{
+		Added:   make([]transport.AgencyDiffEntry, len(diff.Added)),
+		Removed: make([]transport.AgencyDiffEntry, len(diff.Removed)),
+		Changed: make([]transport.AgencyDiffChange, len(diff.Changed)),
+	}
+	for i, a := range diff.Added {
+		resp.Added[i] = upstreamAgencyDiffEntry(a)
+	}
+	for i, a := range diff.Removed {
+		resp.Removed[i] = localAgencyDiffEntry(a)
+	}
+	for i, ch := range diff.Changed {
+		resp.Changed[i] = transport.AgencyDiffChange{
+			FRAgencyID: ch.Local.FRAgencyID,
+			Fields:     ch.Fields,
+			Local:      localAgencyDiffEntry(ch.Local),
+			Upstream:   upstreamAgencyDiffEntry(ch.Upstream),
+		}
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *AdminHandler) GetAIUsage(c *gin.Context) {
+	days, _ := strconv.Atoi(c.DefaultQuery("days", "30"))
+	if days < 1 {
+		days = 30
+	}
+	if days > 365 {
+		days = 365
+	}
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	since := today.AddDate(0, 0, -(days - 1))
+
+	rows, err := h.aiUsageRepo.GetDailyTotals(c.Request.Context(), since)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get AI usage"})
+		return
+	}
+
+	resp := transport.AIUsageResponse{Days: make([]transport.AIUsageDay, len(rows))}
+	for i, r := range rows {
+		resp.Days[i] = transport.AIUsageDay{
+			Date:             r.Day.Format("2006-01-02"),
+			Calls:            r.Calls,
+			PromptTokens:     r.PromptTokens,
+			CompletionTokens: r.CompletionTokens,
+			TotalTokens:      r.TotalTokens,
+		}
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *AdminHandler) GetAnalysisPrompt(c *gin.Context) {
+	setting, err := h.settingsRepo.Get(c.Request.Context(), services.AnalysisPromptSettingKey)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get analysis prompt"})
+		return
+	}
+
+	resp := transport.AnalysisPromptResponse{Template: services.DefaultAnalysisPrompt, IsDefault: true}
+	if setting != nil {
+		resp.Template = setting.Value
+		resp.IsDefault = false
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *AdminHandler) UpdateAnalysisPrompt(c *gin.Context) {
+	var req transport.AnalysisPromptRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	if err := services.ValidateAnalysisPrompt(req.Template); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.settingsRepo.Set(c.Request.Context(), services.AnalysisPromptSettingKey, req.Template); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update analysis prompt"})
+		return
+	}
+
+	c.JSON(http.StatusOK, transport.AnalysisPromptResponse{Template: req.Template, IsDefault: false})
+}
+
+// ReprocessDocument re-runs AI analysis for one policy document, overwriting any existing
+// summary, keypoints and scores, and refreshes its feed entry.
+func (h *AdminHandler) ReprocessDocument(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid document ID"})
+		return
+	}
+
+	doc, err := h.docService.Reprocess(c.Request.Context(), id)
+	switch {
+	case errors.Is(err, services.ErrSummarizerUnavailable):
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Summarizer is not configured"})
+		return
+	case errors.Is(err, services.ErrPolicyDocumentNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Document not found"})
+		return
+	case errors.Is(err, services.ErrAnalysisFailed):
+		c.JSON(http.StatusBadGateway, gin.H{"error": "AI analysis failed"})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reprocess document"})
+		return
+	}
+
+	c.JSON(http.StatusOK, adminDocumentToResponse(doc))
+}
+
+// EditDocument applies a manual correction to a document's summary and/or keypoints.
+// Keypoints are checked against the configured admin limits before anything is saved.
+func (h *AdminHandler) EditDocument(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid document ID"})
+		return
+	}
+
+	var req transport.DocumentEditRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	if req.Summary == nil && req.Keypoints == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "summary or keypoints is required"})
+		return
+	}
+	if req.Summary != nil && strings.TrimSpace(*req.Summary) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "summary cannot be empty"})
+		return
+	}
+
+	if req.Keypoints != nil {
+		var verr *services.KeypointValidationError
+		if err := services.ValidateAdminKeypoints(req.Keypoints, h.cfg.AdminMaxKeypoints, h.cfg.AdminKeypointMaxChars); errors.As(err, &verr) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid keypoints", "details": verr.Problems})
+			return
+		}
+	}
+
+	doc, err := h.docService.Edit(c.Request.Context(), id, req.Summary, req.Keypoints)
+	if errors.Is(err, services.ErrPolicyDocumentNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Document not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update document"})
+		return
+	}
+
+	c.JSON(http.StatusOK, adminDocumentToResponse(doc))
+}
+
+// HideDocument removes a document from the feed without deleting it.
+func (h *AdminHandler) HideDocument(c *gin.Context) {
+	h.setDocumentHidden(c, true)
+}
+
+// UnhideDocument returns a hidden document to the feed.
+func (h *AdminHandler) UnhideDocument(c *gin.Context) {
+	h.setDocumentHidden(c, false)
+}
+
+func (h *AdminHandler) setDocumentHidden(c *gin.Context, hidden bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid document ID"})
+		return
+	}
+
+	found, err := h.docRepo.SetHidden(c.Request.Context(), id, hidden)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update document"})
+		return
+	}
+	if !found {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Document not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"id": id, "hidden": hidden})
+}
+
+func adminDocumentToResponse(doc *domain.PolicyDocument) transport.AdminDocumentResponse {
+	return transport.AdminDocumentResponse{
+		ID:             doc.ID,
+		Summary:        doc.Summary,
+		Keypoints:      doc.Keypoints,
+		ImpactScore:    doc.ImpactScore,
+		PoliticalScore: doc.PoliticalScore,
+		UpdatedAt:      doc.UpdatedAt,
+	}
+}
+
+func (h *AdminHandler) GetScraperConfig(c *gin.Context) {
+	c.JSON(http.StatusOK, scraperConfigResponse(h.cfg))
+}
+
+// scraperConfigResponse copies only non-secret scraper settings out of cfg.
+func scraperConfigResponse(cfg *config.Config) transport.ScraperConfigResponse {
+	aiModel := cfg.GrokModel
+	switch cfg.SummarizerProvider {
+	case config.SummarizerProviderOpenAI:
+		aiModel = cfg.OpenAIModel
+	case config.SummarizerProviderMock:
+		aiModel = ""
+	}
+
+	return transport.ScraperConfigResponse{
+		IntervalMinutes:         cfg.ScraperIntervalMinutes,
+		DaysLookback:            cfg.ScraperDaysLookback,
+		PublicationTimezone:     cfg.PublicationTimezone,
+		PerPage:                 cfg.FederalRegisterPerPage,
+		MaxPages:                cfg.FederalRegisterMaxPages,
+		FederalRegisterAPIURL:   cfg.FederalRegisterAPIURL,
+		FederalRegisterTimeout:  cfg.FederalRegisterTimeout,
+		AIMode:                  cfg.SummarizerProvider,
+		AIModel:                 aiModel,
+		AITimeout:               cfg.GrokTimeout,
+		FallbackSummaryTemplate: cfg.FallbackSummaryTemplate,
+	}
+}
+
+func localAgencyDiffEntry(a domain.Agency) transport.AgencyDiffEntry {
+	return transport.AgencyDiffEntry{
+		FRAgencyID: a.FRAgencyID,
+		Name:       a.Name,
+		Slug:       a.Slug,
+		ParentID:   a.ParentID,
+	}
+}
+
+func upstreamAgencyDiffEntry(a client.FRAgency) transport.AgencyDiffEntry {
+	e := transport.AgencyDiffEntry{
+		FRAgencyID: int64(a.ID),
+		Name:       a.Name,
+		Slug:       a.Slug,
+	}
+	if a.ParentID != nil {
+		p := int64(*a.ParentID)
+		e.ParentID = &p
+	}
+	return e
+}
@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/alex/opengov-go/internal/domain"
+	"github.com/alex/opengov-go/internal/repository"
+	"github.com/alex/opengov-go/internal/transport"
+)
+
+// AdminJobHandler exposes the status of scrape, pipeline and backfill jobs.
+type AdminJobHandler struct {
+	jobRepo *repository.JobRepository
+}
+
+func NewAdminJobHandler(jobRepo *repository.JobRepository) *AdminJobHandler {
+	return &AdminJobHandler{jobRepo: jobRepo}
+}
+
+// List returns the most recent jobs, newest first.
+func (h *AdminJobHandler) List(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil || limit < 1 || limit > 200 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be between 1 and 200"})
+		return
+	}
+
+	jobs, err := h.jobRepo.List(c.Request.Context(), limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list jobs"})
+		return
+	}
+
+	results := make([]transport.JobResponse, 0, len(jobs))
+	for i := range jobs {
+		results = append(results, jobToResponse(&jobs[i]))
+	}
+	c.JSON(http.StatusOK, transport.JobListResponse{Jobs: results})
+}
+
+func (h *AdminJobHandler) Get(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid job ID"})
+		return
+	}
+
+	job, err := h.jobRepo.Get(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get job"})
+		return
+	}
+	if job == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Job not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, jobToResponse(job))
+}
+
+func jobToResponse(j *domain.Job) transport.JobResponse {
+	return transport.JobResponse{
+		ID:         j.ID,
+		Type:       j.Type,
+		Status:     j.Status,
+		Processed:  j.Processed,
+		Skipped:    j.Skipped,
+		Total:      j.Total,
+		Error:      j.Error,
+		StartedAt:  j.StartedAt,
+		FinishedAt: j.FinishedAt,
+		CreatedAt:  j.CreatedAt,
+		UpdatedAt:  j.UpdatedAt,
+	}
+}
@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestAdminJobHandler_RejectsBadParams(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	h := NewAdminJobHandler(nil)
+	router := gin.New()
+	router.GET("/api/admin/jobs", h.List)
+	router.GET("/api/admin/jobs/:id", h.Get)
+
+	for _, path := range []string{
+		"/api/admin/jobs?limit=0",
+		"/api/admin/jobs?limit=201",
+		"/api/admin/jobs?limit=abc",
+		"/api/admin/jobs/abc",
+	} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("GET %s: status = %d, want %d", path, w.Code, http.StatusBadRequest)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/alex/opengov-go/internal/repository"
+	"github.com/alex/opengov-go/internal/transport"
+)
+
+// AdminRawDocumentHandler shows the raw upstream rows behind documents, lists the ones
+// that failed canonicalization and requeues them.
+type AdminRawDocumentHandler struct {
+	rawRepo *repository.RawPolicyDocumentRepository
+}
+
+func NewAdminRawDocumentHandler(rawRepo *repository.RawPolicyDocumentRepository) *AdminRawDocumentHandler {
+	return &AdminRawDocumentHandler{rawRepo: rawRepo}
+}
+
+func (h *AdminRawDocumentHandler) ListFailed(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil || limit < 1 || limit > 200 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be between 1 and 200"})
+		return
+	}
+
+	rows, err := h.rawRepo.ListFailed(c.Request.Context(), limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list failed documents"})
+		return
+	}
+
+	items := make([]transport.FailedRawDocumentResponse, 0, len(rows))
+	for _, r := range rows {
+		items = append(items, transport.FailedRawDocumentResponse{
+			ID:         r.ID,
+			SourceKey:  r.SourceKey,
+			ExternalID: r.ExternalID,
+			Error:      r.Error,
+			Attempts:   r.Attempts,
+			FetchedAt:  r.FetchedAt,
+			CreatedAt:  r.CreatedAt,
+		})
+	}
+	c.JSON(http.StatusOK, gin.H{"items": items})
+}
+
+// Retry clears a failed row's error so the next canonicalization run picks it up.
+func (h *AdminRawDocumentHandler) Retry(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid raw document ID"})
+		return
+	}
+
+	ok, err := h.rawRepo.Retry(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retry document"})
+		return
+	}
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Failed raw document not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "queued"})
+}
+
+// GetForDocument returns the stored upstream payloads for a policy document, oldest
+// first and pretty-printed, for debugging what the summarizer was given.
+func (h *AdminRawDocumentHandler) GetForDocument(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid document ID"})
+		return
+	}
+
+	rows, err := h.rawRepo.GetByDocumentID(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get raw document"})
+		return
+	}
+	if len(rows) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "No raw document found for this document"})
+		return
+	}
+
+	items := make([]transport.RawDocumentResponse, 0, len(rows))
+	for _, r := range rows {
+		items = append(items, transport.RawDocumentResponse{
+			ID:         r.ID,
+			SourceKey:  r.SourceKey,
+			ExternalID: r.ExternalID,
+			FetchedAt:  r.FetchedAt,
+			CreatedAt:  r.CreatedAt,
+			RawData:    r.RawData,
+		})
+	}
+	c.IndentedJSON(http.StatusOK, gin.H{"items": items})
+}
@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestAdminRawDocumentHandler_RejectsBadParams(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	h := NewAdminRawDocumentHandler(nil)
+	router := gin.New()
+	router.GET("/api/admin/raw-documents/failed", h.ListFailed)
+	router.POST("/api/admin/raw-documents/:id/retry", h.Retry)
+	router.GET("/api/admin/documents/:id/raw", h.GetForDocument)
+
+	for _, tc := range []struct{ method, path string }{
+		{http.MethodGet, "/api/admin/raw-documents/failed?limit=0"},
+		{http.MethodGet, "/api/admin/raw-documents/failed?limit=500"},
+		{http.MethodPost, "/api/admin/raw-documents/abc/retry"},
+		{http.MethodGet, "/api/admin/documents/abc/raw"},
+		{http.MethodGet, "/api/admin/documents/0/raw"},
+	} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(tc.method, tc.path, nil))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s %s: status = %d, want %d", tc.method, tc.path, w.Code, http.StatusBadRequest)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/alex/opengov-go/internal/repository"
+	"github.com/alex/opengov-go/internal/services"
+	"github.com/alex/opengov-go/internal/timeformat"
+	"github.com/alex/opengov-go/internal/transport"
+)
+
+// AdminReprocessHandler starts and cancels bulk AI reprocessing, used to refresh the
+// corpus after the analysis prompt changes.
+type AdminReprocessHandler struct {
+	runner *services.ReprocessRunner
+	loc    *time.Location
+}
+
+func NewAdminReprocessHandler(runner *services.ReprocessRunner, loc *time.Location) *AdminReprocessHandler {
+	return &AdminReprocessHandler{runner: runner, loc: loc}
+}
+
+// Start launches a background job re-running AI analysis for every document matching the
+// optional agency and publication date filters, and returns the job id and document
+// count. Progress is read from GET /api/admin/jobs/:id.
+func (h *AdminReprocessHandler) Start(c *gin.Context) {
+	var req transport.ReprocessAllRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	scope := repository.DocumentScope{Agency: strings.TrimSpace(req.Agency)}
+	if req.StartDate != "" {
+		start, err := timeformat.ParsePublicationDate(req.StartDate, h.loc)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "start_date must be YYYY-MM-DD"})
+			return
+		}
+		scope.PublishedFrom = start
+	}
+	if req.EndDate != "" {
+		end, err := timeformat.ParsePublicationDate(req.EndDate, h.loc)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "end_date must be YYYY-MM-DD"})
+			return
+		}
+		scope.PublishedBefore = end.AddDate(0, 0, 1)
+	}
+	if !scope.PublishedFrom.IsZero() && !scope.PublishedBefore.IsZero() && !scope.PublishedBefore.After(scope.PublishedFrom) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "end_date must not be before start_date"})
+		return
+	}
+
+	job, err := h.runner.Start(c.Request.Context(), scope)
+	switch {
+	case errors.Is(err, services.ErrSummarizerUnavailable):
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Summarizer is not configured"})
+		return
+	case errors.Is(err, services.ErrReprocessRunning):
+		c.JSON(http.StatusConflict, gin.H{"error": "Reprocess already running"})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start reprocess"})
+		return
+	}
+
+	resp := gin.H{
+		"status":  "started",
+		"message": "Reprocess started",
+		"job_id":  job.ID,
+	}
+	if job.Total != nil {
+		resp["total"] = *job.Total
+	}
+	c.JSON(http.StatusAccepted, resp)
+}
+
+// Cancel stops the running bulk reprocess. Documents already reprocessed keep their new
+// analysis.
+func (h *AdminReprocessHandler) Cancel(c *gin.Context) {
+	if err := h.runner.Cancel(); errors.Is(err, services.ErrReprocessNotRunning) {
+		c.JSON(http.StatusConflict, gin.H{"error": "No reprocess running"})
+		return
+	}
+	c.JSON(http.StatusAccepted, gin.H{
+		"status":  "cancelling",
+		"message": "Reprocess cancelling",
+	})
+}
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/alex/opengov-go/internal/config"
+	"github.com/alex/opengov-go/internal/services"
+)
+
+func TestReprocessAll_Validation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// No summarizer, so a request that passes validation gets 503 before any query runs.
+	docService := services.NewPolicyDocumentService(&config.Config{}, nil, nil, nil, nil)
+	runner := services.NewReprocessRunner(nil, docService, nil, 0)
+	h := NewAdminReprocessHandler(runner, time.UTC)
+	router := gin.New()
+	router.POST("/api/admin/reprocess-all", h.Start)
+	router.DELETE("/api/admin/reprocess-all", h.Cancel)
+
+	cases := []struct {
+		name string
+		body string
+		want int
+	}{
+		{"malformed", `{"agency":`, http.StatusBadRequest},
+		{"bad start", `{"start_date":"01/02/2025"}`, http.StatusBadRequest},
+		{"bad end", `{"end_date":"2025-13-01"}`, http.StatusBadRequest},
+		{"end before start", `{"start_date":"2025-02-01","end_date":"2025-01-31"}`, http.StatusBadRequest},
+		{"empty body", ``, http.StatusServiceUnavailable},
+		{"single day", `{"agency":"EPA","start_date":"2025-02-01","end_date":"2025-02-01"}`, http.StatusServiceUnavailable},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/api/admin/reprocess-all", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+			if w.Code != tc.want {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tc.want, w.Body.String())
+			}
+		})
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/api/admin/reprocess-all", nil))
+	if w.Code != http.StatusConflict {
+		t.Fatalf("cancel with nothing running: status = %d, want %d", w.Code, http.StatusConflict)
+	}
+}
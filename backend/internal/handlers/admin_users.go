@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/alex/opengov-go/internal/domain"
+	"github.com/alex/opengov-go/internal/middleware"
+	"github.com/alex/opengov-go/internal/repository"
+	"github.com/alex/opengov-go/internal/transport"
+)
+
+// AdminUserHandler lets superusers list accounts and manage their flags.
+type AdminUserHandler struct {
+	userRepo *repository.UserRepository
+}
+
+func NewAdminUserHandler(userRepo *repository.UserRepository) *AdminUserHandler {
+	return &AdminUserHandler{userRepo: userRepo}
+}
+
+func (h *AdminUserHandler) List(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if limit < 1 {
+		limit = 50
+	}
+	if limit > 200 {
+		limit = 200
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	users, total, err := h.userRepo.List(c.Request.Context(), limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list users"})
+		return
+	}
+
+	results := make([]transport.AdminUserResponse, 0, len(users))
+	for i := range users {
+		results = append(results, adminUserToResponse(&users[i]))
+	}
+
+	c.JSON(http.StatusOK, transport.AdminUserListResponse{
+		Users:  results,
+		Total:  total,
+		Limit:  limit,
+		Offset: offset,
+	})
+}
+
+func (h *AdminUserHandler) Get(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	user, err := h.userRepo.GetByID(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get user"})
+		return
+	}
+	if user == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, adminUserToResponse(user))
+}
+
+// Update toggles is_active, is_superuser and is_verified. Fields left out of the body are
+// unchanged. Superusers cannot demote or deactivate themselves, so the last admin can't
+// lock everyone out by accident.
+func (h *AdminUserHandler) Update(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	var req transport.AdminUserUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	if req.IsActive == nil && req.IsSuperuser == nil && req.IsVerified == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "At least one of is_active, is_superuser or is_verified is required"})
+		return
+	}
+
+	if selfID, ok := middleware.GetUserID(c); ok && selfID == id {
+		if req.IsSuperuser != nil && !*req.IsSuperuser {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "You cannot remove your own superuser access"})
+			return
+		}
+		if req.IsActive != nil && !*req.IsActive {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "You cannot deactivate your own account"})
+			return
+		}
+	}
+
+	ctx := c.Request.Context()
+	found, err := h.userRepo.SetFlags(ctx, id, req.IsActive, req.IsSuperuser, req.IsVerified)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update user"})
+		return
+	}
+	if !found {
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return
+	}
+
+	user, err := h.userRepo.GetByID(ctx, id)
+	if err != nil || user == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get user"})
+		return
+	}
+
+	c.JSON(http.StatusOK, adminUserToResponse(user))
+}
+
+// adminUserToResponse never exposes the password hash; it builds on userToResponse,
+// which doesn't carry it either.
+func adminUserToResponse(u *domain.User) transport.AdminUserResponse {
+	return transport.AdminUserResponse{
+		UserResponse: *userToResponse(u),
+		IsSuperuser:  u.GetIsSuperuser(),
+	}
+}
@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/alex/opengov-go/internal/domain"
+)
+
+func TestAdminUserUpdate_RejectsSelfLockout(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	h := NewAdminUserHandler(nil)
+	router := gin.New()
+	router.PATCH("/api/admin/users/:id", func(c *gin.Context) { c.Set("user_id", int64(7)) }, h.Update)
+
+	tests := []struct {
+		name string
+		path string
+		body string
+	}{
+		{"remove own superuser", "/api/admin/users/7", `{"is_superuser":false}`},
+		{"deactivate self", "/api/admin/users/7", `{"is_active":false}`},
+		{"empty update", "/api/admin/users/8", `{}`},
+		{"invalid id", "/api/admin/users/abc", `{"is_verified":true}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPatch, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("status = %d, want %d", w.Code, http.StatusBadRequest)
+			}
+		})
+	}
+}
+
+func TestAdminUserToResponse_OmitsPasswordHash(t *testing.T) {
+	u := &domain.User{
+		ID:             1,
+		Email:          "admin@example.com",
+		HashedPassword: "$2a$10$secrethash",
+		IsActive:       1,
+		IsSuperuser:    1,
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}
+
+	body, err := json.Marshal(adminUserToResponse(u))
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if strings.Contains(string(body), "secrethash") || strings.Contains(string(body), "password") {
+		t.Fatalf("response leaks password: %s", body)
+	}
+
+	var got map[string]interface{}
+	if err := json.Unmarshal(body, &got); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if got["is_superuser"] != true || got["email"] != "admin@example.com" {
+		t.Fatalf("unexpected response: %s", body)
+	}
+}
@@ -0,0 +1,255 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/alex/opengov-go/internal/domain"
+	"github.com/alex/opengov-go/internal/middleware"
+	"github.com/alex/opengov-go/internal/repository"
+	"github.com/alex/opengov-go/internal/timeformat"
+	"github.com/alex/opengov-go/internal/transport"
+)
+
+type AgencyHandler struct {
+	agencyRepo *repository.AgencyRepository
+	followRepo *repository.AgencyFollowRepository
+	docRepo    *repository.PolicyDocumentRepository
+}
+
+func NewAgencyHandler(agencyRepo *repository.AgencyRepository, followRepo *repository.AgencyFollowRepository, docRepo *repository.PolicyDocumentRepository) *AgencyHandler {
+	return &AgencyHandler{
+		agencyRepo: agencyRepo,
+		followRepo: followRepo,
+		docRepo:    docRepo,
+	}
+}
+
+// GetAgency looks an agency up by slug, or by local id when the param is numeric.
+func (h *AgencyHandler) GetAgency(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	agency, err := h.lookupAgency(ctx, c.Param("slug"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get agency"})
+		return
+	}
+	if agency == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Agency not found"})
+		return
+	}
+
+	resp := agencyToResponse(agency)
+	if agency.ParentID != nil {
+		parent, err := h.agencyRepo.GetByFRAgencyID(ctx, *agency.ParentID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get parent agency"})
+			return
+		}
+		if parent != nil {
+			resp.Parent = &transport.AgencyRef{
+				ID:        parent.ID,
+				Name:      parent.Name,
+				ShortName: parent.ShortName,
+				Slug:      parent.Slug,
+			}
+		}
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *AgencyHandler) GetTree(c *gin.Context) {
+	roots, err := h.agencyRepo.GetAllWithChildren(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get agencies"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"agencies": agencyNodesToResponse(roots)})
+}
+
+// GetEngagement ranks agencies by likes and bookmarks on their documents within window,
+// given in days such as "30d".
+func (h *AgencyHandler) GetEngagement(c *gin.Context) {
+	window := c.DefaultQuery("window", "30d")
+	days, ok := parseEngagementWindow(window)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "window must be between 1d and 365d"})
+		return
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil || limit < 1 || limit > 50 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be between 1 and 50"})
+		return
+	}
+
+	since := time.Now().UTC().AddDate(0, 0, -days)
+	rows, err := h.agencyRepo.GetEngagementLeaderboard(c.Request.Context(), since, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get agency engagement"})
+		return
+	}
+
+	agencies := make([]transport.AgencyEngagement, 0, len(rows))
+	for _, r := range rows {
+		agencies = append(agencies, transport.AgencyEngagement{
+			ID:        r.AgencyID,
+			Name:      r.Name,
+			ShortName: r.ShortName,
+			Slug:      r.Slug,
+			Likes:     r.Likes,
+			Bookmarks: r.Bookmarks,
+			Total:     r.Total(),
+		})
+	}
+
+	c.JSON(http.StatusOK, transport.AgencyEngagementResponse{
+		Window:   fmt.Sprintf("%dd", days),
+		Agencies: agencies,
+	})
+}
+
+// parseEngagementWindow parses a day window like "30d".
+func parseEngagementWindow(s string) (days int, ok bool) {
+	n, found := strings.CutSuffix(s, "d")
+	if !found {
+		return 0, false
+	}
+	days, err := strconv.Atoi(n)
+	if err != nil || days < 1 || days > 365 {
+		return 0, false
+	}
+	return days, true
+}
+
+func (h *AgencyHandler) GetStats(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	agency, err := h.lookupAgency(ctx, c.Param("slug"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get agency"})
+		return
+	}
+	if agency == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Agency not found"})
+		return
+	}
+
+	counts, err := h.docRepo.CountByAgency(ctx, agency)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get agency stats"})
+		return
+	}
+
+	var lastPublishedAt *string
+	if counts.LastPublishedAt != nil {
+		s := counts.LastPublishedAt.Format(timeformat.RFC3339)
+		lastPublishedAt = &s
+	}
+
+	c.JSON(http.StatusOK, transport.AgencyStatsResponse{
+		Agency: transport.AgencyRef{
+			ID:        agency.ID,
+			Name:      agency.Name,
+			ShortName: agency.ShortName,
+			Slug:      agency.Slug,
+		},
+		TotalDocuments:  counts.Total,
+		ByDocumentType:  counts.ByDocumentType,
+		LastPublishedAt: lastPublishedAt,
+	})
+}
+
+func (h *AgencyHandler) lookupAgency(ctx context.Context, slugOrID string) (*domain.Agency, error) {
+	if id, err := strconv.ParseInt(slugOrID, 10, 64); err == nil {
+		return h.agencyRepo.GetByID(ctx, id)
+	}
+	return h.agencyRepo.GetBySlug(ctx, slugOrID)
+}
+
+func (h *AgencyHandler) Follow(c *gin.Context) {
+	userID, hasAuth := middleware.GetUserID(c)
+	if !hasAuth {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	agency, err := h.agencyRepo.GetBySlug(c.Request.Context(), c.Param("slug"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get agency"})
+		return
+	}
+	if agency == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Agency not found"})
+		return
+	}
+
+	if err := h.followRepo.Follow(c.Request.Context(), userID, agency.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to follow agency"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"is_following": true})
+}
+
+func (h *AgencyHandler) Unfollow(c *gin.Context) {
+	userID, hasAuth := middleware.GetUserID(c)
+	if !hasAuth {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	agency, err := h.agencyRepo.GetBySlug(c.Request.Context(), c.Param("slug"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get agency"})
+		return
+	}
+	if agency == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Agency not found"})
+		return
+	}
+
+	if err := h.followRepo.Unfollow(c.Request.Context(), userID, agency.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to unfollow agency"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"is_following": false})
+}
+
+func agencyToResponse(a *domain.Agency) transport.AgencyResponse {
+	return transport.AgencyResponse{
+		ID:          a.ID,
+		FRAgencyID:  a.FRAgencyID,
+		Name:        a.Name,
+		ShortName:   a.ShortName,
+		Slug:        a.Slug,
+		Description: a.Description,
+		URL:         a.URL,
+		ParentID:    a.ParentID,
+		CreatedAt:   a.CreatedAt.Format(timeformat.RFC3339),
+		UpdatedAt:   a.UpdatedAt.Format(timeformat.RFC3339),
+	}
+}
+
+func agencyNodesToResponse(nodes []*repository.AgencyNode) []transport.AgencyTreeNode {
+	out := make([]transport.AgencyTreeNode, len(nodes))
+	for i, n := range nodes {
+		out[i] = transport.AgencyTreeNode{
+			ID:         n.Agency.ID,
+			FRAgencyID: n.Agency.FRAgencyID,
+			Name:       n.Agency.Name,
+			ShortName:  n.Agency.ShortName,
+			Slug:       n.Agency.Slug,
+			Children:   agencyNodesToResponse(n.Children),
+		}
+	}
+	return out
+}
@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestParseEngagementWindow(t *testing.T) {
+	tests := []struct {
+		in   string
+		days int
+		ok   bool
+	}{
+		{"30d", 30, true},
+		{"1d", 1, true},
+		{"365d", 365, true},
+		{"0d", 0, false},
+		{"366d", 0, false},
+		{"30", 0, false},
+		{"2w", 0, false},
+		{"d", 0, false},
+	}
+	for _, tt := range tests {
+		days, ok := parseEngagementWindow(tt.in)
+		if days != tt.days || ok != tt.ok {
+			t.Fatalf("parseEngagementWindow(%q) = %d, %v; want %d, %v", tt.in, days, ok, tt.days, tt.ok)
+		}
+	}
+}
+
+func TestGetEngagement_RejectsInvalidParams(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	h := NewAgencyHandler(nil, nil, nil)
+	router := gin.New()
+	router.GET("/api/agencies/engagement", h.GetEngagement)
+
+	for _, query := range []string{"?window=abc", "?window=0d", "?limit=0", "?limit=51"} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/agencies/engagement"+query, nil))
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("%s: status = %d, want %d", query, w.Code, http.StatusBadRequest)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -33,6 +34,10 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	}
 
 	user, err := h.authService.Authenticate(c.Request.Context(), req.Email, req.Password)
+	if errors.Is(err, services.ErrUserInactive) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Account is disabled"})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid credentials"})
 		return
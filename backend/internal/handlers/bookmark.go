@@ -2,7 +2,6 @@ package handlers
 
 import (
 	"net/http"
-	"strconv"
 
 	"github.com/gin-gonic/gin"
 
@@ -14,12 +13,14 @@ import (
 type BookmarkHandler struct {
 	bookmarkRepo *repository.BookmarkRepository
 	feedService  *services.FeedService
+	feedEntries  FeedEntryChecker
 }
 
-func NewBookmarkHandler(bookmarkRepo *repository.BookmarkRepository, feedService *services.FeedService) *BookmarkHandler {
+func NewBookmarkHandler(bookmarkRepo *repository.BookmarkRepository, feedService *services.FeedService, feedEntries FeedEntryChecker) *BookmarkHandler {
 	return &BookmarkHandler{
 		bookmarkRepo: bookmarkRepo,
 		feedService:  feedService,
+		feedEntries:  feedEntries,
 	}
 }
 
@@ -30,9 +31,8 @@ func (h *BookmarkHandler) Toggle(c *gin.Context) {
 		return
 	}
 
-	feedEntryID, err := strconv.ParseInt(c.Param("feed_entry_id"), 10, 64)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid feed entry ID"})
+	feedEntryID, ok := feedEntryIDParam(c, h.feedEntries)
+	if !ok {
 		return
 	}
 
@@ -54,16 +54,25 @@ func (h *BookmarkHandler) GetBookmarks(c *gin.Context) {
 		return
 	}
 
-	items, err := h.feedService.GetBookmarkedFeed(c.Request.Context(), userID)
+	// Most users have a handful of bookmarks, so the default page fits them all.
+	page, limit, ok := parsePagination(c, 50)
+	if !ok {
+		return
+	}
+
+	sort := c.DefaultQuery("sort", repository.BookmarkSortNewest)
+	if !repository.ValidBookmarkSort(sort) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "sort must be one of newest, oldest, published_newest, published_oldest"})
+		return
+	}
+
+	resp, err := h.feedService.GetBookmarkedFeed(c.Request.Context(), userID, page, limit, sort, c.Query("agency"))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch bookmarks"})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"items": items,
-		"total": len(items),
-	})
+	c.JSON(http.StatusOK, resp)
 }
 
 func (h *BookmarkHandler) Remove(c *gin.Context) {
@@ -73,21 +82,21 @@ func (h *BookmarkHandler) Remove(c *gin.Context) {
 		return
 	}
 
-	feedEntryID, err := strconv.ParseInt(c.Param("feed_entry_id"), 10, 64)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid feed entry ID"})
+	feedEntryID, ok := feedEntryIDParam(c, h.feedEntries)
+	if !ok {
 		return
 	}
 
-	err = h.bookmarkRepo.Remove(c.Request.Context(), userID, feedEntryID)
+	err := h.bookmarkRepo.Remove(c.Request.Context(), userID, feedEntryID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to remove bookmark"})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"success": true,
-		"message": "Bookmark removed",
+		"success":       true,
+		"message":       "Bookmark removed",
+		"is_bookmarked": false,
 	})
 }
 
@@ -98,9 +107,8 @@ func (h *BookmarkHandler) GetStatus(c *gin.Context) {
 		return
 	}
 
-	feedEntryID, err := strconv.ParseInt(c.Param("feed_entry_id"), 10, 64)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid feed entry ID"})
+	feedEntryID, ok := feedEntryIDParam(c, h.feedEntries)
+	if !ok {
 		return
 	}
 
@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/alex/opengov-go/internal/repository"
+	"github.com/alex/opengov-go/internal/transport"
+)
+
+type CategoryHandler struct {
+	docRepo *repository.PolicyDocumentRepository
+}
+
+func NewCategoryHandler(docRepo *repository.PolicyDocumentRepository) *CategoryHandler {
+	return &CategoryHandler{docRepo: docRepo}
+}
+
+// List returns every category in use with its document count, most used first.
+func (h *CategoryHandler) List(c *gin.Context) {
+	rows, err := h.docRepo.CountByCategory(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get categories"})
+		return
+	}
+
+	categories := make([]transport.CategoryCount, 0, len(rows))
+	for _, r := range rows {
+		categories = append(categories, transport.CategoryCount{Name: r.Category, Count: r.Count})
+	}
+	c.JSON(http.StatusOK, transport.CategoriesResponse{Categories: categories})
+}
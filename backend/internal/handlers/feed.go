@@ -1,57 +1,400 @@
 package handlers
 
 import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"log"
 	"net/http"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/alex/opengov-go/internal/constants"
 	"github.com/alex/opengov-go/internal/middleware"
+	"github.com/alex/opengov-go/internal/repository"
 	"github.com/alex/opengov-go/internal/services"
+	"github.com/alex/opengov-go/internal/timeformat"
 	"github.com/alex/opengov-go/internal/transport"
 )
 
 type FeedHandler struct {
 	feedService *services.FeedService
+	feedCache   *services.FeedCache
 }
 
-func NewFeedHandler(feedService *services.FeedService) *FeedHandler {
+func NewFeedHandler(feedService *services.FeedService, feedCache *services.FeedCache) *FeedHandler {
 	return &FeedHandler{
 		feedService: feedService,
+		feedCache:   feedCache,
 	}
 }
 
 func (h *FeedHandler) GetFeed(c *gin.Context) {
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	page, limit, ok := parseFeedPagination(c)
+	if !ok {
+		return
+	}
 	sort := c.DefaultQuery("sort", "newest")
+	filter, ok := feedFilterFromQuery(c)
+	if !ok {
+		return
+	}
+	if filter.IncludeHidden, ok = includeHiddenFromQuery(c); !ok {
+		return
+	}
 
-	if page < 1 {
-		page = 1
+	if c.Query("balance") == "true" {
+		sort = repository.FeedSortBalanced
 	}
-	if limit < 1 {
-		limit = 20
+
+	userID, hasAuth := middleware.GetUserID(c)
+	if v := c.Query("leaning"); v != "" {
+		if v != "opposite" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "leaning must be opposite"})
+			return
+		}
+		if !hasAuth {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+			return
+		}
+		side, err := h.feedService.OppositeSide(c.Request.Context(), userID)
+		if errors.Is(err, services.ErrNoPoliticalLeaning) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Set a left or right political leaning in your profile to use leaning=opposite"})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch feed"})
+			return
+		}
+		filter.PoliticalSide = side
 	}
-	if limit > 100 {
-		limit = 100
+
+	if code := c.Query("state"); code != "" {
+		name, ok := constants.USStates[strings.ToUpper(code)]
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "state must be a two-letter US state code"})
+			return
+		}
+		filter.StateName = name
+	} else if c.Query("relevant_to_state") == "true" {
+		if !hasAuth {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+			return
+		}
+		name, err := h.feedService.ProfileStateName(c.Request.Context(), userID)
+		if errors.Is(err, services.ErrNoProfileState) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Set a state in your profile to use relevant_to_state"})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch feed"})
+			return
+		}
+		filter.StateName = name
 	}
 
-	offset := (page - 1) * limit
-	if offset > 10000 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Page number too high"})
+	// English is the source language, so lang=en is the same as no lang at all.
+	lang := strings.ToLower(c.Query("lang"))
+	if _, ok := constants.Languages[lang]; !ok && lang != "" && lang != "en" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unsupported lang"})
 		return
 	}
 
-	userID, hasAuth := middleware.GetUserID(c)
+	// since compares against when entries were added to the feed, not published, so
+	// late-published documents still count as new. The server time is read before the
+	// query so an entry added meanwhile is returned twice rather than never.
+	if v := c.Query("since"); v != "" {
+		since, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "since must be an RFC3339 timestamp"})
+			return
+		}
+		filter.CreatedAfter = since
+	}
+	serverTime := time.Now().UTC().Format(time.RFC3339Nano)
+
+	// Anonymous responses depend only on the feed's contents, so pollers can revalidate
+	// them with If-None-Match instead of downloading the page again. A cached page
+	// answers with the ETag it was fetched under, without a database round trip. Every
+	// poller sends its own since, so caching those would only evict useful pages.
+	cacheable := !hasAuth && filter.CreatedAfter.IsZero()
 	var resp transport.FeedResponse
-	var err error
+	var etag string
+	var cached bool
+	if cacheable {
+		resp, etag, cached = h.feedCache.Get(page, limit, sort, filter)
+	}
+	if !hasAuth {
+		if !cached {
+			var err error
+			etag, err = h.feedService.FeedETag(c.Request.Context())
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch feed"})
+				return
+			}
+		}
+		c.Header("ETag", etag)
+		c.Header("Cache-Control", "public, no-cache")
+		if etagMatches(c.GetHeader("If-None-Match"), etag) {
+			c.Status(http.StatusNotModified)
+			return
+		}
+	}
 
-	if hasAuth {
-		resp, err = h.feedService.GetFeed(c.Request.Context(), &userID, page, limit, sort)
-	} else {
-		resp, err = h.feedService.GetFeed(c.Request.Context(), nil, page, limit, sort)
+	if !cached {
+		var uid *int64
+		if hasAuth {
+			uid = &userID
+		}
+		var err error
+		resp, err = h.feedService.GetFeed(c.Request.Context(), uid, page, limit, sort, filter)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch feed"})
+			return
+		}
+		// A cached page keeps the server_time it was fetched at, so a since built from it
+		// still covers entries added while it was cached.
+		resp.ServerTime = serverTime
+		if cacheable {
+			h.feedCache.Put(page, limit, sort, filter, etag, resp)
+		}
+	}
+
+	if _, ok := constants.Languages[lang]; ok {
+		if err := h.feedService.Translate(c.Request.Context(), resp.Items, lang); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch feed"})
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// feedFilterFromQuery reads the content filters shared by the feed and its export,
+// writing a 400 and returning false when one is invalid.
+func feedFilterFromQuery(c *gin.Context) (repository.FeedFilter, bool) {
+	filter := repository.FeedFilter{
+		Agency:       c.Query("agency"),
+		DocumentType: c.Query("document_type"),
+		Keyword:      c.Query("q"),
+	}
+	if v := c.Query("cfr_title"); v != "" {
+		title, err := strconv.Atoi(v)
+		if err != nil || title < 1 || title > 50 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "cfr_title must be between 1 and 50"})
+			return filter, false
+		}
+		filter.CFRTitle = title
+	}
+	if v := c.Query("category"); v != "" {
+		if !slices.Contains(constants.Categories, v) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Unknown category"})
+			return filter, false
+		}
+		filter.Category = v
+	}
+	if v := c.Query("source"); v != "" {
+		key, ok := constants.SourceAliases[strings.ToLower(v)]
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Unknown source"})
+			return filter, false
+		}
+		filter.Source = key
+	}
+	return filter, true
+}
+
+// includeHiddenFromQuery reads include_hidden, which only superusers may set. It writes
+// a 403 and returns ok=false for anyone else.
+func includeHiddenFromQuery(c *gin.Context) (includeHidden, ok bool) {
+	if c.Query("include_hidden") != "true" {
+		return false, true
+	}
+	if !middleware.IsSuperuser(c) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "include_hidden requires superuser access"})
+		return false, false
+	}
+	return true, true
+}
+
+// exportFilterFromQuery reads the feed filters plus the export's published_after
+// (inclusive) and published_before (exclusive) days, writing a 400 and returning false
+// when one is invalid.
+func (h *FeedHandler) exportFilterFromQuery(c *gin.Context) (repository.FeedFilter, bool) {
+	filter, ok := feedFilterFromQuery(c)
+	if !ok {
+		return filter, false
+	}
+	for param, dst := range map[string]*time.Time{
+		"published_after":  &filter.PublishedFrom,
+		"published_before": &filter.PublishedBefore,
+	} {
+		v := c.Query(param)
+		if v == "" {
+			continue
+		}
+		day, err := h.feedService.ParsePublicationDay(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": param + " must be a date (YYYY-MM-DD)"})
+			return filter, false
+		}
+		*dst = day
+	}
+	if !filter.PublishedFrom.IsZero() && !filter.PublishedBefore.IsZero() && !filter.PublishedFrom.Before(filter.PublishedBefore) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "published_after must be before published_before"})
+		return filter, false
+	}
+	return filter, true
+}
+
+// exportFlushRows is how many rows an export buffers before flushing to the client.
+const exportFlushRows = 500
+
+// ExportCSV streams the entries matching the export filters as a CSV download, newest
+// first, capped at services.ExportMaxRows.
+func (h *FeedHandler) ExportCSV(c *gin.Context) {
+	filter, ok := h.exportFilterFromQuery(c)
+	if !ok {
+		return
+	}
+
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", `attachment; filename="opengov-feed.csv"`)
+
+	w := csv.NewWriter(c.Writer)
+	_ = w.Write([]string{"title", "agency", "summary", "impact_score", "political_score", "published_at", "source_url"})
+	rows := 0
+	err := h.feedService.Export(c.Request.Context(), filter, nil, func(row repository.FeedExportRow) error {
+		political := ""
+		if row.PoliticalScore != nil {
+			political = strconv.Itoa(*row.PoliticalScore)
+		}
+		if err := w.Write([]string{
+			row.Title,
+			derefString(row.Agency),
+			row.Summary,
+			derefString(row.ImpactScore),
+			political,
+			h.feedService.PublicationDay(row.PublishedAt),
+			row.SourceURL,
+		}); err != nil {
+			return err
+		}
+		if rows++; rows%exportFlushRows == 0 {
+			w.Flush()
+			return w.Error()
+		}
+		return nil
+	})
+	if err != nil {
+		exportFailed(c, rows, err)
+		return
 	}
+	w.Flush()
+}
 
+// ExportJSON streams the entries matching the export filters as NDJSON, one object per
+// line, newest first and capped at services.ExportMaxRows. Each object carries a cursor;
+// pass the last one back as ?cursor= to continue after it.
+func (h *FeedHandler) ExportJSON(c *gin.Context) {
+	filter, ok := h.exportFilterFromQuery(c)
+	if !ok {
+		return
+	}
+	var after *repository.FeedCursor
+	if v := c.Query("cursor"); v != "" {
+		cursor, err := services.ParseExportCursor(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid cursor"})
+			return
+		}
+		after = cursor
+	}
+
+	c.Header("Content-Type", "application/x-ndjson")
+	c.Header("Content-Disposition", `attachment; filename="opengov-feed.ndjson"`)
+
+	buf := bufio.NewWriter(c.Writer)
+	enc := json.NewEncoder(buf)
+	rows := 0
+	err := h.feedService.Export(c.Request.Context(), filter, after, func(row repository.FeedExportRow) error {
+		if err := enc.Encode(transport.FeedExportItem{
+			ID:             row.ID,
+			Title:          row.Title,
+			Agency:         row.Agency,
+			Summary:        row.Summary,
+			ImpactScore:    row.ImpactScore,
+			PoliticalScore: row.PoliticalScore,
+			PublishedAt:    row.PublishedAt.Format(timeformat.DBTime),
+			SourceURL:      row.SourceURL,
+			Cursor:         services.EncodeExportCursor(row),
+		}); err != nil {
+			return err
+		}
+		if rows++; rows%exportFlushRows == 0 {
+			return buf.Flush()
+		}
+		return nil
+	})
+	if err != nil {
+		exportFailed(c, rows, err)
+		return
+	}
+	_ = buf.Flush()
+}
+
+// exportFailed reports a failed export. Once rows have gone out the status is fixed,
+// so the client just sees a truncated file.
+func exportFailed(c *gin.Context, rows int, err error) {
+	log.Printf("Feed export failed after %d rows: %v", rows, err)
+	if !c.Writer.Written() {
+		c.Writer.Header().Del("Content-Type")
+		c.Writer.Header().Del("Content-Disposition")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to export feed"})
+	}
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// etagMatches reports whether an If-None-Match header lists etag, using the weak
+// comparison RFC 9110 requires for GET.
+func etagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *FeedHandler) GetFollowing(c *gin.Context) {
+	userID, hasAuth := middleware.GetUserID(c)
+	if !hasAuth {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	page, limit, ok := parseFeedPagination(c)
+	if !ok {
+		return
+	}
+	sort := c.DefaultQuery("sort", "newest")
+
+	resp, err := h.feedService.GetFollowingFeed(c.Request.Context(), userID, page, limit, sort)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch feed"})
 		return
@@ -60,6 +403,99 @@ func (h *FeedHandler) GetFeed(c *gin.Context) {
 	c.JSON(http.StatusOK, resp)
 }
 
+// GetRecommended returns entries similar to the caller's bookmarks.
+func (h *FeedHandler) GetRecommended(c *gin.Context) {
+	userID, hasAuth := middleware.GetUserID(c)
+	if !hasAuth {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit < 1 || limit > 50 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be between 1 and 50"})
+		return
+	}
+
+	items, err := h.feedService.GetRecommended(c.Request.Context(), userID, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch recommendations"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"items": items,
+		"total": len(items),
+	})
+}
+
+func (h *FeedHandler) GetThemes(c *gin.Context) {
+	days, err := strconv.Atoi(c.DefaultQuery("days", "7"))
+	if err != nil || days < 1 || days > 90 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "days must be between 1 and 90"})
+		return
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil || limit < 1 || limit > 50 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be between 1 and 50"})
+		return
+	}
+
+	resp, err := h.feedService.GetThemes(c.Request.Context(), days, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch themes"})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *FeedHandler) GetArchive(c *gin.Context) {
+	resp, err := h.feedService.GetArchiveBuckets(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch archive"})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *FeedHandler) GetArchiveMonth(c *gin.Context) {
+	year, err := strconv.Atoi(c.Param("year"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid year"})
+		return
+	}
+	month, err := strconv.Atoi(c.Param("month"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid month"})
+		return
+	}
+
+	page, limit, ok := parseFeedPagination(c)
+	if !ok {
+		return
+	}
+	sort := c.DefaultQuery("sort", "newest")
+
+	var userIDPtr *int64
+	if userID, hasAuth := middleware.GetUserID(c); hasAuth {
+		userIDPtr = &userID
+	}
+
+	resp, err := h.feedService.GetArchiveMonth(c.Request.Context(), userIDPtr, year, month, page, limit, sort)
+	if errors.Is(err, services.ErrInvalidArchiveMonth) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch archive"})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
 func (h *FeedHandler) GetItem(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
@@ -68,14 +504,19 @@ func (h *FeedHandler) GetItem(c *gin.Context) {
 		return
 	}
 
+	includeHidden, ok := includeHiddenFromQuery(c)
+	if !ok {
+		return
+	}
+
 	userID, hasAuth := middleware.GetUserID(c)
 	var item *transport.FeedEntryResponse
 	var svcErr error
 
 	if hasAuth {
-		item, svcErr = h.feedService.GetItem(c.Request.Context(), &userID, id)
+		item, svcErr = h.feedService.GetItem(c.Request.Context(), &userID, id, includeHidden)
 	} else {
-		item, svcErr = h.feedService.GetItem(c.Request.Context(), nil, id)
+		item, svcErr = h.feedService.GetItem(c.Request.Context(), nil, id, includeHidden)
 	}
 
 	if svcErr != nil {
@@ -89,3 +530,97 @@ func (h *FeedHandler) GetItem(c *gin.Context) {
 
 	c.JSON(http.StatusOK, item)
 }
+
+// GetRelated returns entries sharing an agency or a category with :id.
+func (h *FeedHandler) GetRelated(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid feed entry ID"})
+		return
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "5"))
+	if err != nil || limit < 1 || limit > 20 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be between 1 and 20"})
+		return
+	}
+	includeHidden, ok := includeHiddenFromQuery(c)
+	if !ok {
+		return
+	}
+
+	items, err := h.feedService.GetRelated(c.Request.Context(), id, includeHidden, limit)
+	if errors.Is(err, services.ErrFeedEntryNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Feed entry not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch related entries"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"items": items,
+		"total": len(items),
+	})
+}
+
+// GetItemFull returns a feed entry with the full upstream abstract alongside its summary.
+func (h *FeedHandler) GetItemFull(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid feed entry ID"})
+		return
+	}
+
+	includeHidden, ok := includeHiddenFromQuery(c)
+	if !ok {
+		return
+	}
+
+	var userID *int64
+	if uid, hasAuth := middleware.GetUserID(c); hasAuth {
+		userID = &uid
+	}
+
+	item, err := h.feedService.GetItemFull(c.Request.Context(), userID, id, includeHidden)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch feed entry"})
+		return
+	}
+	if item == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Feed entry not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
+
+// parseFeedPagination reads page/limit query params with the feed's defaults and caps.
+// It writes a 400 response and returns ok=false when the page is too deep.
+func parseFeedPagination(c *gin.Context) (page, limit int, ok bool) {
+	return parsePagination(c, 20)
+}
+
+// parsePagination reads page and limit, falling back to defaultLimit and capping limit
+// at 100. It responds with 400 and returns ok=false when the page is too deep.
+func parsePagination(c *gin.Context, defaultLimit int) (page, limit int, ok bool) {
+	page, _ = strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ = strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(defaultLimit)))
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = defaultLimit
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	offset := (page - 1) * limit
+	if offset > 10000 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Page number too high"})
+		return 0, 0, false
+	}
+	return page, limit, true
+}
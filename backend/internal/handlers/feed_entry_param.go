@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FeedEntryChecker reports whether a feed entry exists.
+type FeedEntryChecker interface {
+	Exists(ctx context.Context, feedEntryID int64) (bool, error)
+}
+
+// feedEntryIDParam parses the :feed_entry_id path parameter and confirms the entry
+// exists. Non-numeric, non-positive and out-of-range ids get a 400 without touching
+// the database; unknown ids get a 404. It writes the response and returns false when
+// the id can't be used.
+func feedEntryIDParam(c *gin.Context, entries FeedEntryChecker) (int64, bool) {
+	feedEntryID, err := strconv.ParseInt(c.Param("feed_entry_id"), 10, 64)
+	if err != nil || feedEntryID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid feed entry ID"})
+		return 0, false
+	}
+
+	exists, err := entries.Exists(c.Request.Context(), feedEntryID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to look up feed entry"})
+		return 0, false
+	}
+	if !exists {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Feed entry not found"})
+		return 0, false
+	}
+	return feedEntryID, true
+}
@@ -0,0 +1,292 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/alex/opengov-go/internal/repository"
+	"github.com/alex/opengov-go/internal/services"
+	"github.com/alex/opengov-go/internal/transport"
+)
+
+func TestGetArchiveMonth_RejectsInvalidMonth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// Validation runs before any repository access, so no DB is needed.
+	h := NewFeedHandler(services.NewFeedService(nil, nil, nil, time.UTC), nil)
+	router := gin.New()
+	router.GET("/api/feed/archive/:year/:month", h.GetArchiveMonth)
+
+	for _, path := range []string{
+		"/api/feed/archive/2025/13",
+		"/api/feed/archive/2025/0",
+		"/api/feed/archive/2025/jan",
+		"/api/feed/archive/1800/1",
+	} {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("%s: status = %d, want %d", path, w.Code, http.StatusBadRequest)
+		}
+	}
+}
+
+func TestGetFeed_RejectsInvalidCFRTitle(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	h := NewFeedHandler(services.NewFeedService(nil, nil, nil, time.UTC), nil)
+	router := gin.New()
+	router.GET("/api/feed", h.GetFeed)
+
+	for _, v := range []string{"0", "51", "forty"} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/feed?cfr_title="+v, nil))
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("cfr_title=%s: status = %d, want %d", v, w.Code, http.StatusBadRequest)
+		}
+	}
+}
+
+func TestGetFeed_IncludeHiddenRequiresSuperuser(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	h := NewFeedHandler(services.NewFeedService(nil, nil, nil, time.UTC), nil)
+	router := gin.New()
+	router.GET("/api/feed", h.GetFeed)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/feed?include_hidden=true", nil))
+
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusForbidden)
+	}
+}
+
+func TestGetRecommended_Validation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	h := NewFeedHandler(services.NewFeedService(nil, nil, nil, time.UTC), nil)
+	router := gin.New()
+	router.GET("/api/feed/recommended", h.GetRecommended)
+	router.GET("/api/auth/feed/recommended", func(c *gin.Context) { c.Set("user_id", int64(7)) }, h.GetRecommended)
+
+	for path, want := range map[string]int{
+		"/api/feed/recommended":               http.StatusUnauthorized,
+		"/api/auth/feed/recommended?limit=0":  http.StatusBadRequest,
+		"/api/auth/feed/recommended?limit=51": http.StatusBadRequest,
+	} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		if w.Code != want {
+			t.Fatalf("%s: status = %d, want %d", path, w.Code, want)
+		}
+	}
+}
+
+func TestGetItemFull_RejectsInvalidID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	h := NewFeedHandler(services.NewFeedService(nil, nil, nil, time.UTC), nil)
+	router := gin.New()
+	router.GET("/api/feed/:id/full", h.GetItemFull)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/feed/abc/full", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+}
+
+func TestParsePagination(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cases := []struct {
+		query       string
+		page, limit int
+		ok          bool
+	}{
+		{"", 1, 50, true},
+		{"?page=3&limit=10", 3, 10, true},
+		{"?page=-1&limit=0", 1, 50, true},
+		{"?limit=500", 1, 100, true},
+		{"?page=1000&limit=100", 0, 0, false},
+	}
+	for _, tc := range cases {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/api/bookmarks"+tc.query, nil)
+
+		page, limit, ok := parsePagination(c, 50)
+		if page != tc.page || limit != tc.limit || ok != tc.ok {
+			t.Errorf("%q: got page=%d limit=%d ok=%v, want %d %d %v", tc.query, page, limit, ok, tc.page, tc.limit, tc.ok)
+		}
+	}
+}
+
+func TestGetFeed_LeaningValidation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	h := NewFeedHandler(services.NewFeedService(nil, nil, nil, time.UTC), nil)
+	router := gin.New()
+	router.GET("/anon", h.GetFeed)
+	router.GET("/me", func(c *gin.Context) { c.Set("user_id", int64(7)) }, h.GetFeed)
+
+	for path, want := range map[string]int{
+		"/anon?leaning=opposite": http.StatusUnauthorized,
+		"/me?leaning=same":       http.StatusBadRequest,
+	} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		if w.Code != want {
+			t.Errorf("%s: status = %d, want %d", path, w.Code, want)
+		}
+	}
+}
+
+func TestGetFeed_RejectsInvalidFilters(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	h := NewFeedHandler(services.NewFeedService(nil, nil, nil, time.UTC), nil)
+	router := gin.New()
+	router.GET("/api/feed", h.GetFeed)
+
+	for path, want := range map[string]int{
+		"/api/feed?state=XX":               http.StatusBadRequest,
+		"/api/feed?state=California":       http.StatusBadRequest,
+		"/api/feed?relevant_to_state=true": http.StatusUnauthorized,
+		"/api/feed?category=aliens":        http.StatusBadRequest,
+		"/api/feed?source=twitter":         http.StatusBadRequest,
+		"/api/feed?lang=klingon":           http.StatusBadRequest,
+		"/api/feed?since=2025-03-10":       http.StatusBadRequest,
+		"/api/feed?since=yesterday":        http.StatusBadRequest,
+	} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		if w.Code != want {
+			t.Errorf("%s: status = %d, want %d", path, w.Code, want)
+		}
+	}
+}
+
+// Only superusers may see hidden documents. The feed service has no repository, so a
+// request that got past the check would panic.
+func TestGetItem_IncludeHiddenRequiresSuperuser(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	h := NewFeedHandler(services.NewFeedService(nil, nil, nil, time.UTC), nil)
+	router := gin.New()
+	router.GET("/api/feed/:id", h.GetItem)
+	router.GET("/api/feed/:id/full", h.GetItemFull)
+	router.GET("/api/feed/:id/related", h.GetRelated)
+
+	for _, path := range []string{
+		"/api/feed/1?include_hidden=true",
+		"/api/feed/1/full?include_hidden=true",
+		"/api/feed/1/related?include_hidden=true",
+	} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		if w.Code != http.StatusForbidden {
+			t.Errorf("%s: status = %d, want %d", path, w.Code, http.StatusForbidden)
+		}
+	}
+}
+
+func TestGetRelated_Validation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	h := NewFeedHandler(services.NewFeedService(nil, nil, nil, time.UTC), nil)
+	router := gin.New()
+	router.GET("/api/feed/:id/related", h.GetRelated)
+
+	for _, path := range []string{
+		"/api/feed/abc/related",
+		"/api/feed/0/related",
+		"/api/feed/1/related?limit=0",
+		"/api/feed/1/related?limit=21",
+	} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want %d", path, w.Code, http.StatusBadRequest)
+		}
+	}
+}
+
+func TestETagMatches(t *testing.T) {
+	const etag = `W/"5f1-10-3"`
+	cases := map[string]bool{
+		"":                        false,
+		`W/"5f1-10-3"`:            true,
+		`"5f1-10-3"`:              true,
+		`"abc", W/"5f1-10-3"`:     true,
+		"*":                       true,
+		`W/"5f1-10-4"`:            false,
+		`W/"5f1-10-3-extra", "x"`: false,
+	}
+	for header, want := range cases {
+		if got := etagMatches(header, etag); got != want {
+			t.Errorf("etagMatches(%q) = %v, want %v", header, got, want)
+		}
+	}
+}
+
+func TestGetFeed_ServesCacheHitsWithoutDatabase(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// The service has no repository, so any database access would panic.
+	cache := services.NewFeedCache(time.Minute)
+	cache.Put(1, 20, "newest", repository.FeedFilter{}, `W/"5f1"`, transport.FeedResponse{Page: 1, Total: 7})
+	h := NewFeedHandler(services.NewFeedService(nil, nil, nil, time.UTC), cache)
+	router := gin.New()
+	router.GET("/api/feed", h.GetFeed)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/feed?page=1&limit=20", nil))
+	if w.Code != http.StatusOK || w.Header().Get("ETag") != `W/"5f1"` {
+		t.Fatalf("status = %d, ETag = %q; want 200 with the cached ETag", w.Code, w.Header().Get("ETag"))
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/feed?page=1&limit=20", nil)
+	req.Header.Set("If-None-Match", `W/"5f1"`)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusNotModified {
+		t.Fatalf("revalidation status = %d, want %d", w.Code, http.StatusNotModified)
+	}
+}
+
+func TestExport_Validation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	h := NewFeedHandler(services.NewFeedService(nil, nil, nil, time.UTC), nil)
+	router := gin.New()
+	router.GET("/api/feed/export.csv", h.ExportCSV)
+	router.GET("/api/feed/export.json", h.ExportJSON)
+
+	for _, path := range []string{
+		"/api/feed/export.csv?published_after=01/02/2025",
+		"/api/feed/export.csv?published_before=2025-13-01",
+		"/api/feed/export.csv?published_after=2025-02-01&published_before=2025-01-01",
+		"/api/feed/export.csv?published_after=2025-01-01&published_before=2025-01-01",
+		"/api/feed/export.csv?cfr_title=99",
+		"/api/feed/export.csv?category=aliens",
+		"/api/feed/export.json?published_after=yesterday",
+		"/api/feed/export.json?cursor=not-a-cursor",
+	} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want %d", path, w.Code, http.StatusBadRequest)
+		}
+		if got := w.Header().Get("Content-Disposition"); got != "" {
+			t.Errorf("%s: Content-Disposition = %q on an error", path, got)
+		}
+	}
+}
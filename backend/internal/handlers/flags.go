@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/alex/opengov-go/internal/services"
+	"github.com/alex/opengov-go/internal/transport"
+)
+
+type FlagHandler struct {
+	flags *services.FeatureFlags
+}
+
+func NewFlagHandler(flags *services.FeatureFlags) *FlagHandler {
+	return &FlagHandler{flags: flags}
+}
+
+func (h *FlagHandler) List(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"flags": h.flags.All()})
+}
+
+func (h *FlagHandler) Update(c *gin.Context) {
+	var req transport.FlagUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.Enabled == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	name := c.Param("name")
+	err := h.flags.Set(c.Request.Context(), name, *req.Enabled)
+	if errors.Is(err, services.ErrInvalidFlagName) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update flag"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"name": name, "enabled": *req.Enabled})
+}
@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/alex/opengov-go/internal/domain"
+	"github.com/alex/opengov-go/internal/repository"
+)
+
+type HealthHandler struct {
+	docRepo    *repository.PolicyDocumentRepository
+	stateRepo  *repository.ScrapeStateRepository
+	staleAfter time.Duration
+}
+
+func NewHealthHandler(docRepo *repository.PolicyDocumentRepository, stateRepo *repository.ScrapeStateRepository, staleAfter time.Duration) *HealthHandler {
+	return &HealthHandler{
+		docRepo:    docRepo,
+		stateRepo:  stateRepo,
+		staleAfter: staleAfter,
+	}
+}
+
+// ScraperHealth reports how long ago the scraper last made progress and fails with 503
+// once that exceeds the stale threshold, so monitoring catches a stalled scraper. A
+// successful run that found no new documents counts as progress.
+func (h *HealthHandler) ScraperHealth(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=60")
+
+	count, err := h.docRepo.Count(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"status": "error", "error": "Failed to count documents"})
+		return
+	}
+
+	latest, err := h.docRepo.GetLatest(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"status": "error", "error": "Failed to get latest document"})
+		return
+	}
+
+	lastRun, err := h.stateRepo.GetLatestRun(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"status": "error", "error": "Failed to get scrape state"})
+		return
+	}
+
+	var lastScrape *time.Time
+	if latest != nil {
+		lastScrape = &latest.FetchedAt
+	}
+
+	status, body := scraperHealth(lastScrape, lastRun, count, time.Now(), h.staleAfter)
+	c.JSON(status, body)
+}
+
+// scraperHealth measures freshness from the later of the newest document's fetch and
+// the last successful scrape run. lastRun may be nil.
+func scraperHealth(lastScrape *time.Time, lastRun *domain.ScrapeState, count int, now time.Time, staleAfter time.Duration) (int, gin.H) {
+	body := gin.H{
+		"status":              "ok",
+		"document_count":      count,
+		"stale_after_seconds": int(staleAfter.Seconds()),
+	}
+
+	fresh := lastScrape
+	if lastScrape != nil {
+		body["last_scrape_time"] = *lastScrape
+		body["last_scrape_age_seconds"] = int(now.Sub(*lastScrape).Seconds())
+	}
+	if lastRun != nil {
+		body["last_run_time"] = lastRun.LastRunAt
+		if lastRun.LastRunDocuments != nil {
+			body["last_run_documents"] = *lastRun.LastRunDocuments
+			if *lastRun.LastRunDocuments == 0 {
+				body["last_run_outcome"] = "no_new_documents"
+			}
+		}
+		if fresh == nil || lastRun.LastRunAt.After(*fresh) {
+			fresh = &lastRun.LastRunAt
+		}
+	}
+
+	if fresh == nil || now.Sub(*fresh) > staleAfter {
+		body["status"] = "stale"
+		return http.StatusServiceUnavailable, body
+	}
+	return http.StatusOK, body
+}
@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/alex/opengov-go/internal/domain"
+)
+
+func TestScraperHealth(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	staleAfter := 30 * time.Minute
+	recent := now.Add(-10 * time.Minute)
+	old := now.Add(-45 * time.Minute)
+
+	tests := []struct {
+		name       string
+		lastScrape *time.Time
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "recent scrape", lastScrape: &recent, wantStatus: http.StatusOK, wantBody: "ok"},
+		{name: "stalled scrape", lastScrape: &old, wantStatus: http.StatusServiceUnavailable, wantBody: "stale"},
+		{name: "never scraped", lastScrape: nil, wantStatus: http.StatusServiceUnavailable, wantBody: "stale"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			status, body := scraperHealth(tc.lastScrape, nil, 42, now, staleAfter)
+			if status != tc.wantStatus {
+				t.Fatalf("status = %d, want %d", status, tc.wantStatus)
+			}
+			if body["status"] != tc.wantBody {
+				t.Fatalf("body status = %v, want %q", body["status"], tc.wantBody)
+			}
+			if body["document_count"] != 42 {
+				t.Fatalf("document_count = %v, want 42", body["document_count"])
+			}
+		})
+	}
+}
+
+// An empty but successful run keeps the scraper healthy even though no document has
+// been fetched for longer than the stale threshold.
+func TestScraperHealth_EmptyRun(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	old := now.Add(-48 * time.Hour)
+	none := 0
+	run := &domain.ScrapeState{SourceKey: "federal_register", LastRunAt: now.Add(-5 * time.Minute), LastRunDocuments: &none}
+
+	status, body := scraperHealth(&old, run, 42, now, 30*time.Minute)
+	if status != http.StatusOK || body["status"] != "ok" {
+		t.Fatalf("got %d %v, want 200 ok", status, body["status"])
+	}
+	if body["last_run_outcome"] != "no_new_documents" {
+		t.Fatalf("last_run_outcome = %v, want no_new_documents", body["last_run_outcome"])
+	}
+
+	run.LastRunAt = now.Add(-time.Hour)
+	if status, body := scraperHealth(&old, run, 42, now, 30*time.Minute); status != http.StatusServiceUnavailable || body["status"] != "stale" {
+		t.Fatalf("got %d %v for an old empty run, want 503 stale", status, body["status"])
+	}
+}
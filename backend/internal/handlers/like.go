@@ -1,8 +1,10 @@
 package handlers
 
 import (
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 
@@ -12,15 +14,19 @@ import (
 )
 
 type LikeHandler struct {
-	likeRepo *repository.LikeRepository
+	likeRepo    *repository.LikeRepository
+	feedEntries FeedEntryChecker
 }
 
-func NewLikeHandler(likeRepo *repository.LikeRepository) *LikeHandler {
+func NewLikeHandler(likeRepo *repository.LikeRepository, feedEntries FeedEntryChecker) *LikeHandler {
 	return &LikeHandler{
-		likeRepo: likeRepo,
+		likeRepo:    likeRepo,
+		feedEntries: feedEntries,
 	}
 }
 
+// Toggle sets the user's reaction to like, dislike or none in one call and returns the
+// resulting value with fresh counts.
 func (h *LikeHandler) Toggle(c *gin.Context) {
 	userID, hasAuth := middleware.GetUserID(c)
 	if !hasAuth {
@@ -28,9 +34,8 @@ func (h *LikeHandler) Toggle(c *gin.Context) {
 		return
 	}
 
-	feedEntryID, err := strconv.ParseInt(c.Param("feed_entry_id"), 10, 64)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid feed entry ID"})
+	feedEntryID, ok := feedEntryIDParam(c, h.feedEntries)
+	if !ok {
 		return
 	}
 
@@ -39,26 +44,55 @@ func (h *LikeHandler) Toggle(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
 		return
 	}
-	if req.Value != 1 && req.Value != -1 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "value must be 1 or -1"})
+	value, ok := likeValue(req)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "value must be 1, -1 or 0"})
 		return
 	}
 
-	like, err := h.likeRepo.SetValue(c.Request.Context(), userID, feedEntryID, req.Value)
-	if err != nil {
+	ctx := c.Request.Context()
+	if value == 0 {
+		if err := h.likeRepo.Remove(ctx, userID, feedEntryID); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to clear like"})
+			return
+		}
+	} else if _, err := h.likeRepo.SetValue(ctx, userID, feedEntryID, value); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to set like"})
 		return
 	}
 
+	likes, dislikes, err := h.likeRepo.GetFeedEntryCounts(ctx, feedEntryID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get counts"})
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
-		"value": like.Value,
+		"value":    value,
+		"likes":    likes,
+		"dislikes": dislikes,
 	})
 }
 
+// likeValue resolves the requested reaction, preferring value over the legacy
+// is_positive flag. ok is false when neither is set or value is out of range.
+func likeValue(req transport.ToggleLikeRequest) (value int, ok bool) {
+	switch {
+	case req.Value != nil:
+		v := *req.Value
+		return v, v == 1 || v == -1 || v == 0
+	case req.IsPositive != nil:
+		if *req.IsPositive {
+			return 1, true
+		}
+		return -1, true
+	}
+	return 0, false
+}
+
 func (h *LikeHandler) GetCounts(c *gin.Context) {
-	feedEntryID, err := strconv.ParseInt(c.Param("feed_entry_id"), 10, 64)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid feed entry ID"})
+	feedEntryID, ok := feedEntryIDParam(c, h.feedEntries)
+	if !ok {
 		return
 	}
 
@@ -74,6 +108,58 @@ func (h *LikeHandler) GetCounts(c *gin.Context) {
 	})
 }
 
+// maxCountsBatchIDs caps GET /api/likes/counts?ids=... so one request stays one cheap query.
+const maxCountsBatchIDs = 100
+
+// GetCountsBatch returns like and dislike counts for a comma-separated list of feed
+// entry ids, keyed by id. Ids with no votes, or no entry, get zero counts.
+func (h *LikeHandler) GetCountsBatch(c *gin.Context) {
+	ids, err := parseIDList(c.Query("ids"), maxCountsBatchIDs)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	counts, err := h.likeRepo.GetFeedEntryCountsBatch(c.Request.Context(), ids)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get counts"})
+		return
+	}
+
+	resp := make(map[int64]transport.LikeCountsResponse, len(counts))
+	for id, n := range counts {
+		resp[id] = transport.LikeCountsResponse{Likes: n.Likes, Dislikes: n.Dislikes}
+	}
+	c.JSON(http.StatusOK, gin.H{"counts": resp})
+}
+
+// parseIDList parses a comma-separated list of positive ids, dropping repeats. It
+// rejects an empty list and more than max distinct ids.
+func parseIDList(raw string, max int) ([]int64, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, fmt.Errorf("ids is required")
+	}
+
+	seen := map[int64]bool{}
+	var ids []int64
+	for _, part := range strings.Split(raw, ",") {
+		id, err := strconv.ParseInt(strings.TrimSpace(part), 10, 64)
+		if err != nil || id <= 0 {
+			return nil, fmt.Errorf("invalid id %q", part)
+		}
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+		if len(ids) > max {
+			return nil, fmt.Errorf("at most %d ids are allowed", max)
+		}
+	}
+	return ids, nil
+}
+
+// Remove clears the user's reaction and returns fresh counts, like Toggle.
 func (h *LikeHandler) Remove(c *gin.Context) {
 	userID, hasAuth := middleware.GetUserID(c)
 	if !hasAuth {
@@ -81,21 +167,29 @@ func (h *LikeHandler) Remove(c *gin.Context) {
 		return
 	}
 
-	feedEntryID, err := strconv.ParseInt(c.Param("feed_entry_id"), 10, 64)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid feed entry ID"})
+	feedEntryID, ok := feedEntryIDParam(c, h.feedEntries)
+	if !ok {
 		return
 	}
 
-	err = h.likeRepo.Remove(c.Request.Context(), userID, feedEntryID)
-	if err != nil {
+	ctx := c.Request.Context()
+	if err := h.likeRepo.Remove(ctx, userID, feedEntryID); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to remove like"})
 		return
 	}
 
+	likes, dislikes, err := h.likeRepo.GetFeedEntryCounts(ctx, feedEntryID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get counts"})
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
-		"success": true,
-		"message": "Like removed",
+		"success":  true,
+		"message":  "Like removed",
+		"value":    0,
+		"likes":    likes,
+		"dislikes": dislikes,
 	})
 }
 
@@ -106,9 +200,8 @@ func (h *LikeHandler) GetStatus(c *gin.Context) {
 		return
 	}
 
-	feedEntryID, err := strconv.ParseInt(c.Param("feed_entry_id"), 10, 64)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid feed entry ID"})
+	feedEntryID, ok := feedEntryIDParam(c, h.feedEntries)
+	if !ok {
 		return
 	}
 
@@ -0,0 +1,181 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/alex/opengov-go/internal/transport"
+)
+
+type fakeFeedEntries struct {
+	ids   map[int64]bool
+	calls int
+}
+
+func (f *fakeFeedEntries) Exists(_ context.Context, id int64) (bool, error) {
+	f.calls++
+	return f.ids[id], nil
+}
+
+func TestFeedEntryIDParam_Likes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	entries := &fakeFeedEntries{ids: map[int64]bool{1: true}}
+	h := NewLikeHandler(nil, entries)
+	router := gin.New()
+	router.GET("/api/likes/counts/:feed_entry_id", h.GetCounts)
+
+	cases := []struct {
+		id     string
+		want   int
+		lookup bool
+	}{
+		{"-5", http.StatusBadRequest, false},
+		{"0", http.StatusBadRequest, false},
+		{"99999999999999999999", http.StatusBadRequest, false},
+		{"abc", http.StatusBadRequest, false},
+		{"42", http.StatusNotFound, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.id, func(t *testing.T) {
+			entries.calls = 0
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/likes/counts/"+tc.id, nil))
+
+			if w.Code != tc.want {
+				t.Fatalf("status = %d, want %d", w.Code, tc.want)
+			}
+			if got := entries.calls > 0; got != tc.lookup {
+				t.Fatalf("looked up entry = %v, want %v", got, tc.lookup)
+			}
+		})
+	}
+}
+
+// The like repository is nil, so reaching it for a missing entry would panic rather
+// than leave a dangling row.
+func TestToggleLike_MissingEntry(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	entries := &fakeFeedEntries{ids: map[int64]bool{1: true}}
+	h := NewLikeHandler(nil, entries)
+	router := gin.New()
+	router.POST("/api/likes/:feed_entry_id", func(c *gin.Context) { c.Set("user_id", int64(7)) }, h.Toggle)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/likes/42", strings.NewReader(`{"value":1}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusNotFound)
+	}
+	if entries.calls != 1 {
+		t.Fatalf("Exists calls = %d, want 1", entries.calls)
+	}
+}
+
+func TestFeedEntryIDParam_Bookmarks(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	entries := &fakeFeedEntries{ids: map[int64]bool{1: true}}
+	h := NewBookmarkHandler(nil, nil, entries)
+	router := gin.New()
+	router.POST("/api/bookmarks/:feed_entry_id", func(c *gin.Context) { c.Set("user_id", int64(7)) }, h.Toggle)
+
+	for id, want := range map[string]int{
+		"-1":                  http.StatusBadRequest,
+		"0":                   http.StatusBadRequest,
+		"9223372036854775808": http.StatusBadRequest,
+		"9223372036854775807": http.StatusNotFound,
+		"42":                  http.StatusNotFound,
+	} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/bookmarks/"+id, nil))
+		if w.Code != want {
+			t.Fatalf("%s: status = %d, want %d", id, w.Code, want)
+		}
+	}
+}
+
+func TestParseIDList(t *testing.T) {
+	got, err := parseIDList(" 3,1, 3 ,2", 3)
+	if err != nil {
+		t.Fatalf("parseIDList() error: %v", err)
+	}
+	if want := []int64{3, 1, 2}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("parseIDList() = %v, want %v", got, want)
+	}
+
+	for _, raw := range []string{"", " ", "1,,2", "1,abc", "0", "-4", "1,2,3,4"} {
+		if _, err := parseIDList(raw, 3); err == nil {
+			t.Errorf("parseIDList(%q) expected an error", raw)
+		}
+	}
+}
+
+func TestGetCountsBatch_RejectsTooManyIDs(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	h := NewLikeHandler(nil, nil)
+	router := gin.New()
+	router.GET("/api/likes/counts", h.GetCountsBatch)
+
+	ids := make([]string, maxCountsBatchIDs+1)
+	for i := range ids {
+		ids[i] = strconv.Itoa(i + 1)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/likes/counts?ids="+strings.Join(ids, ","), nil))
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+}
+
+func TestGetBookmarks_RejectsUnknownSort(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	h := NewBookmarkHandler(nil, nil, nil)
+	router := gin.New()
+	router.GET("/api/bookmarks", func(c *gin.Context) { c.Set("user_id", int64(7)) }, h.GetBookmarks)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/bookmarks?sort=alphabetical", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+}
+
+func TestLikeValue(t *testing.T) {
+	intp := func(v int) *int { return &v }
+	boolp := func(b bool) *bool { return &b }
+
+	cases := []struct {
+		name   string
+		req    transport.ToggleLikeRequest
+		want   int
+		wantOK bool
+	}{
+		{"like", transport.ToggleLikeRequest{Value: intp(1)}, 1, true},
+		{"dislike", transport.ToggleLikeRequest{Value: intp(-1)}, -1, true},
+		{"clear", transport.ToggleLikeRequest{Value: intp(0)}, 0, true},
+		{"out of range", transport.ToggleLikeRequest{Value: intp(2)}, 0, false},
+		{"legacy positive", transport.ToggleLikeRequest{IsPositive: boolp(true)}, 1, true},
+		{"legacy negative", transport.ToggleLikeRequest{IsPositive: boolp(false)}, -1, true},
+		{"value wins", transport.ToggleLikeRequest{Value: intp(0), IsPositive: boolp(true)}, 0, true},
+		{"empty", transport.ToggleLikeRequest{}, 0, false},
+	}
+	for _, tc := range cases {
+		got, ok := likeValue(tc.req)
+		if ok != tc.wantOK || (ok && got != tc.want) {
+			t.Errorf("%s: likeValue() = %d, %v; want %d, %v", tc.name, got, ok, tc.want, tc.wantOK)
+		}
+	}
+}
@@ -54,6 +54,18 @@ func (h *OAuthHandler) cleanupExpiredStatesLocked(now time.Time) {
 	}
 }
 
+// redirectError sends the browser to the frontend's OAuth error page with code in the
+// error query parameter.
+func (h *OAuthHandler) redirectError(c *gin.Context, code string) {
+	c.Redirect(http.StatusTemporaryRedirect, h.cfg.FrontendURL+h.cfg.OAuthErrorPath+"?error="+url.QueryEscape(code))
+}
+
+// redirectSuccess sends the browser to the frontend's OAuth callback page. The token goes
+// in the URL fragment so it is never sent back to a server.
+func (h *OAuthHandler) redirectSuccess(c *gin.Context, jwtToken string) {
+	c.Redirect(http.StatusTemporaryRedirect, h.cfg.FrontendURL+h.cfg.OAuthSuccessPath+"#access_token="+jwtToken)
+}
+
 func (h *OAuthHandler) GoogleLogin(c *gin.Context) {
 	now := time.Now()
 	state := generateState()
@@ -86,7 +98,7 @@ func (h *OAuthHandler) GoogleCallback(c *gin.Context) {
 	h.oauthStatesMu.Unlock()
 	if !ok {
 		log.Printf("Invalid or expired OAuth state: %s", state)
-		c.Redirect(http.StatusTemporaryRedirect, h.cfg.FrontendURL+"/login?error=invalid_state")
+		h.redirectError(c, "invalid_state")
 		return
 	}
 
@@ -94,7 +106,7 @@ func (h *OAuthHandler) GoogleCallback(c *gin.Context) {
 	token, err := exchangeGoogleToken(code, h.cfg)
 	if err != nil {
 		log.Printf("Google OAuth token exchange failed: %v", err)
-		c.Redirect(http.StatusTemporaryRedirect, h.cfg.FrontendURL+"/login?error=token_exchange_failed")
+		h.redirectError(c, "token_exchange_failed")
 		return
 	}
 
@@ -102,14 +114,14 @@ func (h *OAuthHandler) GoogleCallback(c *gin.Context) {
 	userInfo, err := getGoogleUserInfo(token, h.cfg)
 	if err != nil {
 		log.Printf("Failed to get Google user info: %v", err)
-		c.Redirect(http.StatusTemporaryRedirect, h.cfg.FrontendURL+"/login?error=invalid_response")
+		h.redirectError(c, "invalid_response")
 		return
 	}
 
 	googleID, ok := userInfo["sub"].(string)
 	if !ok {
 		log.Printf("No Google ID in user info")
-		c.Redirect(http.StatusTemporaryRedirect, h.cfg.FrontendURL+"/login?error=invalid_user_info")
+		h.redirectError(c, "invalid_user_info")
 		return
 	}
 
@@ -120,7 +132,7 @@ func (h *OAuthHandler) GoogleCallback(c *gin.Context) {
 	user, err := h.userRepo.GetByGoogleID(ctx, googleID)
 	if err != nil {
 		log.Printf("Database error getting user by Google ID: %v", err)
-		c.Redirect(http.StatusTemporaryRedirect, h.cfg.FrontendURL+"/login?error=oauth_error")
+		h.redirectError(c, "oauth_error")
 		return
 	}
 
@@ -158,7 +170,7 @@ func (h *OAuthHandler) GoogleCallback(c *gin.Context) {
 			}
 			if err := h.userRepo.CreateFromGoogle(ctx, user); err != nil {
 				log.Printf("Failed to create user from Google OAuth: %v", err)
-				c.Redirect(http.StatusTemporaryRedirect, h.cfg.FrontendURL+"/login?error=oauth_error")
+				h.redirectError(c, "oauth_error")
 				return
 			}
 		}
@@ -170,17 +182,23 @@ func (h *OAuthHandler) GoogleCallback(c *gin.Context) {
 		user.PictureURL = &picture
 	}
 
+	if !user.GetIsActive() {
+		log.Printf("Inactive user %d attempted Google login", user.ID)
+		h.redirectError(c, "account_disabled")
+		return
+	}
+
 	// Generate JWT token
 	jwtToken, err := h.authService.GenerateToken(user)
 	if err != nil {
 		log.Printf("Failed to generate JWT token: %v", err)
-		c.Redirect(http.StatusTemporaryRedirect, h.cfg.FrontendURL+"/login?error=oauth_error")
+		h.redirectError(c, "oauth_error")
 		return
 	}
 
 	// Redirect to frontend callback with token in URL fragment
 	// The callback page will extract the token and store it in the auth store
-	c.Redirect(http.StatusTemporaryRedirect, h.cfg.FrontendURL+"/auth/callback#access_token="+jwtToken)
+	h.redirectSuccess(c, jwtToken)
 }
 
 func generateState() string {
@@ -272,7 +290,7 @@ func (h *OAuthHandler) TestLogin(c *gin.Context) {
 	user, err := h.userRepo.GetByGoogleID(ctx, testGoogleID)
 	if err != nil {
 		log.Printf("Database error getting test user: %v", err)
-		c.Redirect(http.StatusTemporaryRedirect, h.cfg.FrontendURL+"/login?error=test_login_error")
+		h.redirectError(c, "test_login_error")
 		return
 	}
 
@@ -281,7 +299,7 @@ func (h *OAuthHandler) TestLogin(c *gin.Context) {
 		user, err = h.userRepo.GetByEmail(ctx, testEmail)
 		if err != nil {
 			log.Printf("Database error getting user by email: %v", err)
-			c.Redirect(http.StatusTemporaryRedirect, h.cfg.FrontendURL+"/login?error=test_login_error")
+			h.redirectError(c, "test_login_error")
 			return
 		}
 
@@ -305,7 +323,7 @@ func (h *OAuthHandler) TestLogin(c *gin.Context) {
 			}
 			if err := h.userRepo.CreateFromGoogle(ctx, user); err != nil {
 				log.Printf("Failed to create test user: %v", err)
-				c.Redirect(http.StatusTemporaryRedirect, h.cfg.FrontendURL+"/login?error=test_login_error")
+				h.redirectError(c, "test_login_error")
 				return
 			}
 			log.Printf("Created test user with email: %s", testEmail)
@@ -316,7 +334,7 @@ func (h *OAuthHandler) TestLogin(c *gin.Context) {
 	jwtToken, err := h.authService.GenerateToken(user)
 	if err != nil {
 		log.Printf("Failed to generate JWT token for test user: %v", err)
-		c.Redirect(http.StatusTemporaryRedirect, h.cfg.FrontendURL+"/login?error=test_login_error")
+		h.redirectError(c, "test_login_error")
 		return
 	}
 
@@ -325,5 +343,5 @@ func (h *OAuthHandler) TestLogin(c *gin.Context) {
 
 	// Redirect to frontend callback with token in URL fragment (same as Google OAuth)
 	log.Printf("Test user logged in: %s", testEmail)
-	c.Redirect(http.StatusTemporaryRedirect, h.cfg.FrontendURL+"/auth/callback#access_token="+jwtToken)
+	h.redirectSuccess(c, jwtToken)
 }
@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/alex/opengov-go/internal/config"
+)
+
+func TestGoogleCallback_RedirectsToConfiguredErrorPath(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cfg := &config.Config{
+		FrontendURL:      "https://app.example",
+		OAuthSuccessPath: "/signin/done",
+		OAuthErrorPath:   "/signin",
+	}
+	h := NewOAuthHandler(nil, nil, cfg)
+	router := gin.New()
+	router.GET("/api/auth/google/callback", h.GoogleCallback)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/auth/google/callback?state=unknown&code=x", nil))
+
+	if w.Code != http.StatusTemporaryRedirect {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusTemporaryRedirect)
+	}
+	if got, want := w.Header().Get("Location"), "https://app.example/signin?error=invalid_state"; got != want {
+		t.Fatalf("Location = %q, want %q", got, want)
+	}
+}
+
+func TestRedirectSuccess_UsesConfiguredPath(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	h := NewOAuthHandler(nil, nil, &config.Config{FrontendURL: "https://app.example", OAuthSuccessPath: "/signin/done"})
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+
+	h.redirectSuccess(c, "tok")
+
+	if got, want := w.Header().Get("Location"), "https://app.example/signin/done#access_token=tok"; got != want {
+		t.Fatalf("Location = %q, want %q", got, want)
+	}
+}
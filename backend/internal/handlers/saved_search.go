@@ -0,0 +1,216 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/alex/opengov-go/internal/domain"
+	"github.com/alex/opengov-go/internal/middleware"
+	"github.com/alex/opengov-go/internal/services"
+	"github.com/alex/opengov-go/internal/timeformat"
+	"github.com/alex/opengov-go/internal/transport"
+)
+
+type SavedSearchHandler struct {
+	savedSearchService *services.SavedSearchService
+}
+
+func NewSavedSearchHandler(savedSearchService *services.SavedSearchService) *SavedSearchHandler {
+	return &SavedSearchHandler{
+		savedSearchService: savedSearchService,
+	}
+}
+
+func (h *SavedSearchHandler) List(c *gin.Context) {
+	userID, hasAuth := middleware.GetUserID(c)
+	if !hasAuth {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	searches, err := h.savedSearchService.List(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch saved searches"})
+		return
+	}
+
+	items := make([]transport.SavedSearchResponse, len(searches))
+	for i := range searches {
+		items[i] = savedSearchToResponse(&searches[i])
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"items": items,
+		"total": len(items),
+	})
+}
+
+func (h *SavedSearchHandler) Create(c *gin.Context) {
+	userID, hasAuth := middleware.GetUserID(c)
+	if !hasAuth {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	var req transport.SavedSearchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	search, err := h.savedSearchService.Create(c.Request.Context(), userID, req.Name, req.Filters)
+	if errors.Is(err, services.ErrInvalidSavedSearchFilter) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create saved search"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, savedSearchToResponse(search))
+}
+
+func (h *SavedSearchHandler) Get(c *gin.Context) {
+	userID, hasAuth := middleware.GetUserID(c)
+	if !hasAuth {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid saved search ID"})
+		return
+	}
+
+	search, err := h.savedSearchService.Get(c.Request.Context(), userID, id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch saved search"})
+		return
+	}
+	if search == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Saved search not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, savedSearchToResponse(search))
+}
+
+func (h *SavedSearchHandler) Update(c *gin.Context) {
+	userID, hasAuth := middleware.GetUserID(c)
+	if !hasAuth {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid saved search ID"})
+		return
+	}
+
+	var req transport.SavedSearchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	search, err := h.savedSearchService.Update(c.Request.Context(), userID, id, req.Name, req.Filters)
+	if errors.Is(err, services.ErrInvalidSavedSearchFilter) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update saved search"})
+		return
+	}
+	if search == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Saved search not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, savedSearchToResponse(search))
+}
+
+func (h *SavedSearchHandler) Delete(c *gin.Context) {
+	userID, hasAuth := middleware.GetUserID(c)
+	if !hasAuth {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid saved search ID"})
+		return
+	}
+
+	deleted, err := h.savedSearchService.Delete(c.Request.Context(), userID, id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete saved search"})
+		return
+	}
+	if !deleted {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Saved search not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Saved search deleted",
+	})
+}
+
+func (h *SavedSearchHandler) Results(c *gin.Context) {
+	userID, hasAuth := middleware.GetUserID(c)
+	if !hasAuth {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid saved search ID"})
+		return
+	}
+
+	page, limit, ok := parseFeedPagination(c)
+	if !ok {
+		return
+	}
+	sort := c.DefaultQuery("sort", "newest")
+
+	resp, err := h.savedSearchService.Results(c.Request.Context(), userID, id, page, limit, sort)
+	if errors.Is(err, services.ErrInvalidSavedSearchFilter) {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to run saved search"})
+		return
+	}
+	if resp == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Saved search not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func savedSearchToResponse(s *domain.SavedSearch) transport.SavedSearchResponse {
+	filters := map[string]interface{}(s.Filters)
+	if filters == nil {
+		filters = map[string]interface{}{}
+	}
+	return transport.SavedSearchResponse{
+		ID:        s.ID,
+		Name:      s.Name,
+		Filters:   filters,
+		CreatedAt: s.CreatedAt.Format(timeformat.RFC3339),
+		UpdatedAt: s.UpdatedAt.Format(timeformat.RFC3339),
+	}
+}
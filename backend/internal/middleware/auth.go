@@ -1,15 +1,25 @@
 package middleware
 
 import (
+	"context"
 	"net/http"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/alex/opengov-go/internal/domain"
 	"github.com/alex/opengov-go/internal/services"
 )
 
-func AuthMiddleware(authService *services.AuthService) gin.HandlerFunc {
+// Authenticator validates access tokens and loads the users they belong to.
+type Authenticator interface {
+	ValidateToken(tokenString string) (*services.Claims, error)
+	UserLoader
+}
+
+// AuthMiddleware requires a valid bearer token for an existing, active user. The user is
+// reloaded on every request so deactivation takes effect before the token expires.
+func AuthMiddleware(auth Authenticator) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
@@ -25,23 +35,97 @@ func AuthMiddleware(authService *services.AuthService) gin.HandlerFunc {
 			return
 		}
 
-		claims, err := authService.ValidateToken(parts[1])
+		claims, err := auth.ValidateToken(parts[1])
 		if err != nil {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
 			c.Abort()
 			return
 		}
 
-		// Set user info in context
-		c.Set("user_id", claims.UserID)
-		c.Set("email", claims.Email)
-		c.Set("is_superuser", claims.IsSuperuser)
+		user, err := auth.GetUserByID(c.Request.Context(), claims.UserID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load user"})
+			c.Abort()
+			return
+		}
+		if user == nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
+			c.Abort()
+			return
+		}
+		if !user.GetIsActive() {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Account is disabled"})
+			c.Abort()
+			return
+		}
 
+		setUser(c, user)
 		c.Next()
 	}
 }
 
-func OptionalAuthMiddleware(authService *services.AuthService) gin.HandlerFunc {
+// setUser records the authenticated user in the request context.
+func setUser(c *gin.Context, user *domain.User) {
+	c.Set("user_id", user.ID)
+	c.Set("email", user.Email)
+	c.Set("is_superuser", user.GetIsSuperuser())
+}
+
+// UserLoader looks a user up by id, returning nil when the user does not exist.
+type UserLoader interface {
+	GetUserByID(ctx context.Context, id int64) (*domain.User, error)
+}
+
+// RequireSuperuser rejects requests from anyone who is not a superuser. It must run
+// after AuthMiddleware, which reloads the user on every request, so the flag it records
+// reflects the database rather than the token and a revoked superuser is refused at once.
+func RequireSuperuser() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := GetUserID(c); !ok {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+			c.Abort()
+			return
+		}
+		if !IsSuperuser(c) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Superuser access required"})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RequireFeature hides a route behind a feature flag, answering 404 while it is off.
+func RequireFeature(flags *services.FeatureFlags, name string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !flags.Enabled(name) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Not found"})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RequireFeatureForQuery is RequireFeature for requests that set the query parameter
+// param, for an option that belongs to a feature on a route that does not.
+func RequireFeatureForQuery(flags *services.FeatureFlags, name, param string) gin.HandlerFunc {
+	require := RequireFeature(flags, name)
+	return func(c *gin.Context) {
+		if c.Query(param) != "" {
+			require(c)
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// OptionalAuthMiddleware identifies the caller when a valid token for an active user is
+// present and otherwise lets the request through anonymously.
+func OptionalAuthMiddleware(auth Authenticator) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
@@ -55,16 +139,19 @@ func OptionalAuthMiddleware(authService *services.AuthService) gin.HandlerFunc {
 			return
 		}
 
-		claims, err := authService.ValidateToken(parts[1])
+		claims, err := auth.ValidateToken(parts[1])
 		if err != nil {
 			c.Next()
 			return
 		}
 
-		c.Set("user_id", claims.UserID)
-		c.Set("email", claims.Email)
-		c.Set("is_superuser", claims.IsSuperuser)
+		user, err := auth.GetUserByID(c.Request.Context(), claims.UserID)
+		if err != nil || user == nil || !user.GetIsActive() {
+			c.Next()
+			return
+		}
 
+		setUser(c, user)
 		c.Next()
 	}
 }
@@ -0,0 +1,145 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/alex/opengov-go/internal/constants"
+	"github.com/alex/opengov-go/internal/domain"
+	"github.com/alex/opengov-go/internal/services"
+)
+
+func TestRequireFeature(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	flags := services.NewFeatureFlags(nil, nil)
+	router := gin.New()
+	router.GET("/api/feed/following", RequireFeature(flags, constants.FeaturePersonalization), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"items": []string{}})
+	})
+
+	get := func() int {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/feed/following", nil))
+		return w.Code
+	}
+
+	if code := get(); code != http.StatusNotFound {
+		t.Fatalf("flag off: status = %d, want %d", code, http.StatusNotFound)
+	}
+
+	if err := flags.Set(context.Background(), constants.FeaturePersonalization, true); err != nil {
+		t.Fatalf("Set() error: %v", err)
+	}
+	if code := get(); code != http.StatusOK {
+		t.Fatalf("flag on: status = %d, want %d", code, http.StatusOK)
+	}
+}
+
+type fakeUserLoader map[int64]*domain.User
+
+func (f fakeUserLoader) GetUserByID(_ context.Context, id int64) (*domain.User, error) {
+	if id < 0 {
+		return nil, errors.New("db down")
+	}
+	return f[id], nil
+}
+
+func TestRequireSuperuser(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// Every token claims is_superuser; only the loaded user's flag may count.
+	auth := fakeAuthenticator{fakeUserLoader{
+		1: {ID: 1, IsActive: 1, IsSuperuser: 1},
+		2: {ID: 2, IsActive: 1, IsSuperuser: 0},
+		3: {ID: 3, IsActive: 0, IsSuperuser: 1},
+	}}
+	router := gin.New()
+	router.GET("/api/admin/stats", AuthMiddleware(auth), RequireSuperuser(), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{})
+	})
+	router.GET("/no-auth", RequireSuperuser(), func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{}) })
+
+	tests := []struct {
+		name  string
+		path  string
+		token string
+		want  int
+	}{
+		{"superuser", "/api/admin/stats", "1", http.StatusOK},
+		{"regular user with stale superuser claim", "/api/admin/stats", "2", http.StatusForbidden},
+		{"inactive superuser", "/api/admin/stats", "3", http.StatusForbidden},
+		{"deleted user", "/api/admin/stats", "4", http.StatusUnauthorized},
+		{"load failure", "/api/admin/stats", "-1", http.StatusInternalServerError},
+		{"unauthenticated", "/no-auth", "", http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			if w.Code != tt.want {
+				t.Fatalf("status = %d, want %d", w.Code, tt.want)
+			}
+		})
+	}
+}
+
+type fakeAuthenticator struct {
+	fakeUserLoader
+}
+
+func (fakeAuthenticator) ValidateToken(token string) (*services.Claims, error) {
+	id, err := strconv.ParseInt(token, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &services.Claims{UserID: id, IsSuperuser: true}, nil
+}
+
+func TestAuthMiddleware_RejectsInactiveUser(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	auth := fakeAuthenticator{fakeUserLoader{
+		1: {ID: 1, IsActive: 1},
+		2: {ID: 2, IsActive: 0, IsSuperuser: 1},
+	}}
+	router := gin.New()
+	router.GET("/api/auth/me", AuthMiddleware(auth), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"is_superuser": IsSuperuser(c)})
+	})
+	router.GET("/api/feed", OptionalAuthMiddleware(auth), func(c *gin.Context) {
+		_, authed := GetUserID(c)
+		c.JSON(http.StatusOK, gin.H{"authed": authed})
+	})
+
+	get := func(path, token string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	if w := get("/api/auth/me", "1"); w.Code != http.StatusOK || w.Body.String() != `{"is_superuser":false}` {
+		t.Fatalf("active user: status = %d, body = %s", w.Code, w.Body.String())
+	}
+	if w := get("/api/auth/me", "2"); w.Code != http.StatusForbidden {
+		t.Fatalf("inactive user: status = %d, want %d", w.Code, http.StatusForbidden)
+	}
+	if w := get("/api/auth/me", "3"); w.Code != http.StatusUnauthorized {
+		t.Fatalf("deleted user: status = %d, want %d", w.Code, http.StatusUnauthorized)
+	}
+	if w := get("/api/feed", "2"); w.Code != http.StatusOK || w.Body.String() != `{"authed":false}` {
+		t.Fatalf("optional auth with inactive user: status = %d, body = %s", w.Code, w.Body.String())
+	}
+}
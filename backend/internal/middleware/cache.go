@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NoStore keeps responses out of every cache. It is the default for API routes, and
+// routes that require authentication must keep it so user-specific responses never
+// reach a shared cache.
+func NoStore() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Cache-Control", "private, no-store")
+		c.Next()
+	}
+}
+
+// PublicCache lets shared caches keep successful anonymous responses for maxAge. It must
+// run after OptionalAuthMiddleware: an authenticated request gets private, no-store
+// instead, and Vary: Authorization stops a cache serving the anonymous response to a
+// signed-in caller. Error responses are never cached. A handler may still set its own
+// Cache-Control.
+func PublicCache(maxAge time.Duration) gin.HandlerFunc {
+	public := fmt.Sprintf("public, max-age=%d", int(maxAge.Seconds()))
+	return func(c *gin.Context) {
+		c.Writer.Header().Add("Vary", "Authorization")
+		if _, ok := GetUserID(c); ok {
+			c.Header("Cache-Control", "private, no-store")
+			c.Next()
+			return
+		}
+		c.Header("Cache-Control", public)
+		c.Writer = &noStoreOnError{ResponseWriter: c.Writer}
+		c.Next()
+	}
+}
+
+// noStoreOnError overrides Cache-Control for error statuses. gin only records the
+// status in WriteHeader, so the header can still change until the body is written.
+type noStoreOnError struct {
+	gin.ResponseWriter
+}
+
+func (w *noStoreOnError) WriteHeader(code int) {
+	if code >= 400 {
+		w.Header().Set("Cache-Control", "private, no-store")
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestPublicCache(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		if c.GetHeader("Authorization") != "" {
+			c.Set("user_id", int64(7))
+		}
+	}, PublicCache(5*time.Minute))
+	router.GET("/api/feed", func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{"items": []string{}}) })
+	router.GET("/api/feed/following", NoStore(), func(c *gin.Context) {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header required"})
+	})
+	router.GET("/api/feed/:id", func(c *gin.Context) { c.JSON(http.StatusNotFound, gin.H{"error": "Feed entry not found"}) })
+
+	cases := []struct {
+		name, path string
+		authed     bool
+		want       string
+	}{
+		{"anonymous read", "/api/feed", false, "public, max-age=300"},
+		{"authenticated read", "/api/feed", true, "private, no-store"},
+		{"auth-only route", "/api/feed/following", false, "private, no-store"},
+		{"error", "/api/feed/42", false, "private, no-store"},
+	}
+	for _, tc := range cases {
+		req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+		if tc.authed {
+			req.Header.Set("Authorization", "Bearer token")
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if got := w.Header().Get("Cache-Control"); got != tc.want {
+			t.Errorf("%s: Cache-Control = %q, want %q", tc.name, got, tc.want)
+		}
+		if got := w.Header().Get("Vary"); got != "Authorization" {
+			t.Errorf("%s: Vary = %q, want Authorization", tc.name, got)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/alex/opengov-go/internal/services"
+)
+
+// maxIdempotencyKeyLen bounds the Idempotency-Key header; clients send UUIDs.
+const maxIdempotencyKeyLen = 255
+
+// Idempotency replays the response to an earlier request with the same Idempotency-Key
+// from the same user, so a retried toggle does not flip a like or bookmark back. It must
+// run after AuthMiddleware. Requests without the header run as usual, and server errors
+// are not stored so the retry runs again.
+func Idempotency(store *services.IdempotencyStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader("Idempotency-Key")
+		if key == "" || store == nil {
+			c.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLen {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Idempotency-Key is too long"})
+			return
+		}
+
+		var body []byte
+		if c.Request.Body != nil {
+			var err error
+			if body, err = io.ReadAll(c.Request.Body); err != nil {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Failed to read request body"})
+				return
+			}
+			c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		}
+		h := sha256.New()
+		h.Write([]byte(c.Request.Method + " " + c.Request.URL.Path + "\n"))
+		h.Write(body)
+		var fingerprint [32]byte
+		h.Sum(fingerprint[:0])
+
+		userID, _ := GetUserID(c)
+		storeKey := strconv.FormatInt(userID, 10) + "|" + key
+
+		state, prior := store.Begin(storeKey, fingerprint)
+		switch state {
+		case services.IdempotencyReplay:
+			c.Header("Idempotent-Replayed", "true")
+			c.Data(prior.Status, prior.ContentType, prior.Body)
+			c.Abort()
+			return
+		case services.IdempotencyInProgress:
+			c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": "A request with this Idempotency-Key is still in progress"})
+			return
+		case services.IdempotencyMismatch:
+			c.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{"error": "Idempotency-Key was already used for a different request"})
+			return
+		}
+
+		w := &recordingWriter{ResponseWriter: c.Writer}
+		c.Writer = w
+		completed := false
+		defer func() {
+			if !completed {
+				store.Release(storeKey) // the handler panicked
+			}
+		}()
+
+		c.Next()
+
+		completed = true
+		if w.Status() >= http.StatusInternalServerError {
+			store.Release(storeKey)
+			return
+		}
+		store.Complete(storeKey, services.IdempotentResponse{
+			Status:      w.Status(),
+			ContentType: w.Header().Get("Content-Type"),
+			Body:        w.body.Bytes(),
+		})
+	}
+}
+
+// recordingWriter keeps a copy of the response body for replay.
+type recordingWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *recordingWriter) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *recordingWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
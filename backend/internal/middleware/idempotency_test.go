@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/alex/opengov-go/internal/services"
+)
+
+func TestIdempotency_ReplaysToggle(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	liked := false
+	calls := 0
+	router := gin.New()
+	router.POST("/api/likes/:feed_entry_id", func(c *gin.Context) {
+		c.Set("user_id", int64(c.GetHeader("X-User")[0]-'0'))
+	}, Idempotency(services.NewIdempotencyStore(time.Minute)), func(c *gin.Context) {
+		calls++
+		if c.Query("fail") == "true" {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to toggle like"})
+			return
+		}
+		liked = !liked
+		c.JSON(http.StatusOK, gin.H{"liked": liked})
+	})
+
+	post := func(user, key, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		req.Header.Set("X-User", user)
+		if key != "" {
+			req.Header.Set("Idempotency-Key", key)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	first := post("7", "k1", "/api/likes/1", `{"value":1}`)
+	retry := post("7", "k1", "/api/likes/1", `{"value":1}`)
+	if calls != 1 || retry.Code != http.StatusOK || retry.Body.String() != first.Body.String() {
+		t.Fatalf("retry: calls = %d, body = %q, want 1 call and %q", calls, retry.Body.String(), first.Body.String())
+	}
+	if retry.Header().Get("Idempotent-Replayed") != "true" || retry.Header().Get("Content-Type") != first.Header().Get("Content-Type") {
+		t.Fatalf("retry headers = %v", retry.Header())
+	}
+
+	if w := post("7", "k1", "/api/likes/1", `{"value":-1}`); w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("reused key: status = %d, want %d", w.Code, http.StatusUnprocessableEntity)
+	}
+	if post("8", "k1", "/api/likes/1", `{"value":1}`); calls != 2 {
+		t.Fatalf("another user's key: calls = %d, want 2", calls)
+	}
+	if post("7", "", "/api/likes/1", `{"value":1}`); calls != 3 {
+		t.Fatalf("no key: calls = %d, want 3", calls)
+	}
+
+	// Server errors are not stored, so the retry runs again.
+	post("7", "k2", "/api/likes/1?fail=true", ``)
+	post("7", "k2", "/api/likes/1?fail=true", ``)
+	if calls != 5 {
+		t.Fatalf("after server errors: calls = %d, want 5", calls)
+	}
+
+	if w := post("7", strings.Repeat("k", maxIdempotencyKeyLen+1), "/api/likes/1", ``); w.Code != http.StatusBadRequest {
+		t.Fatalf("long key: status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+}
@@ -0,0 +1,114 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const requestTimeoutKey = "request_timeout"
+
+// requestTimeout is the deadline state a request carries once Timeout has run. parent
+// is the request context from before any timeout, so a route-level Timeout can replace
+// a group's rather than only shorten it.
+type requestTimeout struct {
+	parent context.Context
+	ctx    context.Context
+	w      *timeoutWriter
+}
+
+// Timeout gives the rest of the chain a deadline of d. Handlers see it through
+// c.Request.Context(), so database and upstream calls made with that context give up
+// once it passes. If the deadline passes before the response has started, whatever the
+// handler then writes (typically a 500 for the cancelled query) is replaced with 503,
+// as http.TimeoutHandler does. Unlike http.TimeoutHandler the handler keeps the request
+// goroutine, so gin's context is never shared; a handler that ignores its context runs
+// to completion before the 503 goes out.
+//
+// A Timeout on a route replaces one inherited from its group, which lets streaming
+// routes run longer than the default. d <= 0 removes the deadline.
+func Timeout(d time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		t, nested := c.Get(requestTimeoutKey)
+		state, _ := t.(*requestTimeout)
+		if !nested {
+			state = &requestTimeout{parent: c.Request.Context()}
+			state.w = &timeoutWriter{ResponseWriter: c.Writer, t: state}
+			c.Set(requestTimeoutKey, state)
+			c.Writer = state.w
+		}
+
+		ctx, cancel := state.parent, context.CancelFunc(func() {})
+		if d > 0 {
+			ctx, cancel = context.WithTimeout(state.parent, d)
+		}
+		defer cancel()
+		state.ctx = ctx
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+
+		// A handler that gave up without writing anything still gets the 503.
+		if !nested {
+			state.w.expired()
+		}
+	}
+}
+
+// timeoutWriter swaps the handler's response for a 503 when the request's deadline
+// passed before anything was written.
+type timeoutWriter struct {
+	gin.ResponseWriter
+	t        *requestTimeout
+	timedOut bool
+}
+
+// expired reports whether the handler's output should be discarded, writing the 503
+// the first time it does.
+func (w *timeoutWriter) expired() bool {
+	if w.timedOut {
+		return true
+	}
+	if w.ResponseWriter.Written() || !errors.Is(w.t.ctx.Err(), context.DeadlineExceeded) {
+		return false
+	}
+	w.timedOut = true
+	h := w.ResponseWriter.Header()
+	h.Set("Content-Type", "application/json; charset=utf-8")
+	h.Set("Cache-Control", "private, no-store")
+	h.Del("Content-Disposition")
+	w.ResponseWriter.WriteHeader(http.StatusServiceUnavailable)
+	w.ResponseWriter.WriteString(`{"error":"Request timed out"}`)
+	return true
+}
+
+func (w *timeoutWriter) WriteHeader(code int) {
+	if w.expired() {
+		return
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *timeoutWriter) WriteHeaderNow() {
+	if w.expired() {
+		return
+	}
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+func (w *timeoutWriter) Write(b []byte) (int, error) {
+	if w.expired() {
+		return len(b), nil
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *timeoutWriter) WriteString(s string) (int, error) {
+	if w.expired() {
+		return len(s), nil
+	}
+	return w.ResponseWriter.WriteString(s)
+}
@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestTimeout(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// waitForDeadline stands in for a handler blocked on a slow query: it returns once
+	// its context ends and reports the failure as the handler would.
+	waitForDeadline := func(c *gin.Context) {
+		<-c.Request.Context().Done()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch feed"})
+	}
+
+	router := gin.New()
+	router.Use(PublicCache(5*time.Minute), Timeout(20*time.Millisecond))
+	router.GET("/fast", func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{"ok": true}) })
+	router.GET("/slow", waitForDeadline)
+	router.GET("/silent", func(c *gin.Context) { <-c.Request.Context().Done() })
+	router.GET("/export", Timeout(0), func(c *gin.Context) {
+		time.Sleep(40 * time.Millisecond)
+		if err := c.Request.Context().Err(); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.String(http.StatusOK, "id,title\n")
+	})
+
+	cases := []struct {
+		path string
+		want int
+	}{
+		{"/fast", http.StatusOK},
+		{"/slow", http.StatusServiceUnavailable},
+		{"/silent", http.StatusServiceUnavailable},
+		{"/export", http.StatusOK},
+	}
+	for _, tc := range cases {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tc.path, nil))
+		if w.Code != tc.want {
+			t.Errorf("%s: status = %d, want %d (body %s)", tc.path, w.Code, tc.want, w.Body)
+			continue
+		}
+		if tc.want != http.StatusServiceUnavailable {
+			continue
+		}
+		if body := w.Body.String(); body != `{"error":"Request timed out"}` {
+			t.Errorf("%s: body = %s", tc.path, body)
+		}
+		if cc := w.Header().Get("Cache-Control"); !strings.Contains(cc, "no-store") {
+			t.Errorf("%s: Cache-Control = %q, want no-store", tc.path, cc)
+		}
+	}
+}
+
+// A response already under way when the deadline passes is left alone.
+func TestTimeout_StartedResponse(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(Timeout(20 * time.Millisecond))
+	router.GET("/stream", func(c *gin.Context) {
+		c.Writer.WriteString("id,title\n")
+		<-c.Request.Context().Done()
+		c.Writer.WriteString("1,Ozone\n")
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/stream", nil))
+	if w.Code != http.StatusOK || w.Body.String() != "id,title\n1,Ozone\n" {
+		t.Fatalf("got %d %q, want the handler's response", w.Code, w.Body)
+	}
+}
@@ -0,0 +1,109 @@
+package openapi
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// schemas collects component schemas as operations reference transport types.
+type schemas map[string]any
+
+// ref returns a reference to the schema for v's type, registering it (and every struct
+// type it contains) under components.schemas the first time.
+func (s schemas) ref(v any) map[string]any {
+	return s.of(reflect.TypeOf(v))
+}
+
+func (s schemas) of(t reflect.Type) map[string]any {
+	switch {
+	case t == timeType:
+		return map[string]any{"type": "string", "format": "date-time"}
+	case t.Kind() == reflect.Pointer:
+		schema := s.of(t.Elem())
+		if _, isRef := schema["$ref"]; isRef {
+			return schema
+		}
+		nullable := map[string]any{"nullable": true}
+		for k, v := range schema {
+			nullable[k] = v
+		}
+		return nullable
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		name := t.Name()
+		if _, ok := s[name]; !ok {
+			s[name] = nil // placeholder so recursive types terminate
+			s[name] = s.object(t)
+		}
+		return map[string]any{"$ref": "#/components/schemas/" + name}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": s.of(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": s.of(t.Elem())}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int64, reflect.Uint64:
+		return map[string]any{"type": "integer", "format": "int64"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	}
+	return map[string]any{} // interface{}: any value
+}
+
+// object describes a struct by its json tags. Embedded structs are flattened, as
+// encoding/json does. A field is required when binding requires it, or when it is
+// always encoded and cannot be null.
+func (s schemas) object(t reflect.Type) map[string]any {
+	props := map[string]any{}
+	var required []string
+	s.fields(t, props, &required)
+
+	obj := map[string]any{"type": "object", "properties": props}
+	if len(required) > 0 {
+		obj["required"] = required
+	}
+	return obj
+}
+
+func (s schemas) fields(t reflect.Type, props map[string]any, required *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			s.fields(f.Type, props, required)
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		props[name] = s.of(f.Type)
+		omitempty := strings.Contains(opts, "omitempty")
+		switch f.Type.Kind() {
+		case reflect.Pointer, reflect.Slice, reflect.Map, reflect.Interface:
+			if strings.Contains(f.Tag.Get("binding"), "required") {
+				*required = append(*required, name)
+			}
+		default:
+			if !omitempty || strings.Contains(f.Tag.Get("binding"), "required") {
+				*required = append(*required, name)
+			}
+		}
+	}
+}
@@ -0,0 +1,298 @@
+// Package openapi describes the HTTP API as an OpenAPI 3 document. Paths are written
+// out by hand; request and response schemas are derived from the transport DTOs, so
+// they cannot drift from what the handlers encode.
+package openapi
+
+import (
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/alex/opengov-go/internal/constants"
+	"github.com/alex/opengov-go/internal/transport"
+)
+
+// access is who may call an operation.
+type access int
+
+const (
+	public access = iota
+	optionalUser
+	user
+	superuser
+)
+
+type operation struct {
+	method  string
+	path    string // gin syntax, e.g. /api/feed/:id
+	tag     string
+	summary string
+	access  access
+	params  []map[string]any
+	body    any // transport request type
+	status  int
+	resp    any    // transport response type, a schema map, or nil for no body
+	media   string // response media type; application/json when empty
+}
+
+// Operation identifies a documented route.
+type Operation struct {
+	Method string
+	Path   string // gin syntax
+}
+
+// Operations lists every documented route.
+func Operations() []Operation {
+	ops := operations(schemas{})
+	out := make([]Operation, len(ops))
+	for i, op := range ops {
+		out[i] = Operation{Method: op.method, Path: op.path}
+	}
+	return out
+}
+
+// Spec returns the OpenAPI document, ready to encode as JSON.
+func Spec() map[string]any {
+	s := schemas{"Error": map[string]any{
+		"type":       "object",
+		"properties": map[string]any{"error": map[string]any{"type": "string"}},
+		"required":   []string{"error"},
+	}}
+	paths := map[string]any{}
+
+	for _, op := range operations(s) {
+		path := openAPIPath(op.path)
+		item, ok := paths[path].(map[string]any)
+		if !ok {
+			item = map[string]any{}
+			paths[path] = item
+		}
+
+		params := op.params
+		for _, name := range pathParams(op.path) {
+			params = append([]map[string]any{{
+				"name": name, "in": "path", "required": true, "schema": map[string]any{"type": "string"},
+			}}, params...)
+		}
+
+		media := op.media
+		if media == "" {
+			media = "application/json"
+		}
+		success := map[string]any{"description": http.StatusText(op.status)}
+		switch resp := op.resp.(type) {
+		case nil:
+		case map[string]any:
+			success["content"] = map[string]any{media: map[string]any{"schema": resp}}
+		default:
+			success["content"] = map[string]any{media: map[string]any{"schema": s.ref(resp)}}
+		}
+		responses := map[string]any{
+			strconv.Itoa(op.status): success,
+			"default": map[string]any{
+				"description": "Error",
+				"content":     map[string]any{"application/json": map[string]any{"schema": map[string]any{"$ref": "#/components/schemas/Error"}}},
+			},
+		}
+
+		o := map[string]any{
+			"tags":      []string{op.tag},
+			"summary":   op.summary,
+			"responses": responses,
+		}
+		if len(params) > 0 {
+			o["parameters"] = params
+		}
+		if op.body != nil {
+			o["requestBody"] = map[string]any{
+				"required": true,
+				"content":  map[string]any{"application/json": map[string]any{"schema": s.ref(op.body)}},
+			}
+		}
+		switch op.access {
+		case optionalUser:
+			o["security"] = []map[string]any{{}, {"bearerAuth": []string{}}}
+		case user, superuser:
+			o["security"] = []map[string]any{{"bearerAuth": []string{}}}
+		}
+		if op.access == superuser {
+			o["description"] = "Requires a superuser."
+		}
+		item[strings.ToLower(op.method)] = o
+	}
+
+	return map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":   "OpenGov API",
+			"version": "1.0",
+		},
+		"paths": paths,
+		"components": map[string]any{
+			"schemas": map[string]any(s),
+			"securitySchemes": map[string]any{
+				"bearerAuth": map[string]any{"type": "http", "scheme": "bearer", "bearerFormat": "JWT"},
+			},
+		},
+	}
+}
+
+// openAPIPath turns gin's :param segments into {param}.
+func openAPIPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, seg := range segments {
+		if strings.HasPrefix(seg, ":") {
+			segments[i] = "{" + seg[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+func pathParams(path string) []string {
+	var names []string
+	for _, seg := range strings.Split(path, "/") {
+		if strings.HasPrefix(seg, ":") {
+			names = append(names, seg[1:])
+		}
+	}
+	return names
+}
+
+func query(name, typ, description string) map[string]any {
+	return map[string]any{"name": name, "in": "query", "description": description, "schema": map[string]any{"type": typ}}
+}
+
+func header(name, description string) map[string]any {
+	return map[string]any{"name": name, "in": "header", "description": description, "schema": map[string]any{"type": "string"}}
+}
+
+func queryEnum(name, description string, values ...string) map[string]any {
+	return map[string]any{"name": name, "in": "query", "description": description, "schema": map[string]any{"type": "string", "enum": values}}
+}
+
+func object(props map[string]any) map[string]any {
+	return map[string]any{"type": "object", "properties": props}
+}
+
+var (
+	str     = map[string]any{"type": "string"}
+	integer = map[string]any{"type": "integer"}
+	boolean = map[string]any{"type": "boolean"}
+)
+
+func arrayOf(items map[string]any) map[string]any {
+	return map[string]any{"type": "array", "items": items}
+}
+
+func pagination(defaultLimit int) []map[string]any {
+	return []map[string]any{
+		query("page", "integer", "Page number, from 1"),
+		query("limit", "integer", "Page size, at most 100 (default "+strconv.Itoa(defaultLimit)+")"),
+	}
+}
+
+var includeHidden = queryEnum("include_hidden", "Include hidden documents; superuser only", "true")
+
+func feedFilters() []map[string]any {
+	return []map[string]any{
+		query("agency", "string", "Agency name"),
+		query("document_type", "string", "Federal Register document type, e.g. Rule"),
+		query("q", "string", "Keyword search"),
+		query("cfr_title", "integer", "CFR title, 1-50"),
+		queryEnum("category", "Topic category", constants.Categories...),
+		queryEnum("source", "Source the document was scraped from", slices.Sorted(maps.Keys(constants.SourceAliases))...),
+	}
+}
+
+// operations lists the documented routes. s collects the schemas their inline objects
+// reference.
+func operations(s schemas) []operation {
+	feedList := append(pagination(20), feedFilters()...)
+	feedList = append(feedList,
+		queryEnum("sort", "Order (default newest)", "newest", "oldest", "balanced"),
+		queryEnum("balance", "true interleaves left, center and right documents", "true"),
+		queryEnum("leaning", "Documents scored against the caller's profile leaning; requires auth", "opposite"),
+		query("state", "string", "Two-letter US state code the document mentions"),
+		queryEnum("relevant_to_state", "Use the caller's profile state; requires auth", "true"),
+		query("lang", "string", "Language for summaries and keypoints, e.g. es"),
+		query("since", "string", "Only entries added after this RFC3339 time; use a previous response's server_time"),
+		includeHidden,
+	)
+	exportParams := append(feedFilters(),
+		query("published_after", "string", "First publication day, YYYY-MM-DD (inclusive)"),
+		query("published_before", "string", "Last publication day, YYYY-MM-DD (exclusive)"),
+	)
+	idempotencyKey := []map[string]any{header("Idempotency-Key", "Replays the first response to a retry with the same key")}
+	likeCounts := object(map[string]any{"likes": integer, "dislikes": integer})
+	likeResult := object(map[string]any{"value": integer, "likes": integer, "dislikes": integer})
+	started := object(map[string]any{"status": str, "message": str})
+
+	return []operation{
+		// Auth
+		{method: http.MethodPost, path: "/api/auth/login", tag: "auth", summary: "Log in with email and password", body: transport.LoginRequest{}, status: http.StatusOK, resp: transport.AuthResponse{}},
+		{method: http.MethodPost, path: "/api/auth/register", tag: "auth", summary: "Create an account", body: transport.RegisterRequest{}, status: http.StatusCreated, resp: transport.AuthResponse{}},
+		{method: http.MethodPost, path: "/api/auth/logout", tag: "auth", summary: "Log out", status: http.StatusOK, resp: object(map[string]any{"message": str})},
+		{method: http.MethodGet, path: "/api/auth/me", tag: "auth", summary: "Get the current user", access: user, status: http.StatusOK, resp: transport.UserResponse{}},
+		{method: http.MethodPost, path: "/api/auth/refresh", tag: "auth", summary: "Issue a fresh access token", access: user, status: http.StatusOK, resp: object(map[string]any{"access_token": str})},
+		{method: http.MethodPatch, path: "/api/users/me", tag: "auth", summary: "Update the current user's profile", access: user, body: transport.UpdateUserRequest{}, status: http.StatusOK, resp: transport.UserResponse{}},
+		{method: http.MethodGet, path: "/api/auth/google/login", tag: "auth", summary: "Start Google sign-in", status: http.StatusTemporaryRedirect},
+		{method: http.MethodGet, path: "/api/auth/google/callback", tag: "auth", summary: "Google sign-in callback", status: http.StatusTemporaryRedirect},
+
+		// Feed
+		{method: http.MethodGet, path: "/api/feed", tag: "feed", summary: "List feed entries. Anonymous responses carry a weak ETag and honor If-None-Match.", access: optionalUser, params: feedList, status: http.StatusOK, resp: transport.FeedResponse{}},
+		{method: http.MethodGet, path: "/api/feed/following", tag: "feed", summary: "Entries from followed agencies", access: user, params: pagination(20), status: http.StatusOK, resp: transport.FeedResponse{}},
+		{method: http.MethodGet, path: "/api/feed/recommended", tag: "feed", summary: "Entries similar to the caller's bookmarks", access: user, params: []map[string]any{query("limit", "integer", "1-50 (default 20)")}, status: http.StatusOK, resp: object(map[string]any{"items": arrayOf(s.ref(transport.FeedEntryResponse{})), "total": integer})},
+		{method: http.MethodGet, path: "/api/feed/themes", tag: "feed", summary: "Most common recent themes", params: []map[string]any{query("days", "integer", "1-90 (default 7)"), query("limit", "integer", "1-50 (default 10)")}, status: http.StatusOK, resp: transport.ThemesResponse{}},
+		{method: http.MethodGet, path: "/api/feed/export.csv", tag: "feed", summary: "Download matching entries as CSV, newest first", params: exportParams, status: http.StatusOK, resp: str, media: "text/csv"},
+		{method: http.MethodGet, path: "/api/feed/export.json", tag: "feed", summary: "Download matching entries as NDJSON, one FeedExportItem per line", params: append(exportParams, query("cursor", "string", "Resume after the item carrying this cursor")), status: http.StatusOK, resp: transport.FeedExportItem{}, media: "application/x-ndjson"},
+		{method: http.MethodGet, path: "/api/feed/archive", tag: "feed", summary: "Entry counts by month", status: http.StatusOK, resp: transport.ArchiveResponse{}},
+		{method: http.MethodGet, path: "/api/feed/archive/:year/:month", tag: "feed", summary: "Entries published in a month", access: optionalUser, params: pagination(20), status: http.StatusOK, resp: transport.FeedResponse{}},
+		{method: http.MethodGet, path: "/api/feed/:id", tag: "feed", summary: "Get a feed entry", access: optionalUser, params: []map[string]any{includeHidden}, status: http.StatusOK, resp: transport.FeedEntryResponse{}},
+		{method: http.MethodGet, path: "/api/feed/:id/full", tag: "feed", summary: "Get a feed entry with its full abstract", access: optionalUser, params: []map[string]any{includeHidden}, status: http.StatusOK, resp: transport.FeedEntryFullResponse{}},
+		{method: http.MethodGet, path: "/api/feed/:id/related", tag: "feed", summary: "Entries sharing an agency or a category", access: optionalUser, params: []map[string]any{query("limit", "integer", "1-20 (default 5)"), includeHidden}, status: http.StatusOK, resp: object(map[string]any{"items": arrayOf(s.ref(transport.FeedEntryResponse{})), "total": integer})},
+
+		// Bookmarks
+		{method: http.MethodGet, path: "/api/bookmarks", tag: "bookmarks", summary: "List bookmarked entries", access: user, params: append(pagination(20), queryEnum("sort", "Order (default newest)", "newest", "oldest", "published_newest", "published_oldest"), query("agency", "string", "Agency name")), status: http.StatusOK, resp: transport.FeedResponse{}},
+		{method: http.MethodPost, path: "/api/bookmarks/:feed_entry_id", tag: "bookmarks", summary: "Toggle a bookmark", access: user, params: idempotencyKey, status: http.StatusOK, resp: object(map[string]any{"is_bookmarked": boolean})},
+		{method: http.MethodDelete, path: "/api/bookmarks/:feed_entry_id", tag: "bookmarks", summary: "Remove a bookmark", access: user, status: http.StatusOK, resp: object(map[string]any{"success": boolean, "message": str, "is_bookmarked": boolean})},
+		{method: http.MethodGet, path: "/api/bookmarks/status/:feed_entry_id", tag: "bookmarks", summary: "Whether an entry is bookmarked", access: user, status: http.StatusOK, resp: object(map[string]any{"is_bookmarked": boolean})},
+
+		// Likes
+		{method: http.MethodPost, path: "/api/likes/:feed_entry_id", tag: "likes", summary: "Like (1), dislike (-1) or clear (0) an entry", access: user, params: idempotencyKey, body: transport.ToggleLikeRequest{}, status: http.StatusOK, resp: likeResult},
+		{method: http.MethodDelete, path: "/api/likes/:feed_entry_id", tag: "likes", summary: "Clear a like or dislike", access: user, status: http.StatusOK, resp: likeResult},
+		{method: http.MethodGet, path: "/api/likes/counts", tag: "likes", summary: "Like counts for up to 100 entries", access: user, params: []map[string]any{query("ids", "string", "Comma-separated feed entry ids")}, status: http.StatusOK, resp: object(map[string]any{"counts": map[string]any{"type": "object", "additionalProperties": s.ref(transport.LikeCountsResponse{})}})},
+		{method: http.MethodGet, path: "/api/likes/counts/:feed_entry_id", tag: "likes", summary: "Like counts for an entry", access: user, status: http.StatusOK, resp: likeCounts},
+		{method: http.MethodGet, path: "/api/likes/status/:feed_entry_id", tag: "likes", summary: "The caller's reaction to an entry, or null", access: user, status: http.StatusOK, resp: object(map[string]any{"value": map[string]any{"type": "integer", "nullable": true}})},
+
+		// Admin
+		{method: http.MethodGet, path: "/api/admin/stats", tag: "admin", summary: "Document counts, breakdowns and last scrape", access: superuser, status: http.StatusOK, resp: transport.StatsResponse{}},
+		{method: http.MethodGet, path: "/api/admin/stats/external", tag: "admin", summary: "Recent calls to external services", access: superuser, params: []map[string]any{query("limit", "integer", "Default 50")}, status: http.StatusOK, resp: object(map[string]any{"calls": arrayOf(s.ref(transport.ExternalCall{}))})},
+		{method: http.MethodGet, path: "/api/admin/agencies", tag: "admin", summary: "List synced agencies", access: superuser, params: []map[string]any{query("limit", "integer", "Default 100"), query("offset", "integer", "Default 0")}, status: http.StatusOK, resp: object(map[string]any{"agencies": arrayOf(s.ref(transport.AgencyResponse{})), "total": integer, "limit": integer, "offset": integer})},
+		{method: http.MethodGet, path: "/api/admin/agencies/diff", tag: "admin", summary: "Differences between synced and upstream agencies", access: superuser, status: http.StatusOK, resp: transport.AgencyDiffResponse{}},
+		{method: http.MethodGet, path: "/api/admin/federal-register/agencies", tag: "admin", summary: "Live Federal Register agency list", access: superuser, status: http.StatusOK, resp: object(map[string]any{"agencies": arrayOf(map[string]any{"type": "object"}), "total": integer})},
+		{method: http.MethodPost, path: "/api/admin/scrape", tag: "admin", summary: "Start the scrape pipeline", access: superuser, status: http.StatusAccepted, resp: started},
+		{method: http.MethodPost, path: "/api/admin/backfill", tag: "admin", summary: "Start a historical backfill", access: superuser, body: transport.BackfillRequest{}, status: http.StatusAccepted, resp: object(map[string]any{"status": str, "message": str, "job_id": integer})},
+		{method: http.MethodGet, path: "/api/admin/ai-usage", tag: "admin", summary: "Daily AI token usage", access: superuser, params: []map[string]any{query("days", "integer", "Default 30")}, status: http.StatusOK, resp: transport.AIUsageResponse{}},
+		{method: http.MethodPatch, path: "/api/admin/documents/:id", tag: "admin", summary: "Edit a document's summary or keypoints", access: superuser, body: transport.DocumentEditRequest{}, status: http.StatusOK, resp: transport.AdminDocumentResponse{}},
+		{method: http.MethodPost, path: "/api/admin/documents/:id/reprocess", tag: "admin", summary: "Re-run AI analysis on a document", access: superuser, status: http.StatusOK, resp: transport.AdminDocumentResponse{}},
+		{method: http.MethodPost, path: "/api/admin/reprocess-all", tag: "admin", summary: "Re-run AI analysis on every document, or those matching a filter, as a job", access: superuser, body: transport.ReprocessAllRequest{}, status: http.StatusAccepted, resp: object(map[string]any{"status": str, "message": str, "job_id": integer, "total": integer})},
+		{method: http.MethodDelete, path: "/api/admin/reprocess-all", tag: "admin", summary: "Cancel the running bulk reprocess", access: superuser, status: http.StatusAccepted, resp: object(map[string]any{"status": str, "message": str})},
+		{method: http.MethodPost, path: "/api/admin/documents/:id/hide", tag: "admin", summary: "Hide a document from the feed", access: superuser, status: http.StatusOK, resp: object(map[string]any{"id": integer, "hidden": boolean})},
+		{method: http.MethodPost, path: "/api/admin/documents/:id/unhide", tag: "admin", summary: "Show a hidden document again", access: superuser, status: http.StatusOK, resp: object(map[string]any{"id": integer, "hidden": boolean})},
+		{method: http.MethodGet, path: "/api/admin/flags", tag: "admin", summary: "List feature flags", access: superuser, status: http.StatusOK, resp: object(map[string]any{"flags": map[string]any{"type": "object", "additionalProperties": boolean}})},
+		{method: http.MethodPut, path: "/api/admin/flags/:name", tag: "admin", summary: "Set a feature flag", access: superuser, body: transport.FlagUpdateRequest{}, status: http.StatusOK, resp: object(map[string]any{"name": str, "enabled": boolean})},
+		{method: http.MethodGet, path: "/api/admin/jobs", tag: "admin", summary: "Recent jobs", access: superuser, params: []map[string]any{query("limit", "integer", "Default 50")}, status: http.StatusOK, resp: transport.JobListResponse{}},
+		{method: http.MethodGet, path: "/api/admin/jobs/:id", tag: "admin", summary: "Get a job", access: superuser, status: http.StatusOK, resp: transport.JobResponse{}},
+		{method: http.MethodGet, path: "/api/admin/documents/:id/raw", tag: "admin", summary: "Stored upstream payloads for a document, oldest first", access: superuser, status: http.StatusOK, resp: object(map[string]any{"items": arrayOf(s.ref(transport.RawDocumentResponse{}))})},
+		{method: http.MethodGet, path: "/api/admin/raw-documents/failed", tag: "admin", summary: "Raw documents that failed canonicalization", access: superuser, params: []map[string]any{query("limit", "integer", "Default 50")}, status: http.StatusOK, resp: object(map[string]any{"items": arrayOf(s.ref(transport.FailedRawDocumentResponse{}))})},
+		{method: http.MethodPost, path: "/api/admin/raw-documents/:id/retry", tag: "admin", summary: "Requeue a failed raw document", access: superuser, status: http.StatusOK, resp: object(map[string]any{"status": str})},
+		{method: http.MethodGet, path: "/api/admin/scraper/config", tag: "admin", summary: "Effective scraper configuration", access: superuser, status: http.StatusOK, resp: transport.ScraperConfigResponse{}},
+		{method: http.MethodGet, path: "/api/admin/settings/analysis-prompt", tag: "admin", summary: "Get the AI analysis prompt", access: superuser, status: http.StatusOK, resp: transport.AnalysisPromptResponse{}},
+		{method: http.MethodPut, path: "/api/admin/settings/analysis-prompt", tag: "admin", summary: "Replace the AI analysis prompt", access: superuser, body: transport.AnalysisPromptRequest{}, status: http.StatusOK, resp: transport.AnalysisPromptResponse{}},
+		{method: http.MethodGet, path: "/api/admin/users", tag: "admin", summary: "List users", access: superuser, params: []map[string]any{query("limit", "integer", "Default 50"), query("offset", "integer", "Default 0")}, status: http.StatusOK, resp: transport.AdminUserListResponse{}},
+		{method: http.MethodGet, path: "/api/admin/users/:id", tag: "admin", summary: "Get a user", access: superuser, status: http.StatusOK, resp: transport.AdminUserResponse{}},
+		{method: http.MethodPatch, path: "/api/admin/users/:id", tag: "admin", summary: "Update a user's flags", access: superuser, body: transport.AdminUserUpdateRequest{}, status: http.StatusOK, resp: transport.AdminUserResponse{}},
+	}
+}
@@ -0,0 +1,67 @@
+package openapi
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestSpec_RefsResolve(t *testing.T) {
+	raw, err := json.Marshal(Spec())
+	if err != nil {
+		t.Fatalf("Marshal() error: %v", err)
+	}
+	var doc struct {
+		Components struct {
+			Schemas map[string]json.RawMessage `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		t.Fatalf("Unmarshal() error: %v", err)
+	}
+
+	const prefix = `"$ref":"#/components/schemas/`
+	for rest := string(raw); ; {
+		i := strings.Index(rest, prefix)
+		if i < 0 {
+			break
+		}
+		rest = rest[i+len(prefix):]
+		name := rest[:strings.IndexByte(rest, '"')]
+		if _, ok := doc.Components.Schemas[name]; !ok {
+			t.Errorf("$ref to missing schema %s", name)
+		}
+	}
+}
+
+func TestSpec_DerivesSchemasFromTransport(t *testing.T) {
+	schemas := Spec()["components"].(map[string]any)["schemas"].(map[string]any)
+
+	full, ok := schemas["FeedEntryFullResponse"].(map[string]any)
+	if !ok {
+		t.Fatal("FeedEntryFullResponse schema missing")
+	}
+	props := full["properties"].(map[string]any)
+	for _, name := range []string{"id", "summary", "abstract"} {
+		if _, ok := props[name]; !ok {
+			t.Errorf("FeedEntryFullResponse lacks %s; embedded fields should be flattened", name)
+		}
+	}
+	if abstract := props["abstract"].(map[string]any); abstract["nullable"] != true {
+		t.Errorf("abstract = %v, want nullable", abstract)
+	}
+
+	login := schemas["LoginRequest"].(map[string]any)
+	if got := login["required"].([]string); len(got) != 2 {
+		t.Errorf("LoginRequest required = %v, want email and password", got)
+	}
+	if _, ok := schemas["UserResponse"]; !ok {
+		t.Error("UserResponse, nested in AuthResponse, was not registered")
+	}
+}
+
+func TestOpenAPIPath(t *testing.T) {
+	if got := openAPIPath("/api/feed/archive/:year/:month"); got != "/api/feed/archive/{year}/{month}" {
+		t.Fatalf("openAPIPath() = %s", got)
+	}
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/alex/opengov-go/internal/db"
+)
+
+type AgencyFollowRepository struct {
+	db *db.DB
+}
+
+func NewAgencyFollowRepository(db *db.DB) *AgencyFollowRepository {
+	return &AgencyFollowRepository{db: db}
+}
+
+// Follow is idempotent: following an already-followed agency is a no-op.
+func (r *AgencyFollowRepository) Follow(ctx context.Context, userID, agencyID int64) error {
+	query := `
+		INSERT INTO user_agency_follows (user_id, agency_id)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id, agency_id) DO NOTHING
+	`
+	if _, err := r.db.ExecContext(ctx, query, userID, agencyID); err != nil {
+		return fmt.Errorf("failed to follow agency: %w", err)
+	}
+	return nil
+}
+
+func (r *AgencyFollowRepository) Unfollow(ctx context.Context, userID, agencyID int64) error {
+	query := "DELETE FROM user_agency_follows WHERE user_id = $1 AND agency_id = $2"
+	if _, err := r.db.ExecContext(ctx, query, userID, agencyID); err != nil {
+		return fmt.Errorf("failed to unfollow agency: %w", err)
+	}
+	return nil
+}
+
+// GetFollowedAgencyNames returns the names of agencies the user follows. Names are what
+// policy_documents.agency stores, so they can be fed straight into a FeedFilter.
+func (r *AgencyFollowRepository) GetFollowedAgencyNames(ctx context.Context, userID int64) ([]string, error) {
+	query := `
+		SELECT a.name
+		FROM user_agency_follows f
+		JOIN agencies a ON a.id = f.agency_id
+		WHERE f.user_id = $1
+		ORDER BY a.name
+	`
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query followed agencies: %w", err)
+	}
+	defer rows.Close()
+
+	names := []string{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("failed to scan followed agency: %w", err)
+		}
+		names = append(names, name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating followed agencies: %w", err)
+	}
+	return names, nil
+}
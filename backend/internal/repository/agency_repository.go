@@ -2,7 +2,9 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/alex/opengov-go/internal/db"
 	"github.com/alex/opengov-go/internal/domain"
@@ -57,6 +59,239 @@ func (r *AgencyRepository) GetAll(ctx context.Context, limit, offset int) ([]dom
 	return agencies, total, nil
 }
 
+// GetByID returns nil when the agency does not exist.
+func (r *AgencyRepository) GetByID(ctx context.Context, id int64) (*domain.Agency, error) {
+	query := `
+		SELECT id, fr_agency_id, raw_name, name, short_name, slug, description, url, json_url, parent_id, raw_data, created_at, updated_at
+		FROM agencies WHERE id = $1
+	`
+	var a domain.Agency
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&a.ID, &a.FRAgencyID, &a.RawName, &a.Name, &a.ShortName, &a.Slug, &a.Description,
+		&a.URL, &a.JSONURL, &a.ParentID, &a.RawData, &a.CreatedAt, &a.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get agency: %w", err)
+	}
+	return &a, nil
+}
+
+// GetByFRAgencyID returns nil when no agency has the Federal Register id.
+// Agency.ParentID holds a Federal Register id, so this resolves parents.
+func (r *AgencyRepository) GetByFRAgencyID(ctx context.Context, frAgencyID int64) (*domain.Agency, error) {
+	query := `
+		SELECT id, fr_agency_id, raw_name, name, short_name, slug, description, url, json_url, parent_id, raw_data, created_at, updated_at
+		FROM agencies WHERE fr_agency_id = $1
+	`
+	var a domain.Agency
+	err := r.db.QueryRowContext(ctx, query, frAgencyID).Scan(
+		&a.ID, &a.FRAgencyID, &a.RawName, &a.Name, &a.ShortName, &a.Slug, &a.Description,
+		&a.URL, &a.JSONURL, &a.ParentID, &a.RawData, &a.CreatedAt, &a.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get agency by fr_agency_id: %w", err)
+	}
+	return &a, nil
+}
+
+// GetBySlug returns nil when no agency has the slug.
+func (r *AgencyRepository) GetBySlug(ctx context.Context, slug string) (*domain.Agency, error) {
+	query := `
+		SELECT id, fr_agency_id, raw_name, name, short_name, slug, description, url, json_url, parent_id, raw_data, created_at, updated_at
+		FROM agencies WHERE slug = $1
+		ORDER BY id
+		LIMIT 1
+	`
+	var a domain.Agency
+	err := r.db.QueryRowContext(ctx, query, slug).Scan(
+		&a.ID, &a.FRAgencyID, &a.RawName, &a.Name, &a.ShortName, &a.Slug, &a.Description,
+		&a.URL, &a.JSONURL, &a.ParentID, &a.RawData, &a.CreatedAt, &a.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get agency by slug: %w", err)
+	}
+	return &a, nil
+}
+
+// AgencyNode is an agency with its child agencies attached.
+type AgencyNode struct {
+	Agency   domain.Agency
+	Children []*AgencyNode
+}
+
+// ListAll returns every agency ordered by name.
+func (r *AgencyRepository) ListAll(ctx context.Context) ([]domain.Agency, error) {
+	query := `
+		SELECT id, fr_agency_id, raw_name, name, short_name, slug, description, url, json_url, parent_id, raw_data, created_at, updated_at
+		FROM agencies
+		ORDER BY name
+	`
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query agencies: %w", err)
+	}
+	defer rows.Close()
+
+	var agencies []domain.Agency
+	for rows.Next() {
+		var a domain.Agency
+		if err := rows.Scan(
+			&a.ID, &a.FRAgencyID, &a.RawName, &a.Name, &a.ShortName, &a.Slug, &a.Description,
+			&a.URL, &a.JSONURL, &a.ParentID, &a.RawData, &a.CreatedAt, &a.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan agency: %w", err)
+		}
+		agencies = append(agencies, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating agencies: %w", err)
+	}
+	return agencies, nil
+}
+
+// GetAllWithChildren loads every agency and nests each one under its parent.
+func (r *AgencyRepository) GetAllWithChildren(ctx context.Context) ([]*AgencyNode, error) {
+	agencies, err := r.ListAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return buildAgencyTree(agencies), nil
+}
+
+// buildAgencyTree nests agencies by parent_id, which holds the parent's Federal Register
+// id. Agencies whose parent is missing, and every member of a parent cycle (including an
+// agency that is its own parent), are surfaced as roots, so no agency drops out of the
+// tree. Input order is preserved among siblings.
+func buildAgencyTree(agencies []domain.Agency) []*AgencyNode {
+	nodes := make(map[int64]*AgencyNode, len(agencies))
+	ordered := make([]*AgencyNode, len(agencies))
+	for i := range agencies {
+		n := &AgencyNode{Agency: agencies[i]}
+		nodes[agencies[i].FRAgencyID] = n
+		ordered[i] = n
+	}
+
+	parentOf := func(n *AgencyNode) *AgencyNode {
+		if n.Agency.ParentID == nil {
+			return nil
+		}
+		return nodes[*n.Agency.ParentID]
+	}
+
+	// Walk each parent chain once. A chain that comes back to a node on the current walk
+	// has found a cycle, whose members are the walk from that node on.
+	const (
+		unvisited = iota
+		walking
+		done
+	)
+	state := make(map[*AgencyNode]int, len(ordered))
+	inCycle := map[*AgencyNode]bool{}
+	for _, start := range ordered {
+		var walk []*AgencyNode
+		for n := start; n != nil && state[n] == unvisited; n = parentOf(n) {
+			state[n] = walking
+			walk = append(walk, n)
+		}
+		if len(walk) > 0 {
+			if end := parentOf(walk[len(walk)-1]); end != nil && state[end] == walking {
+				for i := len(walk) - 1; i >= 0; i-- {
+					inCycle[walk[i]] = true
+					if walk[i] == end {
+						break
+					}
+				}
+			}
+		}
+		for _, n := range walk {
+			state[n] = done
+		}
+	}
+
+	var roots []*AgencyNode
+	for _, n := range ordered {
+		parent := parentOf(n)
+		if parent == nil || inCycle[n] {
+			roots = append(roots, n)
+			continue
+		}
+		parent.Children = append(parent.Children, n)
+	}
+	return roots
+}
+
+// AgencyEngagementRow is the likes and bookmarks an agency's documents received.
+type AgencyEngagementRow struct {
+	AgencyID  int64
+	Name      string
+	ShortName *string
+	Slug      string
+	Likes     int
+	Bookmarks int
+}
+
+func (r AgencyEngagementRow) Total() int {
+	return r.Likes + r.Bookmarks
+}
+
+// GetEngagementLeaderboard returns the limit agencies with the most likes plus bookmarks
+// made on their visible documents since the given time, ties broken by likes and then
+// name. A document counts toward every agency it lists, matched by agency_id or, where
+// canonicalization ran before the agency was synced, by name. Only upvotes count as
+// likes; hidden documents, including merged duplicates, and agencies with no engagement
+// are omitted.
+func (r *AgencyRepository) GetEngagementLeaderboard(ctx context.Context, since time.Time, limit int) ([]AgencyEngagementRow, error) {
+	query := `
+		WITH engagement AS (
+			SELECT fe.policy_document_id, 1 AS likes, 0 AS bookmarks
+			FROM likes l
+			JOIN feed_entries fe ON fe.id = l.feed_entry_id
+			WHERE l.value = 1 AND l.created_at >= $1
+			UNION ALL
+			SELECT fe.policy_document_id, 0 AS likes, 1 AS bookmarks
+			FROM bookmarks b
+			JOIN feed_entries fe ON fe.id = b.feed_entry_id
+			WHERE b.created_at >= $1
+		)
+		SELECT a.id, a.name, a.short_name, a.slug, SUM(e.likes), SUM(e.bookmarks)
+		FROM engagement e
+		JOIN policy_documents pd ON pd.id = e.policy_document_id
+		JOIN document_agencies da ON da.policy_document_id = pd.id
+		JOIN agencies a ON a.id = da.agency_id OR (da.agency_id IS NULL AND a.name = da.name)
+		WHERE NOT pd.hidden
+		GROUP BY a.id, a.name, a.short_name, a.slug
+		ORDER BY SUM(e.likes) + SUM(e.bookmarks) DESC, SUM(e.likes) DESC, a.name
+		LIMIT $2
+	`
+	rows, err := r.db.QueryContext(ctx, query, since, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query agency engagement: %w", err)
+	}
+	defer rows.Close()
+
+	var out []AgencyEngagementRow
+	for rows.Next() {
+		var e AgencyEngagementRow
+		if err := rows.Scan(&e.AgencyID, &e.Name, &e.ShortName, &e.Slug, &e.Likes, &e.Bookmarks); err != nil {
+			return nil, fmt.Errorf("failed to scan agency engagement: %w", err)
+		}
+		out = append(out, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating agency engagement: %w", err)
+	}
+	return out, nil
+}
+
 func (r *AgencyRepository) Create(ctx context.Context, agency *domain.Agency) error {
 	query := `
 		INSERT INTO agencies (fr_agency_id, raw_name, name, short_name, slug, description, url, json_url, parent_id, raw_data)
@@ -74,31 +309,32 @@ func (r *AgencyRepository) Create(ctx context.Context, agency *domain.Agency) er
 	return nil
 }
 
-func (r *AgencyRepository) ExistsByFRAgencyID(ctx context.Context, frAgencyID int64) (bool, error) {
-	query := "SELECT COUNT(*) FROM agencies WHERE fr_agency_id = $1"
-	var count int
-	err := r.db.QueryRowContext(ctx, query, frAgencyID).Scan(&count)
-	return count > 0, err
-}
-
+// Upsert inserts the agency or, when its fr_agency_id is already synced, overwrites the
+// stored copy, in one round trip. agency.ID is set either way.
 func (r *AgencyRepository) Upsert(ctx context.Context, agency *domain.Agency) error {
-	exists, err := r.ExistsByFRAgencyID(ctx, agency.FRAgencyID)
+	query := `
+		INSERT INTO agencies (fr_agency_id, raw_name, name, short_name, slug, description, url, json_url, parent_id, raw_data)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (fr_agency_id) DO UPDATE SET
+			raw_name    = EXCLUDED.raw_name,
+			name        = EXCLUDED.name,
+			short_name  = EXCLUDED.short_name,
+			slug        = EXCLUDED.slug,
+			description = EXCLUDED.description,
+			url         = EXCLUDED.url,
+			json_url    = EXCLUDED.json_url,
+			parent_id   = EXCLUDED.parent_id,
+			raw_data    = EXCLUDED.raw_data,
+			updated_at  = NOW()
+		RETURNING id
+	`
+	err := r.db.QueryRowContext(ctx, query,
+		agency.FRAgencyID, agency.RawName, agency.Name, agency.ShortName, agency.Slug,
+		agency.Description, agency.URL, agency.JSONURL, agency.ParentID,
+		agency.RawData,
+	).Scan(&agency.ID)
 	if err != nil {
-		return err
-	}
-
-	if exists {
-		query := `
-			UPDATE agencies SET raw_name=$1, name=$2, short_name=$3, slug=$4, description=$5, url=$6, json_url=$7, parent_id=$8, raw_data=$9, updated_at=NOW()
-			WHERE fr_agency_id=$10
-		`
-		_, err = r.db.ExecContext(ctx, query,
-			agency.RawName, agency.Name, agency.ShortName, agency.Slug, agency.Description,
-			agency.URL, agency.JSONURL, agency.ParentID, agency.RawData,
-			agency.FRAgencyID,
-		)
-		return err
+		return fmt.Errorf("failed to upsert agency: %w", err)
 	}
-
-	return r.Create(ctx, agency)
+	return nil
 }
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/alex/opengov-go/internal/domain"
+)
+
+func int64Ptr(v int64) *int64 { return &v }
+
+func TestBuildAgencyTree(t *testing.T) {
+	agencies := []domain.Agency{
+		{ID: 1, FRAgencyID: 100, Name: "Department of Agriculture"},
+		{ID: 2, FRAgencyID: 200, Name: "Food Safety and Inspection Service", ParentID: int64Ptr(100)},
+		{ID: 3, FRAgencyID: 300, Name: "Forest Service", ParentID: int64Ptr(100)},
+		{ID: 4, FRAgencyID: 400, Name: "Orphaned Office", ParentID: int64Ptr(999)},
+		{ID: 5, FRAgencyID: 500, Name: "Self Parent", ParentID: int64Ptr(500)},
+	}
+
+	roots := buildAgencyTree(agencies)
+
+	if len(roots) != 3 {
+		t.Fatalf("expected 3 roots, got %d", len(roots))
+	}
+	if roots[0].Agency.ID != 1 || len(roots[0].Children) != 2 {
+		t.Fatalf("expected USDA with 2 children, got %#v", roots[0])
+	}
+	if roots[0].Children[0].Agency.ID != 2 || roots[0].Children[1].Agency.ID != 3 {
+		t.Fatalf("children out of order")
+	}
+	if roots[1].Agency.ID != 4 {
+		t.Fatalf("orphan should surface at top level, got %#v", roots[1].Agency)
+	}
+	if roots[2].Agency.ID != 5 {
+		t.Fatalf("self-parented agency should surface at top level, got %#v", roots[2].Agency)
+	}
+}
+
+func TestBuildAgencyTree_ParentCycle(t *testing.T) {
+	agencies := []domain.Agency{
+		{ID: 1, FRAgencyID: 100, Name: "Bureau A", ParentID: int64Ptr(200)},
+		{ID: 2, FRAgencyID: 200, Name: "Bureau B", ParentID: int64Ptr(300)},
+		{ID: 3, FRAgencyID: 300, Name: "Bureau C", ParentID: int64Ptr(100)},
+		{ID: 4, FRAgencyID: 400, Name: "Office under B", ParentID: int64Ptr(200)},
+		{ID: 5, FRAgencyID: 500, Name: "Office under the office", ParentID: int64Ptr(400)},
+	}
+
+	roots := buildAgencyTree(agencies)
+
+	var ids []int64
+	for _, r := range roots {
+		ids = append(ids, r.Agency.ID)
+	}
+	if len(ids) != 3 || ids[0] != 1 || ids[1] != 2 || ids[2] != 3 {
+		t.Fatalf("roots = %v, want every cycle member (1, 2, 3)", ids)
+	}
+	if len(roots[0].Children) != 0 || len(roots[2].Children) != 0 {
+		t.Fatalf("cycle members should not nest under each other: %#v", roots)
+	}
+	b := roots[1]
+	if len(b.Children) != 1 || b.Children[0].Agency.ID != 4 || len(b.Children[0].Children) != 1 || b.Children[0].Children[0].Agency.ID != 5 {
+		t.Fatalf("agencies hanging off the cycle should stay nested under B, got %#v", b.Children)
+	}
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/alex/opengov-go/internal/db"
+	"github.com/alex/opengov-go/internal/domain"
+)
+
+type AIUsageRepository struct {
+	db *db.DB
+}
+
+func NewAIUsageRepository(db *db.DB) *AIUsageRepository {
+	return &AIUsageRepository{db: db}
+}
+
+func (r *AIUsageRepository) Create(ctx context.Context, u *domain.AIUsage) error {
+	query := `
+		INSERT INTO ai_usage (policy_document_id, provider, model, prompt_tokens, completion_tokens, total_tokens)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at, updated_at
+	`
+	err := r.db.QueryRowContext(ctx, query,
+		u.PolicyDocumentID, u.Provider, u.Model, u.PromptTokens, u.CompletionTokens, u.TotalTokens,
+	).Scan(&u.ID, &u.CreatedAt, &u.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert ai usage: %w", err)
+	}
+	return nil
+}
+
+type AIUsageDayRow struct {
+	Day              time.Time
+	Calls            int
+	PromptTokens     int
+	CompletionTokens int
+	TotalTokens      int
+}
+
+// GetDailyTotals returns token totals per UTC day since the given time, newest day first.
+func (r *AIUsageRepository) GetDailyTotals(ctx context.Context, since time.Time) ([]AIUsageDayRow, error) {
+	query := `
+		SELECT
+			date_trunc('day', created_at AT TIME ZONE 'UTC') AS day,
+			COUNT(*) AS calls,
+			COALESCE(SUM(prompt_tokens), 0) AS prompt_tokens,
+			COALESCE(SUM(completion_tokens), 0) AS completion_tokens,
+			COALESCE(SUM(total_tokens), 0) AS total_tokens
+		FROM ai_usage
+		WHERE created_at >= $1
+		GROUP BY 1
+		ORDER BY 1 DESC
+	`
+	rows, err := r.db.QueryContext(ctx, query, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query ai usage: %w", err)
+	}
+	defer rows.Close()
+
+	var out []AIUsageDayRow
+	for rows.Next() {
+		var d AIUsageDayRow
+		if err := rows.Scan(&d.Day, &d.Calls, &d.PromptTokens, &d.CompletionTokens, &d.TotalTokens); err != nil {
+			return nil, fmt.Errorf("failed to scan ai usage: %w", err)
+		}
+		out = append(out, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating ai usage: %w", err)
+	}
+	return out, nil
+}
@@ -5,9 +5,15 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
+	"github.com/lib/pq"
+
+	"github.com/alex/opengov-go/internal/constants"
 	"github.com/alex/opengov-go/internal/db"
+	"github.com/alex/opengov-go/internal/domain"
 )
 
 type FeedRepository struct {
@@ -28,6 +34,7 @@ type FeedEntryRow struct {
 	PoliticalScore *int
 	ImpactScore    *string
 	SourceURL      string
+	SourceKey      string
 
 	IsBookmarked   *bool
 	UserLikeStatus *int
@@ -35,17 +42,161 @@ type FeedEntryRow struct {
 	DislikesCount  int
 }
 
-func (r *FeedRepository) GetFeedAnon(ctx context.Context, page, limit int, sort string) ([]FeedEntryRow, int, error) {
-	offset := (page - 1) * limit
-	var orderDir string
-	if sort == "newest" {
-		orderDir = "DESC"
-	} else {
-		orderDir = "ASC"
+// FeedFilter narrows a feed query. Empty fields are not applied.
+// Agency and Agencies match any agency a document lists, not only its primary one.
+// Agencies is applied whenever it is non-nil, so an empty slice matches nothing.
+// PublishedFrom is inclusive and PublishedBefore is exclusive.
+// CreatedAfter keeps entries added to the feed after that instant, whatever their
+// publication date; zero is not applied.
+// Documents hidden by an admin are excluded unless IncludeHidden is set.
+// CFRTitle matches documents referencing any part of that CFR title; 0 is not applied.
+// Category matches documents tagged with that topic category.
+// PoliticalSide keeps entries scored left of center (-1) or right of center (1); 0 is
+// not applied, and unscored entries never match.
+// StateName matches entries whose title, summary or agency mention that US state name
+// as whole words. A mention inside a longer state name does not count, so "Virginia"
+// does not match "West Virginia".
+// Source matches the policy document's source_key, such as "federal_register".
+type FeedFilter struct {
+	Agency          string
+	Agencies        []string
+	DocumentType    string
+	Keyword         string
+	CFRTitle        int
+	Category        string
+	PoliticalSide   int
+	StateName       string
+	Source          string
+	PublishedFrom   time.Time
+	PublishedBefore time.Time
+	CreatedAfter    time.Time
+	IncludeHidden   bool
+}
+
+// Narrows reports whether the filter can exclude visible entries. IncludeHidden only
+// widens the feed, so it does not count.
+func (f FeedFilter) Narrows() bool {
+	return f.Agency != "" || f.Agencies != nil || f.DocumentType != "" || f.Keyword != "" ||
+		f.CFRTitle != 0 || f.Category != "" || f.PoliticalSide != 0 || f.StateName != "" || f.Source != "" ||
+		!f.PublishedFrom.IsZero() || !f.PublishedBefore.IsZero() || !f.CreatedAfter.IsZero()
+}
+
+// stateNamePattern is the Postgres regex (for ~*) matching name as whole words, except
+// where it ends a longer state name: for "Virginia" a preceding "West " rules the match
+// out.
+func stateNamePattern(name string) string {
+	var excluded []string
+	for _, other := range constants.USStates {
+		if prefix, ok := strings.CutSuffix(other, " "+name); ok {
+			excluded = append(excluded, `(?<!`+prefix+`\s)`)
+		}
 	}
+	slices.Sort(excluded)
+	return strings.Join(excluded, "") + `\m` + name + `\M`
+}
 
-	fromWhere := "FROM feed_entries fi"
-	whereClause := ""
+// whereClause builds a WHERE clause for the filter. Placeholders are numbered
+// after the args already bound by the caller.
+func (f FeedFilter) whereClause(args []interface{}) (string, []interface{}) {
+	var conds []string
+	if f.Agency != "" {
+		args = append(args, f.Agency)
+		conds = append(conds, fmt.Sprintf("EXISTS (SELECT 1 FROM document_agencies da WHERE da.policy_document_id = pd.id AND da.name = $%d)", len(args)))
+	}
+	if f.Agencies != nil {
+		args = append(args, pq.Array(f.Agencies))
+		conds = append(conds, fmt.Sprintf("EXISTS (SELECT 1 FROM document_agencies da WHERE da.policy_document_id = pd.id AND da.name = ANY($%d))", len(args)))
+	}
+	if f.DocumentType != "" {
+		args = append(args, f.DocumentType)
+		conds = append(conds, fmt.Sprintf("pd.document_type = $%d", len(args)))
+	}
+	if f.Keyword != "" {
+		args = append(args, "%"+f.Keyword+"%")
+		conds = append(conds, fmt.Sprintf("(fi.title ILIKE $%d OR fi.short_text ILIKE $%d)", len(args), len(args)))
+	}
+	if f.CFRTitle != 0 {
+		args = append(args, f.CFRTitle)
+		conds = append(conds, fmt.Sprintf("EXISTS (SELECT 1 FROM document_cfr_refs cr WHERE cr.policy_document_id = pd.id AND cr.cfr_title = $%d)", len(args)))
+	}
+	if f.Category != "" {
+		args = append(args, f.Category)
+		conds = append(conds, fmt.Sprintf("EXISTS (SELECT 1 FROM document_categories dc WHERE dc.policy_document_id = pd.id AND dc.category = $%d)", len(args)))
+	}
+	if f.StateName != "" {
+		args = append(args, stateNamePattern(f.StateName))
+		conds = append(conds, fmt.Sprintf("(fi.title ~* $%d OR fi.short_text ~* $%d OR pd.agency ~* $%d)", len(args), len(args), len(args)))
+	}
+	if f.Source != "" {
+		args = append(args, f.Source)
+		conds = append(conds, fmt.Sprintf("pd.source_key = $%d", len(args)))
+	}
+	switch {
+	case f.PoliticalSide < 0:
+		conds = append(conds, "fi.political_score < 0")
+	case f.PoliticalSide > 0:
+		conds = append(conds, "fi.political_score > 0")
+	}
+	if !f.PublishedFrom.IsZero() {
+		args = append(args, f.PublishedFrom)
+		conds = append(conds, fmt.Sprintf("fi.published_at >= $%d", len(args)))
+	}
+	if !f.PublishedBefore.IsZero() {
+		args = append(args, f.PublishedBefore)
+		conds = append(conds, fmt.Sprintf("fi.published_at < $%d", len(args)))
+	}
+	if !f.CreatedAfter.IsZero() {
+		args = append(args, f.CreatedAfter)
+		conds = append(conds, fmt.Sprintf("fi.created_at > $%d", len(args)))
+	}
+	if !f.IncludeHidden {
+		conds = append(conds, "NOT pd.hidden")
+	}
+	if len(conds) == 0 {
+		return "", args
+	}
+	return "WHERE " + strings.Join(conds, " AND "), args
+}
+
+// FeedSortBalanced interleaves entries from each side of the political spectrum
+// instead of listing them strictly by date.
+const FeedSortBalanced = "balanced"
+
+// balancedCenterBand is how far from 0 a political_score can be and still count as
+// center for FeedSortBalanced.
+const balancedCenterBand = 20
+
+// feedOrderBy maps a feed sort to its ORDER BY clause. Anything but newest or balanced
+// lists oldest first.
+//
+// Balanced splits entries into four groups: left (score below -balancedCenterBand),
+// center, right (above balancedCenterBand) and unscored. Each group is numbered newest
+// first, and entries are ordered by that number, so every run of four takes the next
+// newest entry from each group that has one left. A page therefore mixes viewpoints even
+// when one side dominates recent publications, and pagination stays stable because the
+// numbering covers the whole filtered feed.
+func feedOrderBy(sort string) string {
+	switch sort {
+	case "newest":
+		return "fi.published_at DESC"
+	case FeedSortBalanced:
+		group := fmt.Sprintf(`CASE
+				WHEN fi.political_score IS NULL THEN 3
+				WHEN fi.political_score < -%[1]d THEN 0
+				WHEN fi.political_score > %[1]d THEN 2
+				ELSE 1
+			END`, balancedCenterBand)
+		return fmt.Sprintf("ROW_NUMBER() OVER (PARTITION BY %[1]s ORDER BY fi.published_at DESC, fi.id DESC), %[1]s", group)
+	}
+	return "fi.published_at ASC"
+}
+
+func (r *FeedRepository) GetFeedAnon(ctx context.Context, page, limit int, sort string, filter FeedFilter) ([]FeedEntryRow, int, error) {
+	offset := (page - 1) * limit
+	orderBy := feedOrderBy(sort)
+
+	fromWhere := "FROM feed_entries fi\n\t\tJOIN policy_documents pd ON pd.id = fi.policy_document_id"
+	whereClause, args := filter.whereClause(nil)
 	likesAggJoin := `
 		LEFT JOIN (
 			SELECT
@@ -68,14 +219,15 @@ func (r *FeedRepository) GetFeedAnon(ctx context.Context, page, limit int, sort
 			fi.political_score,
 			fi.impact_score,
 			fi.source_url,
+			pd.source_key,
 			COALESCE(agg.likes_count, 0) AS likes_count,
 			COALESCE(agg.dislikes_count, 0) AS dislikes_count
 		%s
-		ORDER BY fi.published_at %s
-		LIMIT $1 OFFSET $2
-	`, baseQuery, orderDir)
+		ORDER BY %s
+		LIMIT $%d OFFSET $%d
+	`, baseQuery, orderBy, len(args)+1, len(args)+2)
 
-	rows, err := r.db.QueryContext(ctx, query, limit, offset)
+	rows, err := r.db.QueryContext(ctx, query, append(args, limit, offset)...)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to query feed: %w", err)
 	}
@@ -97,6 +249,7 @@ func (r *FeedRepository) GetFeedAnon(ctx context.Context, page, limit int, sort
 			&politicalScore,
 			&impactScore,
 			&item.SourceURL,
+			&item.SourceKey,
 			&likesCount,
 			&dislikesCount,
 		)
@@ -122,24 +275,19 @@ func (r *FeedRepository) GetFeedAnon(ctx context.Context, page, limit int, sort
 
 	var total int
 	countQuery := "SELECT COUNT(DISTINCT fi.id)\n" + baseQuery
-	if err := r.db.QueryRowContext(ctx, countQuery).Scan(&total); err != nil {
+	if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
 		return nil, 0, fmt.Errorf("failed to count feed entrys: %w", err)
 	}
 
 	return items, total, nil
 }
 
-func (r *FeedRepository) GetFeedForUser(ctx context.Context, userID int64, page, limit int, sort string) ([]FeedEntryRow, int, error) {
+func (r *FeedRepository) GetFeedForUser(ctx context.Context, userID int64, page, limit int, sort string, filter FeedFilter) ([]FeedEntryRow, int, error) {
 	offset := (page - 1) * limit
-	var orderDir string
-	if sort == "newest" {
-		orderDir = "DESC"
-	} else {
-		orderDir = "ASC"
-	}
+	orderBy := feedOrderBy(sort)
 
-	fromWhere := "FROM feed_entries fi"
-	whereClause := ""
+	fromWhere := "FROM feed_entries fi\n\t\tJOIN policy_documents pd ON pd.id = fi.policy_document_id"
+	whereClause, args := filter.whereClause([]interface{}{userID})
 	likesAggJoin := `
 		LEFT JOIN (
 			SELECT
@@ -166,16 +314,17 @@ func (r *FeedRepository) GetFeedForUser(ctx context.Context, userID int64, page,
 			fi.political_score,
 			fi.impact_score,
 			fi.source_url,
+			pd.source_key,
 			COALESCE(agg.likes_count, 0) AS likes_count,
 			COALESCE(agg.dislikes_count, 0) AS dislikes_count,
 			(CASE WHEN b.feed_entry_id IS NULL THEN FALSE ELSE TRUE END) AS is_bookmarked,
 			ul.value AS user_like_status
 		%s
-		ORDER BY fi.published_at %s
-		LIMIT $2 OFFSET $3
-	`, baseQuery, orderDir)
+		ORDER BY %s
+		LIMIT $%d OFFSET $%d
+	`, baseQuery, orderBy, len(args)+1, len(args)+2)
 
-	rows, err := r.db.QueryContext(ctx, query, userID, limit, offset)
+	rows, err := r.db.QueryContext(ctx, query, append(args, limit, offset)...)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to query feed for user: %w", err)
 	}
@@ -199,6 +348,7 @@ func (r *FeedRepository) GetFeedForUser(ctx context.Context, userID int64, page,
 			&politicalScore,
 			&impactScore,
 			&item.SourceURL,
+			&item.SourceKey,
 			&likesCount,
 			&dislikesCount,
 			&isBookmarked,
@@ -232,14 +382,97 @@ func (r *FeedRepository) GetFeedForUser(ctx context.Context, userID int64, page,
 
 	var total int
 	countQuery := "SELECT COUNT(DISTINCT fi.id)\n" + baseQuery
-	if err := r.db.QueryRowContext(ctx, countQuery, userID).Scan(&total); err != nil {
+	if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
 		return nil, 0, fmt.Errorf("failed to count feed entrys: %w", err)
 	}
 
 	return items, total, nil
 }
 
-func (r *FeedRepository) GetByIDAnon(ctx context.Context, feedEntryID int64) (*FeedEntryRow, error) {
+type ArchiveBucketRow struct {
+	Year  int
+	Month int
+	Count int
+}
+
+// GetArchiveBuckets returns per-month feed entry counts, with months taken in the named
+// IANA timezone, newest month first.
+func (r *FeedRepository) GetArchiveBuckets(ctx context.Context, timezone string) ([]ArchiveBucketRow, error) {
+	query := `
+		SELECT
+			EXTRACT(YEAR FROM fi.published_at AT TIME ZONE $1)::int AS year,
+			EXTRACT(MONTH FROM fi.published_at AT TIME ZONE $1)::int AS month,
+			COUNT(*) AS count
+		FROM feed_entries fi
+		JOIN policy_documents pd ON pd.id = fi.policy_document_id
+		WHERE NOT pd.hidden
+		GROUP BY 1, 2
+		ORDER BY 1 DESC, 2 DESC
+	`
+	rows, err := r.db.QueryContext(ctx, query, timezone)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query archive buckets: %w", err)
+	}
+	defer rows.Close()
+
+	var out []ArchiveBucketRow
+	for rows.Next() {
+		var b ArchiveBucketRow
+		if err := rows.Scan(&b.Year, &b.Month, &b.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan archive bucket: %w", err)
+		}
+		out = append(out, b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating archive buckets: %w", err)
+	}
+	return out, nil
+}
+
+// GetRecentKeyPoints returns the key points of each feed entry published at or after since.
+func (r *FeedRepository) GetRecentKeyPoints(ctx context.Context, since time.Time) ([][]string, error) {
+	query := `
+		SELECT fi.key_points
+		FROM feed_entries fi
+		JOIN policy_documents pd ON pd.id = fi.policy_document_id
+		WHERE fi.published_at >= $1 AND fi.key_points IS NOT NULL AND NOT pd.hidden
+	`
+	rows, err := r.db.QueryContext(ctx, query, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query key points: %w", err)
+	}
+	defer rows.Close()
+
+	var out [][]string
+	for rows.Next() {
+		var raw []byte
+		if err := rows.Scan(&raw); err != nil {
+			return nil, fmt.Errorf("failed to scan key points: %w", err)
+		}
+		var points []string
+		if err := json.Unmarshal(raw, &points); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal key_points: %w", err)
+		}
+		out = append(out, points)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating key points: %w", err)
+	}
+	return out, nil
+}
+
+// hiddenDocumentFilter is the condition single-entry lookups add so a document hidden by
+// an admin is not served, matching FeedFilter.IncludeHidden for lists.
+func hiddenDocumentFilter(includeHidden bool) string {
+	if includeHidden {
+		return ""
+	}
+	return "AND NOT pd.hidden"
+}
+
+// GetByIDAnon returns nil when the entry does not exist or its document is hidden,
+// unless includeHidden is set.
+func (r *FeedRepository) GetByIDAnon(ctx context.Context, feedEntryID int64, includeHidden bool) (*FeedEntryRow, error) {
 	query := `
 		SELECT
 			fi.id AS feed_entry_id,
@@ -250,9 +483,11 @@ func (r *FeedRepository) GetByIDAnon(ctx context.Context, feedEntryID int64) (*F
 			fi.political_score,
 			fi.impact_score,
 			fi.source_url,
+			pd.source_key,
 			COALESCE(agg.likes_count, 0) AS likes_count,
 			COALESCE(agg.dislikes_count, 0) AS dislikes_count
 		FROM feed_entries fi
+		JOIN policy_documents pd ON pd.id = fi.policy_document_id
 		LEFT JOIN (
 			SELECT
 				feed_entry_id,
@@ -261,8 +496,7 @@ func (r *FeedRepository) GetByIDAnon(ctx context.Context, feedEntryID int64) (*F
 			FROM likes
 			GROUP BY feed_entry_id
 		) agg ON agg.feed_entry_id = fi.id
-		WHERE fi.id = $1
-	`
+		WHERE fi.id = $1 ` + hiddenDocumentFilter(includeHidden)
 
 	var item FeedEntryRow
 	var keyPointsRaw []byte
@@ -278,6 +512,7 @@ func (r *FeedRepository) GetByIDAnon(ctx context.Context, feedEntryID int64) (*F
 		&politicalScore,
 		&impactScore,
 		&item.SourceURL,
+		&item.SourceKey,
 		&likesCount,
 		&dislikesCount,
 	)
@@ -304,7 +539,8 @@ func (r *FeedRepository) GetByIDAnon(ctx context.Context, feedEntryID int64) (*F
 	return &item, nil
 }
 
-func (r *FeedRepository) GetByIDForUser(ctx context.Context, userID, feedEntryID int64) (*FeedEntryRow, error) {
+// GetByIDForUser is GetByIDAnon with the user's bookmark and like state.
+func (r *FeedRepository) GetByIDForUser(ctx context.Context, userID, feedEntryID int64, includeHidden bool) (*FeedEntryRow, error) {
 	query := `
 		SELECT
 			fi.id AS feed_entry_id,
@@ -315,11 +551,13 @@ func (r *FeedRepository) GetByIDForUser(ctx context.Context, userID, feedEntryID
 			fi.political_score,
 			fi.impact_score,
 			fi.source_url,
+			pd.source_key,
 			COALESCE(agg.likes_count, 0) AS likes_count,
 			COALESCE(agg.dislikes_count, 0) AS dislikes_count,
 			(CASE WHEN b.feed_entry_id IS NULL THEN FALSE ELSE TRUE END) AS is_bookmarked,
 			ul.value AS user_like_status
 		FROM feed_entries fi
+		JOIN policy_documents pd ON pd.id = fi.policy_document_id
 		LEFT JOIN (
 			SELECT
 				feed_entry_id,
@@ -330,8 +568,7 @@ func (r *FeedRepository) GetByIDForUser(ctx context.Context, userID, feedEntryID
 		) agg ON agg.feed_entry_id = fi.id
 		LEFT JOIN bookmarks b ON b.feed_entry_id = fi.id AND b.user_id = $2
 		LEFT JOIN likes ul ON ul.feed_entry_id = fi.id AND ul.user_id = $2
-		WHERE fi.id = $1
-	`
+		WHERE fi.id = $1 ` + hiddenDocumentFilter(includeHidden)
 
 	var item FeedEntryRow
 	var keyPointsRaw []byte
@@ -349,6 +586,7 @@ func (r *FeedRepository) GetByIDForUser(ctx context.Context, userID, feedEntryID
 		&politicalScore,
 		&impactScore,
 		&item.SourceURL,
+		&item.SourceKey,
 		&likesCount,
 		&dislikesCount,
 		&isBookmarked,
@@ -394,9 +632,11 @@ func (r *FeedRepository) GetByPolicyDocID(ctx context.Context, policyDocID int64
 			fi.political_score,
 			fi.impact_score,
 			fi.source_url,
+			pd.source_key,
 			COALESCE(agg.likes_count, 0) AS likes_count,
 			COALESCE(agg.dislikes_count, 0) AS dislikes_count
 		FROM feed_entries fi
+		JOIN policy_documents pd ON pd.id = fi.policy_document_id
 		LEFT JOIN (
 			SELECT
 				feed_entry_id,
@@ -422,6 +662,7 @@ func (r *FeedRepository) GetByPolicyDocID(ctx context.Context, policyDocID int64
 		&politicalScore,
 		&impactScore,
 		&item.SourceURL,
+		&item.SourceKey,
 		&likesCount,
 		&dislikesCount,
 	)
@@ -491,8 +732,68 @@ func (r *FeedRepository) UpsertFeedEntryByPolicyDocID(ctx context.Context, tx *s
 	return nil
 }
 
-func (r *FeedRepository) GetBookmarkedFeed(ctx context.Context, userID int64) ([]FeedEntryRow, error) {
-	query := `
+// Bookmark sort orders accepted by GetBookmarkedFeed.
+const (
+	BookmarkSortNewest          = "newest"           // most recently bookmarked first
+	BookmarkSortOldest          = "oldest"           // earliest bookmarked first
+	BookmarkSortPublishedNewest = "published_newest" // latest publication date first
+	BookmarkSortPublishedOldest = "published_oldest" // earliest publication date first
+)
+
+// bookmarkOrderBy maps a bookmark sort to its ORDER BY clause. ok is false for an unknown
+// sort.
+func bookmarkOrderBy(sort string) (clause string, ok bool) {
+	switch sort {
+	case BookmarkSortNewest:
+		return "b.created_at DESC, b.id DESC", true
+	case BookmarkSortOldest:
+		return "b.created_at ASC, b.id ASC", true
+	case BookmarkSortPublishedNewest:
+		return "fi.published_at DESC, fi.id DESC", true
+	case BookmarkSortPublishedOldest:
+		return "fi.published_at ASC, fi.id ASC", true
+	}
+	return "", false
+}
+
+// ValidBookmarkSort reports whether GetBookmarkedFeed accepts sort.
+func ValidBookmarkSort(sort string) bool {
+	_, ok := bookmarkOrderBy(sort)
+	return ok
+}
+
+// GetBookmarkedFeed returns one page of userID's bookmarked entries in the given sort
+// order, and the total number matching. A non-empty agency keeps only entries whose
+// document lists that agency.
+func (r *FeedRepository) GetBookmarkedFeed(ctx context.Context, userID int64, page, limit int, sort, agency string) ([]FeedEntryRow, int, error) {
+	orderBy, ok := bookmarkOrderBy(sort)
+	if !ok {
+		return nil, 0, fmt.Errorf("unknown bookmark sort %q", sort)
+	}
+
+	bookmarkFilter := "WHERE b.user_id = $1"
+	args := []interface{}{userID}
+	if agency != "" {
+		args = append(args, agency)
+		bookmarkFilter += `
+			AND EXISTS (
+				SELECT 1 FROM document_agencies da
+				WHERE da.policy_document_id = fi.policy_document_id AND da.name = $2
+			)`
+	}
+
+	var total int
+	countQuery := `
+		SELECT COUNT(*)
+		FROM bookmarks b
+		JOIN feed_entries fi ON fi.id = b.feed_entry_id
+		` + bookmarkFilter
+	if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count bookmarked feed entries: %w", err)
+	}
+
+	offset := (page - 1) * limit
+	query := fmt.Sprintf(`
 		SELECT
 			fi.id AS feed_entry_id,
 			fi.published_at,
@@ -502,12 +803,14 @@ func (r *FeedRepository) GetBookmarkedFeed(ctx context.Context, userID int64) ([
 			fi.political_score,
 			fi.impact_score,
 			fi.source_url,
+			pd.source_key,
 			COALESCE(agg.likes_count, 0) AS likes_count,
 			COALESCE(agg.dislikes_count, 0) AS dislikes_count,
 			TRUE AS is_bookmarked,
 			ul.value AS user_like_status
 		FROM bookmarks b
 		JOIN feed_entries fi ON fi.id = b.feed_entry_id
+		JOIN policy_documents pd ON pd.id = fi.policy_document_id
 		LEFT JOIN (
 			SELECT
 				feed_entry_id,
@@ -517,13 +820,14 @@ func (r *FeedRepository) GetBookmarkedFeed(ctx context.Context, userID int64) ([
 			GROUP BY feed_entry_id
 		) agg ON agg.feed_entry_id = fi.id
 		LEFT JOIN likes ul ON ul.feed_entry_id = fi.id AND ul.user_id = $1
-		WHERE b.user_id = $1
-		ORDER BY b.created_at DESC
-	`
+		%s
+		ORDER BY %s
+		LIMIT $%d OFFSET $%d
+	`, bookmarkFilter, orderBy, len(args)+1, len(args)+2)
 
-	rows, err := r.db.QueryContext(ctx, query, userID)
+	rows, err := r.db.QueryContext(ctx, query, append(args, limit, offset)...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query bookmarked feed entrys: %w", err)
+		return nil, 0, fmt.Errorf("failed to query bookmarked feed entrys: %w", err)
 	}
 	defer rows.Close()
 
@@ -545,13 +849,14 @@ func (r *FeedRepository) GetBookmarkedFeed(ctx context.Context, userID int64) ([
 			&politicalScore,
 			&impactScore,
 			&item.SourceURL,
+			&item.SourceKey,
 			&likesCount,
 			&dislikesCount,
 			&isBookmarked,
 			&userLikeStatus,
 		)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan feed entry: %w", err)
+			return nil, 0, fmt.Errorf("failed to scan feed entry: %w", err)
 		}
 		item.LikesCount = int(likesCount)
 		item.DislikesCount = int(dislikesCount)
@@ -568,6 +873,249 @@ func (r *FeedRepository) GetBookmarkedFeed(ctx context.Context, userID int64) ([
 			uls := int(userLikeStatus.Int64)
 			item.UserLikeStatus = &uls
 		}
+		if len(keyPointsRaw) > 0 {
+			if err := json.Unmarshal(keyPointsRaw, &item.KeyPoints); err != nil {
+				return nil, 0, fmt.Errorf("failed to unmarshal key_points: %w", err)
+			}
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating bookmarked feed entries: %w", err)
+	}
+	return items, total, nil
+}
+
+// Activity actions recorded by GetActivity.
+const (
+	ActivityLiked      = "liked"
+	ActivityDisliked   = "disliked"
+	ActivityBookmarked = "bookmarked"
+)
+
+// ActivityRow is one of a user's interactions with a feed entry. OccurredAt is when a
+// bookmark was made or a reaction last changed.
+type ActivityRow struct {
+	Action     string
+	OccurredAt time.Time
+	Entry      FeedEntryRow
+}
+
+// GetActivity returns one page of userID's likes, dislikes and bookmarks, most recent
+// first, and the total number of them. An entry both liked and bookmarked appears once
+// for each.
+func (r *FeedRepository) GetActivity(ctx context.Context, userID int64, page, limit int) ([]ActivityRow, int, error) {
+	activity := `
+		SELECT
+			feed_entry_id,
+			CASE WHEN value = 1 THEN '` + ActivityLiked + `' ELSE '` + ActivityDisliked + `' END AS action,
+			updated_at AS occurred_at
+		FROM likes
+		WHERE user_id = $1
+		UNION ALL
+		SELECT feed_entry_id, '` + ActivityBookmarked + `', created_at
+		FROM bookmarks
+		WHERE user_id = $1
+	`
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM ("+activity+") a", userID).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count activity: %w", err)
+	}
+
+	offset := (page - 1) * limit
+	query := `
+		WITH activity AS (` + activity + `)
+		SELECT
+			a.action,
+			a.occurred_at,
+			fi.id AS feed_entry_id,
+			fi.published_at,
+			fi.title,
+			fi.short_text,
+			fi.key_points,
+			fi.political_score,
+			fi.impact_score,
+			fi.source_url,
+			pd.source_key,
+			COALESCE(agg.likes_count, 0) AS likes_count,
+			COALESCE(agg.dislikes_count, 0) AS dislikes_count,
+			ub.id IS NOT NULL AS is_bookmarked,
+			ul.value AS user_like_status
+		FROM activity a
+		JOIN feed_entries fi ON fi.id = a.feed_entry_id
+		JOIN policy_documents pd ON pd.id = fi.policy_document_id
+		LEFT JOIN (
+			SELECT
+				feed_entry_id,
+				SUM(CASE WHEN value = 1 THEN 1 ELSE 0 END) AS likes_count,
+				SUM(CASE WHEN value = -1 THEN 1 ELSE 0 END) AS dislikes_count
+			FROM likes
+			GROUP BY feed_entry_id
+		) agg ON agg.feed_entry_id = fi.id
+		LEFT JOIN likes ul ON ul.feed_entry_id = fi.id AND ul.user_id = $1
+		LEFT JOIN bookmarks ub ON ub.feed_entry_id = fi.id AND ub.user_id = $1
+		ORDER BY a.occurred_at DESC, fi.id DESC, a.action
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query activity: %w", err)
+	}
+	defer rows.Close()
+
+	var items []ActivityRow
+	for rows.Next() {
+		var item ActivityRow
+		var keyPointsRaw []byte
+		var politicalScore sql.NullInt64
+		var impactScore sql.NullString
+		var isBookmarked bool
+		var userLikeStatus sql.NullInt64
+		var likesCount, dislikesCount int64
+		err := rows.Scan(
+			&item.Action,
+			&item.OccurredAt,
+			&item.Entry.FeedEntryID,
+			&item.Entry.PublishedAt,
+			&item.Entry.Title,
+			&item.Entry.ShortText,
+			&keyPointsRaw,
+			&politicalScore,
+			&impactScore,
+			&item.Entry.SourceURL,
+			&item.Entry.SourceKey,
+			&likesCount,
+			&dislikesCount,
+			&isBookmarked,
+			&userLikeStatus,
+		)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan activity: %w", err)
+		}
+		item.Entry.LikesCount = int(likesCount)
+		item.Entry.DislikesCount = int(dislikesCount)
+		if politicalScore.Valid {
+			ps := int(politicalScore.Int64)
+			item.Entry.PoliticalScore = &ps
+		}
+		if impactScore.Valid {
+			item.Entry.ImpactScore = &impactScore.String
+		}
+		bookmarked := isBookmarked
+		item.Entry.IsBookmarked = &bookmarked
+		if userLikeStatus.Valid {
+			uls := int(userLikeStatus.Int64)
+			item.Entry.UserLikeStatus = &uls
+		}
+		if len(keyPointsRaw) > 0 {
+			if err := json.Unmarshal(keyPointsRaw, &item.Entry.KeyPoints); err != nil {
+				return nil, 0, fmt.Errorf("failed to unmarshal key_points: %w", err)
+			}
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating activity: %w", err)
+	}
+	return items, total, nil
+}
+
+// GetRelated returns up to limit visible entries, newest first, whose document shares an
+// agency or a category with feedEntryID's document. The entry itself is excluded, and
+// nothing is returned when its document is hidden unless includeHidden is set.
+// Agencies are compared by agency_id when both listings resolved one and by name
+// otherwise. Documents without categories are matched on agency alone.
+func (r *FeedRepository) GetRelated(ctx context.Context, feedEntryID int64, includeHidden bool, limit int) ([]FeedEntryRow, error) {
+	query := `
+		WITH cur AS (
+			SELECT fi.id, fi.policy_document_id
+			FROM feed_entries fi
+			JOIN policy_documents pd ON pd.id = fi.policy_document_id
+			WHERE fi.id = $1 ` + hiddenDocumentFilter(includeHidden) + `
+		)
+		SELECT
+			fi.id AS feed_entry_id,
+			fi.published_at,
+			fi.title,
+			fi.short_text,
+			fi.key_points,
+			fi.political_score,
+			fi.impact_score,
+			fi.source_url,
+			pd.source_key,
+			COALESCE(agg.likes_count, 0) AS likes_count,
+			COALESCE(agg.dislikes_count, 0) AS dislikes_count
+		FROM feed_entries fi
+		JOIN policy_documents pd ON pd.id = fi.policy_document_id
+		CROSS JOIN cur
+		LEFT JOIN (
+			SELECT
+				feed_entry_id,
+				SUM(CASE WHEN value = 1 THEN 1 ELSE 0 END) AS likes_count,
+				SUM(CASE WHEN value = -1 THEN 1 ELSE 0 END) AS dislikes_count
+			FROM likes
+			GROUP BY feed_entry_id
+		) agg ON agg.feed_entry_id = fi.id
+		WHERE fi.id <> cur.id
+			AND NOT pd.hidden
+			AND (
+				EXISTS (
+					SELECT 1
+					FROM document_agencies da
+					JOIN document_agencies ca ON COALESCE(ca.agency_id = da.agency_id, ca.name = da.name)
+					WHERE da.policy_document_id = pd.id AND ca.policy_document_id = cur.policy_document_id
+				)
+				OR EXISTS (
+					SELECT 1
+					FROM document_categories dc
+					JOIN document_categories cc ON cc.category = dc.category
+					WHERE dc.policy_document_id = pd.id AND cc.policy_document_id = cur.policy_document_id
+				)
+			)
+		ORDER BY fi.published_at DESC, fi.id DESC
+		LIMIT $2
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, feedEntryID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query related feed entries: %w", err)
+	}
+	defer rows.Close()
+
+	var items []FeedEntryRow
+	for rows.Next() {
+		var item FeedEntryRow
+		var keyPointsRaw []byte
+		var politicalScore sql.NullInt64
+		var impactScore sql.NullString
+		var likesCount, dislikesCount int64
+		err := rows.Scan(
+			&item.FeedEntryID,
+			&item.PublishedAt,
+			&item.Title,
+			&item.ShortText,
+			&keyPointsRaw,
+			&politicalScore,
+			&impactScore,
+			&item.SourceURL,
+			&item.SourceKey,
+			&likesCount,
+			&dislikesCount,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan feed entry: %w", err)
+		}
+		item.LikesCount = int(likesCount)
+		item.DislikesCount = int(dislikesCount)
+		if politicalScore.Valid {
+			ps := int(politicalScore.Int64)
+			item.PoliticalScore = &ps
+		}
+		if impactScore.Valid {
+			item.ImpactScore = &impactScore.String
+		}
 		if len(keyPointsRaw) > 0 {
 			if err := json.Unmarshal(keyPointsRaw, &item.KeyPoints); err != nil {
 				return nil, fmt.Errorf("failed to unmarshal key_points: %w", err)
@@ -575,5 +1123,427 @@ func (r *FeedRepository) GetBookmarkedFeed(ctx context.Context, userID int64) ([
 		}
 		items = append(items, item)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating related feed entries: %w", err)
+	}
 	return items, nil
 }
+
+// FeedTranslationRow is a feed entry's summary and keypoints in another language.
+type FeedTranslationRow struct {
+	ShortText string
+	KeyPoints []string
+}
+
+// GetTranslations returns the lang translations of the given feed entries, keyed by feed
+// entry id. Entries without one are absent from the map.
+func (r *FeedRepository) GetTranslations(ctx context.Context, lang string, feedEntryIDs []int64) (map[int64]FeedTranslationRow, error) {
+	out := make(map[int64]FeedTranslationRow)
+	if len(feedEntryIDs) == 0 {
+		return out, nil
+	}
+
+	query := `
+		SELECT fi.id, t.summary, t.keypoints
+		FROM feed_entries fi
+		JOIN document_translations t ON t.policy_document_id = fi.policy_document_id AND t.lang = $1
+		WHERE fi.id = ANY($2)
+	`
+	rows, err := r.db.QueryContext(ctx, query, lang, pq.Array(feedEntryIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query translations: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int64
+		var t FeedTranslationRow
+		var keyPointsRaw []byte
+		if err := rows.Scan(&id, &t.ShortText, &keyPointsRaw); err != nil {
+			return nil, fmt.Errorf("failed to scan translation: %w", err)
+		}
+		if len(keyPointsRaw) > 0 {
+			if err := json.Unmarshal(keyPointsRaw, &t.KeyPoints); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal key_points: %w", err)
+			}
+		}
+		out[id] = t
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating translations: %w", err)
+	}
+	return out, nil
+}
+
+// IsVisible reports whether a feed entry with the given id exists and, unless
+// includeHidden is set, its document is not hidden.
+func (r *FeedRepository) IsVisible(ctx context.Context, feedEntryID int64, includeHidden bool) (bool, error) {
+	query := `
+		SELECT EXISTS(
+			SELECT 1
+			FROM feed_entries fi
+			JOIN policy_documents pd ON pd.id = fi.policy_document_id
+			WHERE fi.id = $1 ` + hiddenDocumentFilter(includeHidden) + `
+		)
+	`
+	var visible bool
+	if err := r.db.QueryRowContext(ctx, query, feedEntryID).Scan(&visible); err != nil {
+		return false, fmt.Errorf("failed to check feed entry: %w", err)
+	}
+	return visible, nil
+}
+
+// Exists reports whether a feed entry with the given id exists.
+func (r *FeedRepository) Exists(ctx context.Context, feedEntryID int64) (bool, error) {
+	var exists bool
+	err := r.db.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM feed_entries WHERE id = $1)", feedEntryID).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check feed entry: %w", err)
+	}
+	return exists, nil
+}
+
+// HasEntries reports whether any feed entry exists, counting hidden documents only when
+// includeHidden is set.
+func (r *FeedRepository) HasEntries(ctx context.Context, includeHidden bool) (bool, error) {
+	query := `
+		SELECT EXISTS(
+			SELECT 1
+			FROM feed_entries fi
+			JOIN policy_documents pd ON pd.id = fi.policy_document_id
+			WHERE $1 OR NOT pd.hidden
+		)
+	`
+	var exists bool
+	if err := r.db.QueryRowContext(ctx, query, includeHidden).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check for feed entries: %w", err)
+	}
+	return exists, nil
+}
+
+// FeedExportRow is one line of a feed export.
+type FeedExportRow struct {
+	ID             int64
+	Title          string
+	Agency         *string
+	Summary        string
+	ImpactScore    *string
+	PoliticalScore *int
+	PublishedAt    time.Time
+	SourceURL      string
+}
+
+// FeedCursor marks a position in an export's newest-first order.
+type FeedCursor struct {
+	PublishedAt time.Time
+	ID          int64
+}
+
+// Export calls fn for each entry matching filter, newest first, up to limit, starting
+// after the given cursor when it is non-nil. Rows are read one at a time so memory does
+// not grow with the result; an error from fn stops the export and is returned as is.
+func (r *FeedRepository) Export(ctx context.Context, filter FeedFilter, after *FeedCursor, limit int, fn func(FeedExportRow) error) error {
+	whereClause, args := filter.whereClause(nil)
+	if after != nil {
+		args = append(args, after.PublishedAt, after.ID)
+		cond := fmt.Sprintf("(fi.published_at, fi.id) < ($%d, $%d)", len(args)-1, len(args))
+		if whereClause == "" {
+			whereClause = "WHERE " + cond
+		} else {
+			whereClause += " AND " + cond
+		}
+	}
+	query := fmt.Sprintf(`
+		SELECT
+			fi.id,
+			fi.title,
+			pd.agency,
+			fi.short_text,
+			fi.impact_score,
+			fi.political_score,
+			fi.published_at,
+			fi.source_url
+		FROM feed_entries fi
+		JOIN policy_documents pd ON pd.id = fi.policy_document_id
+		%s
+		ORDER BY fi.published_at DESC, fi.id DESC
+		LIMIT $%d
+	`, whereClause, len(args)+1)
+
+	rows, err := r.db.QueryContext(ctx, query, append(args, limit)...)
+	if err != nil {
+		return fmt.Errorf("failed to query feed export: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row FeedExportRow
+		var agency, impactScore sql.NullString
+		var politicalScore sql.NullInt64
+		if err := rows.Scan(&row.ID, &row.Title, &agency, &row.Summary, &impactScore, &politicalScore, &row.PublishedAt, &row.SourceURL); err != nil {
+			return fmt.Errorf("failed to scan feed export row: %w", err)
+		}
+		if agency.Valid {
+			row.Agency = &agency.String
+		}
+		if impactScore.Valid {
+			row.ImpactScore = &impactScore.String
+		}
+		if politicalScore.Valid {
+			ps := int(politicalScore.Int64)
+			row.PoliticalScore = &ps
+		}
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to read feed export: %w", err)
+	}
+	return nil
+}
+
+// GetVersion returns the newest updated_at across the tables the anonymous feed shows,
+// which moves whenever a page of it may have changed. Writers bump updated_at on every
+// change, including LikeRepository.Remove, which touches the entry it unlikes. Each
+// MAX reads the end of an updated_at index.
+func (r *FeedRepository) GetVersion(ctx context.Context) (time.Time, error) {
+	query := `
+		SELECT GREATEST(
+			(SELECT MAX(updated_at) FROM feed_entries),
+			(SELECT MAX(updated_at) FROM policy_documents),
+			(SELECT MAX(updated_at) FROM likes),
+			(SELECT MAX(updated_at) FROM document_translations)
+		)
+	`
+	var updatedAt sql.NullTime
+	if err := r.db.QueryRowContext(ctx, query).Scan(&updatedAt); err != nil {
+		return time.Time{}, fmt.Errorf("failed to get feed version: %w", err)
+	}
+	return updatedAt.Time, nil
+}
+
+// BookmarkAffinity summarizes a user's bookmarks for recommendations: how many bookmarked
+// documents list each agency and have each document type.
+type BookmarkAffinity struct {
+	FeedEntryIDs  map[int64]bool
+	Agencies      map[string]int
+	DocumentTypes map[string]int
+}
+
+// GetBookmarkAffinity returns the agency and document type counts across a user's
+// bookmarks.
+func (r *FeedRepository) GetBookmarkAffinity(ctx context.Context, userID int64) (BookmarkAffinity, error) {
+	query := `
+		SELECT
+			fi.id,
+			ARRAY(SELECT da.name FROM document_agencies da WHERE da.policy_document_id = pd.id),
+			COALESCE(pd.document_type, '')
+		FROM bookmarks b
+		JOIN feed_entries fi ON fi.id = b.feed_entry_id
+		JOIN policy_documents pd ON pd.id = fi.policy_document_id
+		WHERE b.user_id = $1
+	`
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return BookmarkAffinity{}, fmt.Errorf("failed to query bookmark affinity: %w", err)
+	}
+	defer rows.Close()
+
+	aff := BookmarkAffinity{
+		FeedEntryIDs:  map[int64]bool{},
+		Agencies:      map[string]int{},
+		DocumentTypes: map[string]int{},
+	}
+	for rows.Next() {
+		var id int64
+		var agencies []string
+		var docType string
+		if err := rows.Scan(&id, pq.Array(&agencies), &docType); err != nil {
+			return BookmarkAffinity{}, fmt.Errorf("failed to scan bookmark affinity: %w", err)
+		}
+		aff.FeedEntryIDs[id] = true
+		for _, agency := range agencies {
+			aff.Agencies[agency]++
+		}
+		if docType != "" {
+			aff.DocumentTypes[docType]++
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return BookmarkAffinity{}, fmt.Errorf("error iterating bookmark affinity: %w", err)
+	}
+	return aff, nil
+}
+
+// RecommendationCandidate is a feed entry with the fields recommendations match on.
+// Agencies lists every agency the document names, primary first.
+type RecommendationCandidate struct {
+	Entry        FeedEntryRow
+	Agencies     []string
+	DocumentType string
+}
+
+// GetRecommendationCandidates returns up to limit of the newest visible feed entries
+// listing any of the given agencies or having any of the given document types that the
+// user has not bookmarked.
+func (r *FeedRepository) GetRecommendationCandidates(ctx context.Context, userID int64, agencies, documentTypes []string, limit int) ([]RecommendationCandidate, error) {
+	query := `
+		SELECT
+			fi.id AS feed_entry_id,
+			fi.published_at,
+			fi.title,
+			fi.short_text,
+			fi.key_points,
+			fi.political_score,
+			fi.impact_score,
+			fi.source_url,
+			pd.source_key,
+			COALESCE(agg.likes_count, 0) AS likes_count,
+			COALESCE(agg.dislikes_count, 0) AS dislikes_count,
+			ul.value AS user_like_status,
+			ARRAY(
+				SELECT da.name FROM document_agencies da
+				WHERE da.policy_document_id = pd.id
+				ORDER BY da.position
+			),
+			COALESCE(pd.document_type, '')
+		FROM feed_entries fi
+		JOIN policy_documents pd ON pd.id = fi.policy_document_id
+		LEFT JOIN (
+			SELECT
+				feed_entry_id,
+				SUM(CASE WHEN value = 1 THEN 1 ELSE 0 END) AS likes_count,
+				SUM(CASE WHEN value = -1 THEN 1 ELSE 0 END) AS dislikes_count
+			FROM likes
+			GROUP BY feed_entry_id
+		) agg ON agg.feed_entry_id = fi.id
+		LEFT JOIN likes ul ON ul.feed_entry_id = fi.id AND ul.user_id = $1
+		WHERE NOT pd.hidden
+			AND (
+				EXISTS (
+					SELECT 1 FROM document_agencies da
+					WHERE da.policy_document_id = pd.id AND da.name = ANY($2)
+				)
+				OR pd.document_type = ANY($3)
+			)
+			AND NOT EXISTS (
+				SELECT 1 FROM bookmarks b WHERE b.user_id = $1 AND b.feed_entry_id = fi.id
+			)
+		ORDER BY fi.published_at DESC
+		LIMIT $4
+	`
+	rows, err := r.db.QueryContext(ctx, query, userID, pq.Array(agencies), pq.Array(documentTypes), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query recommendation candidates: %w", err)
+	}
+	defer rows.Close()
+
+	var out []RecommendationCandidate
+	for rows.Next() {
+		var c RecommendationCandidate
+		item := &c.Entry
+		var keyPointsRaw []byte
+		var politicalScore sql.NullInt64
+		var impactScore sql.NullString
+		var userLikeStatus sql.NullInt64
+		var likesCount, dislikesCount int64
+		err := rows.Scan(
+			&item.FeedEntryID,
+			&item.PublishedAt,
+			&item.Title,
+			&item.ShortText,
+			&keyPointsRaw,
+			&politicalScore,
+			&impactScore,
+			&item.SourceURL,
+			&item.SourceKey,
+			&likesCount,
+			&dislikesCount,
+			&userLikeStatus,
+			pq.Array(&c.Agencies),
+			&c.DocumentType,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan recommendation candidate: %w", err)
+		}
+		item.LikesCount = int(likesCount)
+		item.DislikesCount = int(dislikesCount)
+		if politicalScore.Valid {
+			ps := int(politicalScore.Int64)
+			item.PoliticalScore = &ps
+		}
+		if impactScore.Valid {
+			item.ImpactScore = &impactScore.String
+		}
+		bookmarked := false
+		item.IsBookmarked = &bookmarked
+		if userLikeStatus.Valid {
+			uls := int(userLikeStatus.Int64)
+			item.UserLikeStatus = &uls
+		}
+		if len(keyPointsRaw) > 0 {
+			if err := json.Unmarshal(keyPointsRaw, &item.KeyPoints); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal key_points: %w", err)
+			}
+		}
+		out = append(out, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating recommendation candidates: %w", err)
+	}
+	return out, nil
+}
+
+// GetAgencies returns the agencies the document behind a feed entry lists, primary
+// first. It is empty when the entry does not exist.
+func (r *FeedRepository) GetAgencies(ctx context.Context, feedEntryID int64) ([]domain.DocumentAgency, error) {
+	query := `
+		SELECT da.agency_id, da.name
+		FROM feed_entries fi
+		JOIN document_agencies da ON da.policy_document_id = fi.policy_document_id
+		WHERE fi.id = $1
+		ORDER BY da.position
+	`
+	rows, err := r.db.QueryContext(ctx, query, feedEntryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get document agencies: %w", err)
+	}
+	defer rows.Close()
+
+	var agencies []domain.DocumentAgency
+	for rows.Next() {
+		var a domain.DocumentAgency
+		if err := rows.Scan(&a.AgencyID, &a.Name); err != nil {
+			return nil, fmt.Errorf("failed to scan document agency: %w", err)
+		}
+		agencies = append(agencies, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating document agencies: %w", err)
+	}
+	return agencies, nil
+}
+
+// GetAbstract returns the full upstream abstract of the document behind a feed entry.
+// found is false when the entry does not exist; abstract is nil when upstream had none.
+func (r *FeedRepository) GetAbstract(ctx context.Context, feedEntryID int64) (abstract *string, found bool, err error) {
+	query := `
+		SELECT pd.abstract
+		FROM feed_entries fi
+		JOIN policy_documents pd ON pd.id = fi.policy_document_id
+		WHERE fi.id = $1
+	`
+	var a sql.NullString
+	err = r.db.QueryRowContext(ctx, query, feedEntryID).Scan(&a)
+	if err == sql.ErrNoRows {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get abstract: %w", err)
+	}
+	if a.Valid {
+		abstract = &a.String
+	}
+	return abstract, true, nil
+}
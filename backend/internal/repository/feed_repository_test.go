@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFeedFilterWhereClause_Empty(t *testing.T) {
+	where, args := FeedFilter{IncludeHidden: true}.whereClause(nil)
+	if where != "" || len(args) != 0 {
+		t.Fatalf("expected no clause, got %q %v", where, args)
+	}
+}
+
+func TestFeedFilterWhereClause_ExcludesHiddenByDefault(t *testing.T) {
+	where, args := FeedFilter{}.whereClause(nil)
+	if where != "WHERE NOT pd.hidden" || len(args) != 0 {
+		t.Fatalf("unexpected clause %q %v", where, args)
+	}
+}
+
+func TestFeedFilterWhereClause_NumbersAfterExistingArgs(t *testing.T) {
+	f := FeedFilter{Agency: "Food and Drug Administration", Keyword: "safety"}
+	where, args := f.whereClause([]interface{}{int64(42)})
+
+	want := "WHERE EXISTS (SELECT 1 FROM document_agencies da WHERE da.policy_document_id = pd.id AND da.name = $2) AND (fi.title ILIKE $3 OR fi.short_text ILIKE $3) AND NOT pd.hidden"
+	if where != want {
+		t.Fatalf("where = %q, want %q", where, want)
+	}
+	if len(args) != 3 || args[2] != "%safety%" {
+		t.Fatalf("unexpected args: %v", args)
+	}
+}
+
+func TestFeedFilterWhereClause_PublishedRange(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, 0)
+	where, args := FeedFilter{PublishedFrom: start, PublishedBefore: end}.whereClause(nil)
+
+	if !strings.Contains(where, "fi.published_at >= $1") || !strings.Contains(where, "fi.published_at < $2") {
+		t.Fatalf("unexpected where: %q", where)
+	}
+	if args[0] != start || args[1] != end {
+		t.Fatalf("unexpected args: %v", args)
+	}
+}
+
+func TestFeedFilterWhereClause_CFRTitle(t *testing.T) {
+	where, args := FeedFilter{Agency: "EPA", CFRTitle: 40}.whereClause(nil)
+
+	if !strings.Contains(where, "cr.policy_document_id = pd.id AND cr.cfr_title = $2") {
+		t.Fatalf("unexpected where: %q", where)
+	}
+	if len(args) != 2 || args[1] != 40 {
+		t.Fatalf("unexpected args: %v", args)
+	}
+}
+
+func TestFeedFilterWhereClause_Category(t *testing.T) {
+	where, args := FeedFilter{Category: "health"}.whereClause(nil)
+
+	if !strings.Contains(where, "dc.policy_document_id = pd.id AND dc.category = $1") {
+		t.Fatalf("unexpected where: %q", where)
+	}
+	if len(args) != 1 || args[0] != "health" {
+		t.Fatalf("unexpected args: %v", args)
+	}
+}
+
+func TestFeedFilterWhereClause_CreatedAfter(t *testing.T) {
+	since := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	f := FeedFilter{CreatedAfter: since}
+	where, args := f.whereClause(nil)
+
+	if where != "WHERE fi.created_at > $1 AND NOT pd.hidden" || len(args) != 1 || args[0] != since {
+		t.Fatalf("unexpected clause %q %v", where, args)
+	}
+	if !f.Narrows() {
+		t.Fatal("CreatedAfter should narrow the feed")
+	}
+}
+
+func TestFeedFilterWhereClause_PoliticalSide(t *testing.T) {
+	for side, want := range map[int]string{
+		-1: "WHERE fi.political_score < 0 AND NOT pd.hidden",
+		1:  "WHERE fi.political_score > 0 AND NOT pd.hidden",
+	} {
+		where, args := FeedFilter{PoliticalSide: side}.whereClause(nil)
+		if where != want || len(args) != 0 {
+			t.Errorf("side %d: where = %q %v, want %q", side, where, args, want)
+		}
+	}
+}
+
+func TestFeedFilterWhereClause_StateName(t *testing.T) {
+	where, args := FeedFilter{StateName: "New Mexico"}.whereClause([]interface{}{int64(1)})
+
+	want := "WHERE (fi.title ~* $2 OR fi.short_text ~* $2 OR pd.agency ~* $2) AND NOT pd.hidden"
+	if where != want {
+		t.Fatalf("where = %q, want %q", where, want)
+	}
+	if len(args) != 2 || args[1] != `\mNew Mexico\M` {
+		t.Fatalf("unexpected args: %v", args)
+	}
+}
+
+func TestStateNamePattern(t *testing.T) {
+	cases := map[string]string{
+		"Virginia":      `(?<!West\s)\mVirginia\M`,
+		"West Virginia": `\mWest Virginia\M`,
+		"Kansas":        `\mKansas\M`,
+	}
+	for name, want := range cases {
+		if got := stateNamePattern(name); got != want {
+			t.Errorf("stateNamePattern(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestFeedFilterWhereClause_Source(t *testing.T) {
+	f := FeedFilter{Source: "federal_register"}
+	where, args := f.whereClause(nil)
+
+	if where != "WHERE pd.source_key = $1 AND NOT pd.hidden" || len(args) != 1 || args[0] != "federal_register" {
+		t.Fatalf("unexpected clause %q %v", where, args)
+	}
+	if !f.Narrows() {
+		t.Fatal("Source should narrow the feed")
+	}
+}
+
+func TestHiddenDocumentFilter(t *testing.T) {
+	if got := hiddenDocumentFilter(false); got != "AND NOT pd.hidden" {
+		t.Errorf("hiddenDocumentFilter(false) = %q", got)
+	}
+	if got := hiddenDocumentFilter(true); got != "" {
+		t.Errorf("hiddenDocumentFilter(true) = %q, want no condition", got)
+	}
+}
+
+func TestFeedOrderBy(t *testing.T) {
+	if got := feedOrderBy("newest"); got != "fi.published_at DESC" {
+		t.Errorf("newest = %q", got)
+	}
+	if got := feedOrderBy("oldest"); got != "fi.published_at ASC" {
+		t.Errorf("oldest = %q", got)
+	}
+	if got := feedOrderBy(FeedSortBalanced); !strings.HasPrefix(got, "ROW_NUMBER() OVER (PARTITION BY") {
+		t.Errorf("balanced = %q", got)
+	}
+}
+
+func TestBookmarkOrderBy(t *testing.T) {
+	for sort, want := range map[string]string{
+		BookmarkSortNewest:          "b.created_at DESC",
+		BookmarkSortOldest:          "b.created_at ASC",
+		BookmarkSortPublishedNewest: "fi.published_at DESC",
+		BookmarkSortPublishedOldest: "fi.published_at ASC",
+	} {
+		clause, ok := bookmarkOrderBy(sort)
+		if !ok || !strings.HasPrefix(clause, want) {
+			t.Errorf("bookmarkOrderBy(%q) = %q, %v; want prefix %q", sort, clause, ok, want)
+		}
+	}
+	if _, ok := bookmarkOrderBy("title"); ok {
+		t.Fatal("expected an unknown sort to be rejected")
+	}
+}
@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/alex/opengov-go/internal/constants"
+	"github.com/alex/opengov-go/internal/db"
+	"github.com/alex/opengov-go/internal/domain"
+)
+
+type JobRepository struct {
+	db *db.DB
+}
+
+func NewJobRepository(db *db.DB) *JobRepository {
+	return &JobRepository{db: db}
+}
+
+// Create inserts a pending job of the given type, recorded by origin.
+func (r *JobRepository) Create(ctx context.Context, jobType, origin string) (*domain.Job, error) {
+	query := `
+		INSERT INTO jobs (type, status, origin)
+		VALUES ($1, $2, $3)
+		RETURNING id, type, status, processed, skipped, total, error, started_at, finished_at, created_at, updated_at
+	`
+	var j domain.Job
+	err := r.db.QueryRowContext(ctx, query, jobType, constants.JobStatusPending, origin).Scan(
+		&j.ID, &j.Type, &j.Status, &j.Processed, &j.Skipped, &j.Total, &j.Error, &j.StartedAt, &j.FinishedAt, &j.CreatedAt, &j.UpdatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create job: %w", err)
+	}
+	return &j, nil
+}
+
+func (r *JobRepository) MarkRunning(ctx context.Context, id int64) error {
+	query := "UPDATE jobs SET status = $2, started_at = NOW(), updated_at = NOW() WHERE id = $1"
+	if _, err := r.db.ExecContext(ctx, query, id, constants.JobStatusRunning); err != nil {
+		return fmt.Errorf("failed to mark job running: %w", err)
+	}
+	return nil
+}
+
+func (r *JobRepository) UpdateCounts(ctx context.Context, id int64, processed, skipped int) error {
+	query := "UPDATE jobs SET processed = $2, skipped = $3, updated_at = NOW() WHERE id = $1"
+	if _, err := r.db.ExecContext(ctx, query, id, processed, skipped); err != nil {
+		return fmt.Errorf("failed to update job counts: %w", err)
+	}
+	return nil
+}
+
+// SetTotal records how many items the job expects to handle.
+func (r *JobRepository) SetTotal(ctx context.Context, id int64, total int) error {
+	query := "UPDATE jobs SET total = $2, updated_at = NOW() WHERE id = $1"
+	if _, err := r.db.ExecContext(ctx, query, id, total); err != nil {
+		return fmt.Errorf("failed to set job total: %w", err)
+	}
+	return nil
+}
+
+// Finish sets the terminal status. errMsg is nil for a successful job.
+func (r *JobRepository) Finish(ctx context.Context, id int64, status string, errMsg *string) error {
+	query := "UPDATE jobs SET status = $2, error = $3, finished_at = NOW(), updated_at = NOW() WHERE id = $1"
+	if _, err := r.db.ExecContext(ctx, query, id, status, errMsg); err != nil {
+		return fmt.Errorf("failed to finish job: %w", err)
+	}
+	return nil
+}
+
+// FailUnfinished marks every pending or running job recorded by origin as failed with
+// errMsg and returns how many it marked. It is for startup, when no job from that origin
+// can still be running.
+func (r *JobRepository) FailUnfinished(ctx context.Context, origin, errMsg string) (int64, error) {
+	query := `
+		UPDATE jobs
+		SET status = $4, error = $5, finished_at = NOW(), updated_at = NOW()
+		WHERE origin = $1 AND status IN ($2, $3)
+	`
+	res, err := r.db.ExecContext(ctx, query, origin, constants.JobStatusPending, constants.JobStatusRunning, constants.JobStatusFailed, errMsg)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fail unfinished jobs: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to count unfinished jobs: %w", err)
+	}
+	return n, nil
+}
+
+// Get returns nil when no job has the given id.
+func (r *JobRepository) Get(ctx context.Context, id int64) (*domain.Job, error) {
+	query := `
+		SELECT id, type, status, processed, skipped, total, error, started_at, finished_at, created_at, updated_at
+		FROM jobs
+		WHERE id = $1
+	`
+	var j domain.Job
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&j.ID, &j.Type, &j.Status, &j.Processed, &j.Skipped, &j.Total, &j.Error, &j.StartedAt, &j.FinishedAt, &j.CreatedAt, &j.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job: %w", err)
+	}
+	return &j, nil
+}
+
+// List returns the most recent jobs, newest first.
+func (r *JobRepository) List(ctx context.Context, limit int) ([]domain.Job, error) {
+	query := `
+		SELECT id, type, status, processed, skipped, total, error, started_at, finished_at, created_at, updated_at
+		FROM jobs
+		ORDER BY created_at DESC, id DESC
+		LIMIT $1
+	`
+	rows, err := r.db.QueryContext(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query jobs: %w", err)
+	}
+	defer rows.Close()
+
+	var out []domain.Job
+	for rows.Next() {
+		var j domain.Job
+		err := rows.Scan(&j.ID, &j.Type, &j.Status, &j.Processed, &j.Skipped, &j.Total, &j.Error, &j.StartedAt, &j.FinishedAt, &j.CreatedAt, &j.UpdatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan job: %w", err)
+		}
+		out = append(out, j)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating jobs: %w", err)
+	}
+	return out, nil
+}
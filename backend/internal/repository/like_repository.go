@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/lib/pq"
+
 	"github.com/alex/opengov-go/internal/db"
 	"github.com/alex/opengov-go/internal/domain"
 )
@@ -87,6 +89,51 @@ func (r *LikeRepository) GetFeedEntryCounts(ctx context.Context, feedEntryID int
 	return likes, dislikes, nil
 }
 
+// LikeCounts is the number of likes and dislikes on one feed entry.
+type LikeCounts struct {
+	Likes    int
+	Dislikes int
+}
+
+// GetFeedEntryCountsBatch returns counts for each of ids in one query. Every id gets an
+// entry; entries with no votes (or that don't exist) are zero.
+func (r *LikeRepository) GetFeedEntryCountsBatch(ctx context.Context, ids []int64) (map[int64]LikeCounts, error) {
+	counts := make(map[int64]LikeCounts, len(ids))
+	for _, id := range ids {
+		counts[id] = LikeCounts{}
+	}
+	if len(ids) == 0 {
+		return counts, nil
+	}
+
+	query := `
+		SELECT feed_entry_id,
+			COUNT(*) FILTER (WHERE value = 1),
+			COUNT(*) FILTER (WHERE value = -1)
+		FROM likes
+		WHERE feed_entry_id = ANY($1)
+		GROUP BY feed_entry_id
+	`
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("failed to count likes: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int64
+		var c LikeCounts
+		if err := rows.Scan(&id, &c.Likes, &c.Dislikes); err != nil {
+			return nil, fmt.Errorf("failed to scan like counts: %w", err)
+		}
+		counts[id] = c
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating like counts: %w", err)
+	}
+	return counts, nil
+}
+
 func (r *LikeRepository) GetUserStatus(ctx context.Context, userID, feedEntryID int64) (status *int, err error) {
 	query := "SELECT value FROM likes WHERE user_id = $1 AND feed_entry_id = $2"
 	var value int
@@ -100,8 +147,18 @@ func (r *LikeRepository) GetUserStatus(ctx context.Context, userID, feedEntryID
 	return &value, nil
 }
 
+// Remove deletes a user's reaction to an entry. It also touches the entry's updated_at,
+// since a deletion would otherwise leave the feed version (FeedRepository.GetVersion)
+// unchanged while the entry's like counts drop.
 func (r *LikeRepository) Remove(ctx context.Context, userID, feedEntryID int64) error {
-	query := "DELETE FROM likes WHERE user_id = $1 AND feed_entry_id = $2"
+	query := `
+		WITH removed AS (
+			DELETE FROM likes WHERE user_id = $1 AND feed_entry_id = $2
+			RETURNING feed_entry_id
+		)
+		UPDATE feed_entries SET updated_at = NOW()
+		WHERE id IN (SELECT feed_entry_id FROM removed)
+	`
 	_, err := r.db.ExecContext(ctx, query, userID, feedEntryID)
 	return err
 }
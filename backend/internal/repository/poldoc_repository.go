@@ -6,11 +6,13 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/lib/pq"
+
 	"github.com/alex/opengov-go/internal/db"
 	"github.com/alex/opengov-go/internal/domain"
-	"github.com/lib/pq"
 )
 
 var ErrDuplicateDocument = errors.New("document already exists")
@@ -23,9 +25,10 @@ func NewPolicyDocumentRepository(db *db.DB) *PolicyDocumentRepository {
 	return &PolicyDocumentRepository{db: db}
 }
 
+// GetByID returns sql.ErrNoRows when no document has the id.
 func (r *PolicyDocumentRepository) GetByID(ctx context.Context, id int64) (*domain.PolicyDocument, error) {
 	query := `
-		SELECT id, source_key, external_id, fetched_at, title, agency, summary, keypoints, impact_score, political_score, source_url, published_at, document_type, pdf_url, created_at, updated_at
+		SELECT id, source_key, external_id, fetched_at, title, agency, summary, keypoints, impact_score, political_score, source_url, published_at, document_type, pdf_url, abstract, created_at, updated_at
 		FROM policy_documents WHERE id = $1
 	`
 	var a domain.PolicyDocument
@@ -35,7 +38,7 @@ func (r *PolicyDocumentRepository) GetByID(ctx context.Context, id int64) (*doma
 	err := r.db.QueryRowContext(ctx, query, id).Scan(
 		&a.ID, &a.SourceKey, &a.ExternalID, &a.FetchedAt,
 		&a.Title, &agency, &a.Summary, &keypointsRaw, &impactScore, &politicalScore, &a.SourceURL, &a.PublishedAt,
-		&documentType, &pdfURL, &a.CreatedAt, &a.UpdatedAt,
+		&documentType, &pdfURL, &a.Abstract, &a.CreatedAt, &a.UpdatedAt,
 	)
 	if err != nil {
 		return nil, err
@@ -58,6 +61,8 @@ func (r *PolicyDocumentRepository) ExistsBySourceKeyExternalID(ctx context.Conte
 	return count > 0, err
 }
 
+// GetBySourceKeyExternalID returns nil when no document has the key. The pair is unique
+// (idx_policy_documents_source_key_external_id), so at most one row matches.
 func (r *PolicyDocumentRepository) GetBySourceKeyExternalID(ctx context.Context, sourceKey, externalID string) (*domain.PolicyDocument, error) {
 	query := `
 		SELECT id, source_key, external_id, fetched_at, title, agency, summary, keypoints, impact_score, political_score, source_url, published_at, document_type, pdf_url, created_at, updated_at
@@ -72,8 +77,11 @@ func (r *PolicyDocumentRepository) GetBySourceKeyExternalID(ctx context.Context,
 		&a.Title, &agency, &a.Summary, &keypointsRaw, &impactScore, &politicalScore, &a.SourceURL, &a.PublishedAt,
 		&documentType, &pdfURL, &a.CreatedAt, &a.UpdatedAt,
 	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to get document by source key: %w", err)
 	}
 	a.Agency = agency
 	if len(keypointsRaw) > 0 {
@@ -104,19 +112,19 @@ func (r *PolicyDocumentRepository) Create(ctx context.Context, tx *sql.Tx, doc *
 	}
 
 	query := `
-		INSERT INTO policy_documents (source_key, external_id, fetched_at, title, agency, summary, keypoints, impact_score, political_score, source_url, published_at, document_type, pdf_url)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		INSERT INTO policy_documents (source_key, external_id, fetched_at, title, agency, summary, keypoints, impact_score, political_score, source_url, published_at, document_type, pdf_url, abstract)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
 		RETURNING id
 	`
 	err = tx.QueryRowContext(ctx, query,
 		doc.SourceKey, doc.ExternalID, doc.FetchedAt,
 		doc.Title, doc.Agency, doc.Summary, keypointsJSON, doc.ImpactScore, doc.PoliticalScore,
 		doc.SourceURL, doc.PublishedAt,
-		doc.DocumentType, doc.PDFURL,
+		doc.DocumentType, doc.PDFURL, doc.Abstract,
 	).Scan(&doc.ID)
 	if err != nil {
 		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" && pqErr.Constraint == "idx_policy_documents_source_key_external_id" {
-			return ErrDuplicateDocument
+			return fmt.Errorf("%w: %s/%s", ErrDuplicateDocument, doc.SourceKey, doc.ExternalID)
 		}
 		return fmt.Errorf("failed to insert document: %w", err)
 	}
@@ -143,14 +151,17 @@ func (r *PolicyDocumentRepository) UpsertCanonical(ctx context.Context, tx *sql.
 			source_key, external_id, fetched_at,
 			title, agency, summary, keypoints,
 			impact_score, political_score,
-			source_url, published_at, document_type, pdf_url
+			source_url, published_at, document_type, pdf_url,
+			abstract, agency_id
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
 		ON CONFLICT (source_key, external_id) DO UPDATE SET
 			fetched_at      = EXCLUDED.fetched_at,
 			title           = EXCLUDED.title,
 			agency          = EXCLUDED.agency,
+			agency_id       = EXCLUDED.agency_id,
 			summary         = EXCLUDED.summary,
+			abstract        = EXCLUDED.abstract,
 			keypoints       = EXCLUDED.keypoints,
 			impact_score    = EXCLUDED.impact_score,
 			political_score = EXCLUDED.political_score,
@@ -169,6 +180,7 @@ func (r *PolicyDocumentRepository) UpsertCanonical(ctx context.Context, tx *sql.
 		doc.ImpactScore, doc.PoliticalScore,
 		doc.SourceURL, doc.PublishedAt,
 		doc.DocumentType, doc.PDFURL,
+		doc.Abstract, doc.AgencyID,
 	).Scan(&id)
 	if err != nil {
 		return 0, fmt.Errorf("failed to upsert canonical document: %w", err)
@@ -176,6 +188,165 @@ func (r *PolicyDocumentRepository) UpsertCanonical(ctx context.Context, tx *sql.
 	return id, nil
 }
 
+// ReplaceCFRRefs sets the CFR references of a document to refs, dropping any others.
+func (r *PolicyDocumentRepository) ReplaceCFRRefs(ctx context.Context, tx *sql.Tx, policyDocID int64, refs []domain.CFRRef) error {
+	if _, err := tx.ExecContext(ctx, "DELETE FROM document_cfr_refs WHERE policy_document_id = $1", policyDocID); err != nil {
+		return fmt.Errorf("failed to clear cfr refs: %w", err)
+	}
+	for _, ref := range refs {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO document_cfr_refs (policy_document_id, cfr_title, cfr_part)
+			VALUES ($1, $2, $3)
+			ON CONFLICT DO NOTHING
+		`, policyDocID, ref.Title, ref.Part)
+		if err != nil {
+			return fmt.Errorf("failed to insert cfr ref: %w", err)
+		}
+	}
+	return nil
+}
+
+// ReplaceAgencies sets the agencies of a document to agencies, in order, dropping any
+// others.
+func (r *PolicyDocumentRepository) ReplaceAgencies(ctx context.Context, tx *sql.Tx, policyDocID int64, agencies []domain.DocumentAgency) error {
+	if _, err := tx.ExecContext(ctx, "DELETE FROM document_agencies WHERE policy_document_id = $1", policyDocID); err != nil {
+		return fmt.Errorf("failed to clear document agencies: %w", err)
+	}
+	for i, a := range agencies {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO document_agencies (policy_document_id, position, agency_id, name)
+			VALUES ($1, $2, $3, $4)
+		`, policyDocID, i, a.AgencyID, a.Name)
+		if err != nil {
+			return fmt.Errorf("failed to insert document agency: %w", err)
+		}
+	}
+	return nil
+}
+
+// ReplaceCategories sets the topic categories of a document to categories, dropping any
+// others.
+func (r *PolicyDocumentRepository) ReplaceCategories(ctx context.Context, tx *sql.Tx, policyDocID int64, categories []string) error {
+	if _, err := tx.ExecContext(ctx, "DELETE FROM document_categories WHERE policy_document_id = $1", policyDocID); err != nil {
+		return fmt.Errorf("failed to clear categories: %w", err)
+	}
+	for _, c := range categories {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO document_categories (policy_document_id, category)
+			VALUES ($1, $2)
+			ON CONFLICT DO NOTHING
+		`, policyDocID, c)
+		if err != nil {
+			return fmt.Errorf("failed to insert category: %w", err)
+		}
+	}
+	return nil
+}
+
+// CategoryCountRow is how many visible documents carry a category.
+type CategoryCountRow struct {
+	Category string
+	Count    int
+}
+
+// CountByCategory returns each category in use with its number of documents, most used
+// first. Hidden documents are not counted.
+func (r *PolicyDocumentRepository) CountByCategory(ctx context.Context) ([]CategoryCountRow, error) {
+	query := `
+		SELECT dc.category, COUNT(*)
+		FROM document_categories dc
+		JOIN policy_documents pd ON pd.id = dc.policy_document_id
+		WHERE NOT pd.hidden
+		GROUP BY dc.category
+		ORDER BY COUNT(*) DESC, dc.category ASC
+	`
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count categories: %w", err)
+	}
+	defer rows.Close()
+
+	var out []CategoryCountRow
+	for rows.Next() {
+		var c CategoryCountRow
+		if err := rows.Scan(&c.Category, &c.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan category count: %w", err)
+		}
+		out = append(out, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating category counts: %w", err)
+	}
+	return out, nil
+}
+
+// TranslationSourceRow is a document whose summary needs translating.
+type TranslationSourceRow struct {
+	PolicyDocumentID int64
+	Title            string
+	Agency           *string
+	Summary          string
+}
+
+// ListNeedingTranslation returns up to limit documents in the feed, newest first, that
+// have no lang translation or changed since it was written. Documents in excludeIDs are
+// skipped so a caller can pass over ones that failed.
+func (r *PolicyDocumentRepository) ListNeedingTranslation(ctx context.Context, lang string, excludeIDs []int64, limit int) ([]TranslationSourceRow, error) {
+	query := `
+		SELECT pd.id, pd.title, pd.agency, pd.summary
+		FROM policy_documents pd
+		JOIN feed_entries fe ON fe.policy_document_id = pd.id
+		LEFT JOIN document_translations t ON t.policy_document_id = pd.id AND t.lang = $1
+		WHERE pd.summary <> ''
+			AND (t.policy_document_id IS NULL OR t.updated_at < pd.updated_at)
+			AND NOT (pd.id = ANY($2))
+		ORDER BY pd.published_at DESC, pd.id DESC
+		LIMIT $3
+	`
+	rows, err := r.db.QueryContext(ctx, query, lang, pq.Array(excludeIDs), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query documents for translation: %w", err)
+	}
+	defer rows.Close()
+
+	var out []TranslationSourceRow
+	for rows.Next() {
+		var d TranslationSourceRow
+		if err := rows.Scan(&d.PolicyDocumentID, &d.Title, &d.Agency, &d.Summary); err != nil {
+			return nil, fmt.Errorf("failed to scan document for translation: %w", err)
+		}
+		out = append(out, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating documents for translation: %w", err)
+	}
+	return out, nil
+}
+
+// UpsertTranslation stores the lang summary and keypoints of a document.
+func (r *PolicyDocumentRepository) UpsertTranslation(ctx context.Context, policyDocID int64, lang, summary string, keypoints []string) error {
+	var keypointsJSON []byte
+	if len(keypoints) > 0 {
+		var err error
+		keypointsJSON, err = json.Marshal(keypoints)
+		if err != nil {
+			return fmt.Errorf("failed to marshal keypoints: %w", err)
+		}
+	}
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO document_translations (policy_document_id, lang, summary, keypoints)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (policy_document_id, lang) DO UPDATE SET
+			summary = EXCLUDED.summary,
+			keypoints = EXCLUDED.keypoints,
+			updated_at = NOW()
+	`, policyDocID, lang, summary, keypointsJSON)
+	if err != nil {
+		return fmt.Errorf("failed to upsert translation: %w", err)
+	}
+	return nil
+}
+
 func (r *PolicyDocumentRepository) ListNeedingMaterialization(ctx context.Context, limit int) ([]*domain.PolicyDocument, error) {
 	query := `
 		SELECT
@@ -250,10 +421,17 @@ func (r *PolicyDocumentRepository) ListNeedingMaterialization(ctx context.Contex
 	return out, nil
 }
 
+// needsEnrichment matches documents missing AI fields. We intentionally keep this
+// predicate aligned with the pipeline plan:
+// - impact_score IS NULL OR political_score IS NULL OR keypoints empty.
+const needsEnrichment = `(
+	impact_score IS NULL
+	OR political_score IS NULL
+	OR keypoints IS NULL
+	OR keypoints = '[]'::jsonb
+)`
+
 func (r *PolicyDocumentRepository) ListNeedingEnrichment(ctx context.Context, limit int) ([]*domain.PolicyDocument, error) {
-	// "Needs enrichment" means missing AI fields.
-	// We intentionally keep this predicate aligned with the pipeline plan:
-	// - impact_score IS NULL OR political_score IS NULL OR keypoints empty.
 	query := `
 		SELECT
 			id,
@@ -273,11 +451,7 @@ func (r *PolicyDocumentRepository) ListNeedingEnrichment(ctx context.Context, li
 			created_at,
 			updated_at
 		FROM policy_documents
-		WHERE
-			impact_score IS NULL
-			OR political_score IS NULL
-			OR keypoints IS NULL
-			OR keypoints = '[]'::jsonb
+		WHERE ` + needsEnrichment + `
 		ORDER BY published_at DESC
 		LIMIT $1
 	`
@@ -366,12 +540,142 @@ func (r *PolicyDocumentRepository) Update(ctx context.Context, tx *sql.Tx, doc *
 	return nil
 }
 
+// Count returns the number of documents not hidden by an admin.
 func (r *PolicyDocumentRepository) Count(ctx context.Context) (int, error) {
 	var count int
-	err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM policy_documents").Scan(&count)
+	err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM policy_documents WHERE NOT hidden").Scan(&count)
 	return count, err
 }
 
+// SetHidden hides or unhides a document. It reports whether the document exists.
+func (r *PolicyDocumentRepository) SetHidden(ctx context.Context, id int64, hidden bool) (bool, error) {
+	res, err := r.db.ExecContext(ctx, "UPDATE policy_documents SET hidden = $1, updated_at = NOW() WHERE id = $2", hidden, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to update document visibility: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to read affected rows: %w", err)
+	}
+	return n > 0, nil
+}
+
+// DocumentStats breaks down the visible documents for the admin stats endpoint.
+// Enriched and Unenriched sum to the Count total.
+type DocumentStats struct {
+	ByDocumentType map[string]int
+	ByImpactScore  map[string]int
+	AddedLast24h   int
+	AddedLast7d    int
+	Enriched       int
+	Unenriched     int
+}
+
+// GetStats counts visible documents by document_type ("Unknown" when unset) and
+// impact_score ("unscored" when unset), by how recently they were added, and by whether
+// they still need enrichment.
+func (r *PolicyDocumentRepository) GetStats(ctx context.Context) (*DocumentStats, error) {
+	stats := &DocumentStats{ByDocumentType: map[string]int{}, ByImpactScore: map[string]int{}}
+
+	query := `
+		SELECT
+			COUNT(*) FILTER (WHERE created_at >= NOW() - INTERVAL '24 hours'),
+			COUNT(*) FILTER (WHERE created_at >= NOW() - INTERVAL '7 days'),
+			COUNT(*) FILTER (WHERE NOT ` + needsEnrichment + `),
+			COUNT(*) FILTER (WHERE ` + needsEnrichment + `)
+		FROM policy_documents
+		WHERE NOT hidden
+	`
+	err := r.db.QueryRowContext(ctx, query).Scan(&stats.AddedLast24h, &stats.AddedLast7d, &stats.Enriched, &stats.Unenriched)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count documents: %w", err)
+	}
+
+	query = `
+		SELECT 'document_type', COALESCE(document_type, 'Unknown'), COUNT(*)
+		FROM policy_documents
+		WHERE NOT hidden
+		GROUP BY 2
+		UNION ALL
+		SELECT 'impact_score', COALESCE(impact_score, 'unscored'), COUNT(*)
+		FROM policy_documents
+		WHERE NOT hidden
+		GROUP BY 2
+	`
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to group documents: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var field, value string
+		var n int
+		if err := rows.Scan(&field, &value, &n); err != nil {
+			return nil, fmt.Errorf("failed to scan document group: %w", err)
+		}
+		if field == "document_type" {
+			stats.ByDocumentType[value] = n
+		} else {
+			stats.ByImpactScore[value] = n
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating document groups: %w", err)
+	}
+	return stats, nil
+}
+
+// AgencyDocumentCounts summarizes the documents published by one agency.
+type AgencyDocumentCounts struct {
+	Total           int
+	ByDocumentType  map[string]int
+	LastPublishedAt *time.Time
+}
+
+// CountByAgency groups the visible documents listing an agency, primary or not, by
+// document_type. Listings are matched by agency_id, or by name where canonicalization
+// ran before the agency was synced. Agencies with no documents yield zero counts rather
+// than an error.
+func (r *PolicyDocumentRepository) CountByAgency(ctx context.Context, agency *domain.Agency) (*AgencyDocumentCounts, error) {
+	query := `
+		SELECT COALESCE(pd.document_type, 'Unknown') AS document_type, COUNT(*), MAX(pd.published_at)
+		FROM policy_documents pd
+		WHERE NOT pd.hidden
+			AND EXISTS (
+				SELECT 1 FROM document_agencies da
+				WHERE da.policy_document_id = pd.id
+					AND (da.agency_id = $1 OR (da.agency_id IS NULL AND da.name = $2))
+			)
+		GROUP BY 1
+	`
+	rows, err := r.db.QueryContext(ctx, query, agency.ID, agency.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count documents by agency: %w", err)
+	}
+	defer rows.Close()
+
+	counts := &AgencyDocumentCounts{ByDocumentType: map[string]int{}}
+	for rows.Next() {
+		var docType string
+		var n int
+		var latest time.Time
+		if err := rows.Scan(&docType, &n, &latest); err != nil {
+			return nil, fmt.Errorf("failed to scan agency document count: %w", err)
+		}
+		counts.ByDocumentType[docType] = n
+		counts.Total += n
+		if counts.LastPublishedAt == nil || latest.After(*counts.LastPublishedAt) {
+			l := latest
+			counts.LastPublishedAt = &l
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating agency document counts: %w", err)
+	}
+	return counts, nil
+}
+
 func (r *PolicyDocumentRepository) GetLatest(ctx context.Context) (*domain.PolicyDocument, error) {
 	query := `
 		SELECT id, source_key, external_id, fetched_at, title, agency, summary, keypoints, impact_score, political_score, source_url, published_at, document_type, pdf_url, created_at, updated_at
@@ -401,3 +705,140 @@ func (r *PolicyDocumentRepository) GetLatest(ctx context.Context) (*domain.Polic
 	a.PDFURL = pdfURL
 	return &a, nil
 }
+
+// DocumentScope selects documents for a bulk operation. Zero fields match everything;
+// PublishedFrom and PublishedBefore bound published_at as a half-open range.
+type DocumentScope struct {
+	Agency          string
+	PublishedFrom   time.Time
+	PublishedBefore time.Time
+}
+
+// whereClause renders the scope as SQL conditions on pd, with placeholders numbered from
+// len(args)+1.
+func (s DocumentScope) whereClause(args []any) (string, []any) {
+	conds := []string{"TRUE"}
+	if s.Agency != "" {
+		args = append(args, s.Agency)
+		conds = append(conds, fmt.Sprintf("EXISTS (SELECT 1 FROM document_agencies da WHERE da.policy_document_id = pd.id AND da.name = $%d)", len(args)))
+	}
+	if !s.PublishedFrom.IsZero() {
+		args = append(args, s.PublishedFrom)
+		conds = append(conds, fmt.Sprintf("pd.published_at >= $%d", len(args)))
+	}
+	if !s.PublishedBefore.IsZero() {
+		args = append(args, s.PublishedBefore)
+		conds = append(conds, fmt.Sprintf("pd.published_at < $%d", len(args)))
+	}
+	return strings.Join(conds, " AND "), args
+}
+
+// CountInScope returns how many documents match scope and the highest matching id.
+// Passing maxID to ListIDsInScope pins a bulk run to the documents counted here.
+func (r *PolicyDocumentRepository) CountInScope(ctx context.Context, scope DocumentScope) (total int, maxID int64, err error) {
+	where, args := scope.whereClause(nil)
+	query := "SELECT COUNT(*), COALESCE(MAX(pd.id), 0) FROM policy_documents pd WHERE " + where
+	if err := r.db.QueryRowContext(ctx, query, args...).Scan(&total, &maxID); err != nil {
+		return 0, 0, fmt.Errorf("failed to count documents in scope: %w", err)
+	}
+	return total, maxID, nil
+}
+
+// ListIDsInScope returns up to limit ids of documents matching scope with afterID < id
+// <= maxID, in ascending order, for walking the scope in batches.
+func (r *PolicyDocumentRepository) ListIDsInScope(ctx context.Context, scope DocumentScope, afterID, maxID int64, limit int) ([]int64, error) {
+	where, args := scope.whereClause([]any{afterID, maxID, limit})
+	query := `
+		SELECT pd.id
+		FROM policy_documents pd
+		WHERE pd.id > $1 AND pd.id <= $2 AND ` + where + `
+		ORDER BY pd.id
+		LIMIT $3
+	`
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query document ids: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan document id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating document ids: %w", err)
+	}
+	return ids, nil
+}
+
+// normalizedTitle lowercases a title and collapses every run of punctuation and spaces
+// to one space, so "Air Quality Standards; Correction" and "Air quality standards:
+// correction" compare equal.
+const normalizedTitle = `btrim(regexp_replace(lower(%[1]s.title), '[^[:alnum:]]+', ' ', 'g'))`
+
+// SimilarDocument pairs a document with the earlier document it appears to copy.
+type SimilarDocument struct {
+	ID          int64
+	CanonicalID int64
+}
+
+// FindSimilar returns up to limit visible documents that look like another source's copy
+// of an earlier one, each paired with the earliest such document. The match is
+// deliberately narrow: a different source_key, the same published_at and document_type,
+// and the same non-empty normalized title. Documents already marked as duplicates are
+// neither matched nor used as the canonical copy.
+func (r *PolicyDocumentRepository) FindSimilar(ctx context.Context, limit int) ([]SimilarDocument, error) {
+	query := `
+		SELECT d.id, c.id
+		FROM policy_documents d
+		CROSS JOIN LATERAL (
+			SELECT o.id
+			FROM policy_documents o
+			WHERE o.published_at = d.published_at
+				AND o.id < d.id
+				AND o.source_key <> d.source_key
+				AND o.document_type IS NOT DISTINCT FROM d.document_type
+				AND NOT o.hidden
+				AND o.duplicate_of IS NULL
+				AND ` + fmt.Sprintf(normalizedTitle, "o") + ` = ` + fmt.Sprintf(normalizedTitle, "d") + `
+			ORDER BY o.id
+			LIMIT 1
+		) c
+		WHERE NOT d.hidden
+			AND d.duplicate_of IS NULL
+			AND ` + fmt.Sprintf(normalizedTitle, "d") + ` <> ''
+		ORDER BY d.id
+		LIMIT $1
+	`
+	rows, err := r.db.QueryContext(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query similar documents: %w", err)
+	}
+	defer rows.Close()
+
+	var out []SimilarDocument
+	for rows.Next() {
+		var s SimilarDocument
+		if err := rows.Scan(&s.ID, &s.CanonicalID); err != nil {
+			return nil, fmt.Errorf("failed to scan similar document: %w", err)
+		}
+		out = append(out, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating similar documents: %w", err)
+	}
+	return out, nil
+}
+
+// MarkDuplicate records id as a copy of canonicalID and hides it from the feed.
+func (r *PolicyDocumentRepository) MarkDuplicate(ctx context.Context, tx *sql.Tx, id, canonicalID int64) error {
+	query := "UPDATE policy_documents SET duplicate_of = $2, hidden = TRUE, updated_at = NOW() WHERE id = $1"
+	if _, err := tx.ExecContext(ctx, query, id, canonicalID); err != nil {
+		return fmt.Errorf("failed to mark policy_document %d duplicate of %d: %w", id, canonicalID, err)
+	}
+	return nil
+}
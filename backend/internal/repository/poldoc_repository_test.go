@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDocumentScopeWhereClause_Empty(t *testing.T) {
+	where, args := DocumentScope{}.whereClause(nil)
+	if where != "TRUE" || len(args) != 0 {
+		t.Fatalf("unexpected clause %q %v", where, args)
+	}
+}
+
+func TestDocumentScopeWhereClause_NumbersAfterExistingArgs(t *testing.T) {
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	before := from.AddDate(0, 1, 0)
+	scope := DocumentScope{Agency: "Food and Drug Administration", PublishedFrom: from, PublishedBefore: before}
+	where, args := scope.whereClause([]any{int64(0), int64(99), 100})
+
+	want := "TRUE AND EXISTS (SELECT 1 FROM document_agencies da WHERE da.policy_document_id = pd.id AND da.name = $4) AND pd.published_at >= $5 AND pd.published_at < $6"
+	if where != want {
+		t.Fatalf("where = %q, want %q", where, want)
+	}
+	if len(args) != 6 || args[3] != "Food and Drug Administration" || args[4] != from || args[5] != before {
+		t.Fatalf("unexpected args: %v", args)
+	}
+}
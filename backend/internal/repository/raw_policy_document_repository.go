@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/alex/opengov-go/internal/db"
@@ -39,6 +40,83 @@ func (r *RawPolicyDocumentRepository) Create(ctx context.Context, tx *sql.Tx, so
 	return ra > 0, nil
 }
 
+// RawPolicyDocumentInput is one upstream payload for CreateBatch.
+type RawPolicyDocumentInput struct {
+	ExternalID string
+	RawData    []byte
+}
+
+// rawBatchChunkSize keeps each multi-row INSERT well under Postgres's 65535 bind
+// parameter limit (3 per row).
+const rawBatchChunkSize = 500
+
+// CreateBatch inserts raw_policy_documents rows with one multi-row INSERT per chunk of
+// rawBatchChunkSize and no policy_document_id. inserted[i] reports whether rows[i] was
+// new; rows that already exist, or repeat an earlier row's external id, are skipped as
+// in Create.
+func (r *RawPolicyDocumentRepository) CreateBatch(ctx context.Context, tx *sql.Tx, sourceKey string, rows []RawPolicyDocumentInput, fetchedAt time.Time) (inserted []bool, err error) {
+	inserted = make([]bool, 0, len(rows))
+	for start := 0; start < len(rows); start += rawBatchChunkSize {
+		chunk := rows[start:min(start+rawBatchChunkSize, len(rows))]
+		query, args := buildRawBatchInsert(sourceKey, chunk, fetchedAt)
+
+		res, err := tx.QueryContext(ctx, query, args...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to batch insert raw entries: %w", err)
+		}
+		returned := map[string]bool{}
+		for res.Next() {
+			var externalID string
+			if err := res.Scan(&externalID); err != nil {
+				res.Close()
+				return nil, fmt.Errorf("failed to scan inserted raw entry: %w", err)
+			}
+			returned[externalID] = true
+		}
+		res.Close()
+		if err := res.Err(); err != nil {
+			return nil, fmt.Errorf("error iterating inserted raw entries: %w", err)
+		}
+
+		inserted = append(inserted, markInserted(chunk, returned)...)
+	}
+	return inserted, nil
+}
+
+// buildRawBatchInsert returns a single INSERT covering every row. source_key and
+// fetched_at are bound once and shared by all rows.
+func buildRawBatchInsert(sourceKey string, rows []RawPolicyDocumentInput, fetchedAt time.Time) (string, []interface{}) {
+	args := make([]interface{}, 0, 2+2*len(rows))
+	args = append(args, sourceKey, fetchedAt)
+
+	values := make([]string, len(rows))
+	for i, row := range rows {
+		args = append(args, row.ExternalID, row.RawData)
+		values[i] = fmt.Sprintf("($1, $%d, $%d, $2)", len(args)-1, len(args))
+	}
+
+	query := `
+		INSERT INTO raw_policy_documents (source_key, external_id, raw_data, fetched_at)
+		VALUES ` + strings.Join(values, ",\n\t\t\t") + `
+		ON CONFLICT (source_key, external_id) DO NOTHING
+		RETURNING external_id
+	`
+	return query, args
+}
+
+// markInserted maps the external ids RETURNING produced back to row positions. Only the
+// first row with a given id can have been inserted.
+func markInserted(rows []RawPolicyDocumentInput, returned map[string]bool) []bool {
+	out := make([]bool, len(rows))
+	for i, row := range rows {
+		if returned[row.ExternalID] {
+			out[i] = true
+			delete(returned, row.ExternalID)
+		}
+	}
+	return out
+}
+
 func (r *RawPolicyDocumentRepository) GetByID(ctx context.Context, id int64) (*domain.RawPolicyDocument, error) {
 	query := `
 		SELECT id, source_key, external_id, raw_data, fetched_at, policy_document_id, created_at
@@ -73,7 +151,7 @@ func (r *RawPolicyDocumentRepository) GetByDocumentID(ctx context.Context, polic
 	query := `
 		SELECT id, source_key, external_id, raw_data, fetched_at, policy_document_id, created_at
 		FROM raw_policy_documents WHERE policy_document_id = $1
-		ORDER BY created_at ASC
+		ORDER BY created_at ASC, id ASC
 	`
 	rows, err := r.db.QueryContext(ctx, query, policyDocID)
 	if err != nil {
@@ -126,7 +204,7 @@ func (r *RawPolicyDocumentRepository) ListUnlinked(ctx context.Context, limit in
 	query := `
 		SELECT id, source_key, external_id, raw_data, fetched_at, created_at
 		FROM raw_policy_documents
-		WHERE policy_document_id IS NULL
+		WHERE policy_document_id IS NULL AND error IS NULL
 		ORDER BY created_at ASC
 		LIMIT $1
 	`
@@ -150,6 +228,96 @@ func (r *RawPolicyDocumentRepository) ListUnlinked(ctx context.Context, limit in
 	return out, nil
 }
 
+// MarkFailed parks an unlinked row: it is skipped by ListUnlinked until Retry clears
+// the error.
+func (r *RawPolicyDocumentRepository) MarkFailed(ctx context.Context, id int64, msg string) error {
+	query := `
+		UPDATE raw_policy_documents
+		SET error = $2, attempts = attempts + 1
+		WHERE id = $1
+	`
+	if _, err := r.db.ExecContext(ctx, query, id, msg); err != nil {
+		return fmt.Errorf("failed to mark raw_policy_document %d failed: %w", id, err)
+	}
+	return nil
+}
+
+type FailedRawPolicyDocumentRow struct {
+	ID         int64
+	SourceKey  string
+	ExternalID string
+	Error      string
+	Attempts   int
+	FetchedAt  time.Time
+	CreatedAt  time.Time
+}
+
+// ListFailed returns rows parked by MarkFailed, oldest first.
+func (r *RawPolicyDocumentRepository) ListFailed(ctx context.Context, limit int) ([]FailedRawPolicyDocumentRow, error) {
+	query := `
+		SELECT id, source_key, external_id, error, attempts, fetched_at, created_at
+		FROM raw_policy_documents
+		WHERE error IS NOT NULL AND policy_document_id IS NULL
+		ORDER BY id ASC
+		LIMIT $1
+	`
+	rows, err := r.db.QueryContext(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query failed raw entries: %w", err)
+	}
+	defer rows.Close()
+
+	var out []FailedRawPolicyDocumentRow
+	for rows.Next() {
+		var row FailedRawPolicyDocumentRow
+		if err := rows.Scan(&row.ID, &row.SourceKey, &row.ExternalID, &row.Error, &row.Attempts, &row.FetchedAt, &row.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan failed raw entry: %w", err)
+		}
+		out = append(out, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating failed raw entries: %w", err)
+	}
+	return out, nil
+}
+
+// Retry clears a failed row's error so the next canonicalization picks it up again. It
+// reports false when id is not a failed, unlinked row.
+func (r *RawPolicyDocumentRepository) Retry(ctx context.Context, id int64) (bool, error) {
+	query := `
+		UPDATE raw_policy_documents
+		SET error = NULL
+		WHERE id = $1 AND error IS NOT NULL AND policy_document_id IS NULL
+	`
+	res, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to retry raw_policy_document %d: %w", id, err)
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to read rows affected: %w", err)
+	}
+	return ra > 0, nil
+}
+
+// RetryAll clears the error on every failed, unlinked row and returns how many it queued.
+func (r *RawPolicyDocumentRepository) RetryAll(ctx context.Context) (int64, error) {
+	query := `
+		UPDATE raw_policy_documents
+		SET error = NULL
+		WHERE error IS NOT NULL AND policy_document_id IS NULL
+	`
+	res, err := r.db.ExecContext(ctx, query)
+	if err != nil {
+		return 0, fmt.Errorf("failed to retry raw entries: %w", err)
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read rows affected: %w", err)
+	}
+	return ra, nil
+}
+
 func (r *RawPolicyDocumentRepository) LinkToPolicyDocument(ctx context.Context, tx *sql.Tx, rawID, policyDocID int64) error {
 	query := `
 		UPDATE raw_policy_documents
@@ -183,3 +351,17 @@ func (r *RawPolicyDocumentRepository) LinkToPolicyDocument(ctx context.Context,
 	}
 	return fmt.Errorf("raw_policy_document %d still unlinked after link attempt", rawID)
 }
+
+// RelinkPolicyDocument moves every raw row linked to fromID over to toID, e.g. when two
+// documents turn out to be the same policy.
+func (r *RawPolicyDocumentRepository) RelinkPolicyDocument(ctx context.Context, tx *sql.Tx, fromID, toID int64) (int64, error) {
+	res, err := tx.ExecContext(ctx, "UPDATE raw_policy_documents SET policy_document_id = $2 WHERE policy_document_id = $1", fromID, toID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to relink raw_policy_documents from %d to %d: %w", fromID, toID, err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read rows affected when relinking raw_policy_documents: %w", err)
+	}
+	return n, nil
+}
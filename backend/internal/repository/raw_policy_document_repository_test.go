@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func rawInputs(n int) []RawPolicyDocumentInput {
+	rows := make([]RawPolicyDocumentInput, n)
+	for i := range rows {
+		rows[i] = RawPolicyDocumentInput{ExternalID: fmt.Sprintf("2025-%05d", i), RawData: []byte(`{}`)}
+	}
+	return rows
+}
+
+func TestBuildRawBatchInsert_OneStatementFor50Documents(t *testing.T) {
+	fetchedAt := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+	query, args := buildRawBatchInsert("federal_register", rawInputs(50), fetchedAt)
+
+	if n := strings.Count(query, "INSERT INTO"); n != 1 {
+		t.Fatalf("query has %d INSERTs, want 1", n)
+	}
+	if n := strings.Count(query, "($1, $"); n != 50 {
+		t.Fatalf("query has %d value tuples, want 50", n)
+	}
+	if len(args) != 2+2*50 {
+		t.Fatalf("len(args) = %d, want %d", len(args), 2+2*50)
+	}
+	if !strings.Contains(query, "($1, $101, $102, $2)") {
+		t.Fatalf("last tuple not numbered as expected:\n%s", query)
+	}
+	if args[100] != "2025-00049" {
+		t.Fatalf("args[100] = %v, want the last external id", args[100])
+	}
+}
+
+func TestMarkInserted(t *testing.T) {
+	rows := []RawPolicyDocumentInput{{ExternalID: "a"}, {ExternalID: "b"}, {ExternalID: "a"}, {ExternalID: "c"}}
+	got := markInserted(rows, map[string]bool{"a": true, "c": true})
+	want := []bool{true, false, false, true}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("inserted = %v, want %v", got, want)
+	}
+}
+
+// BenchmarkBuildRawBatchInsert builds the single statement that replaces the 50
+// per-row INSERT round-trips a scrape page used to make.
+func BenchmarkBuildRawBatchInsert(b *testing.B) {
+	rows := rawInputs(50)
+	fetchedAt := time.Now()
+	for b.Loop() {
+		buildRawBatchInsert("federal_register", rows, fetchedAt)
+	}
+}
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/alex/opengov-go/internal/db"
+	"github.com/alex/opengov-go/internal/domain"
+)
+
+type SavedSearchRepository struct {
+	db *db.DB
+}
+
+func NewSavedSearchRepository(db *db.DB) *SavedSearchRepository {
+	return &SavedSearchRepository{db: db}
+}
+
+func (r *SavedSearchRepository) Create(ctx context.Context, s *domain.SavedSearch) error {
+	query := `
+		INSERT INTO saved_searches (user_id, name, filters)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at, updated_at
+	`
+	err := r.db.QueryRowContext(ctx, query, s.UserID, s.Name, s.Filters).Scan(&s.ID, &s.CreatedAt, &s.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert saved search: %w", err)
+	}
+	return nil
+}
+
+// GetByIDForUser returns nil when the saved search does not exist or belongs to another user.
+func (r *SavedSearchRepository) GetByIDForUser(ctx context.Context, userID, id int64) (*domain.SavedSearch, error) {
+	query := `
+		SELECT id, user_id, name, filters, created_at, updated_at
+		FROM saved_searches WHERE id = $1 AND user_id = $2
+	`
+	var s domain.SavedSearch
+	err := r.db.QueryRowContext(ctx, query, id, userID).Scan(
+		&s.ID, &s.UserID, &s.Name, &s.Filters, &s.CreatedAt, &s.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get saved search: %w", err)
+	}
+	return &s, nil
+}
+
+func (r *SavedSearchRepository) ListByUser(ctx context.Context, userID int64) ([]domain.SavedSearch, error) {
+	query := `
+		SELECT id, user_id, name, filters, created_at, updated_at
+		FROM saved_searches WHERE user_id = $1
+		ORDER BY created_at DESC
+	`
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query saved searches: %w", err)
+	}
+	defer rows.Close()
+
+	var out []domain.SavedSearch
+	for rows.Next() {
+		var s domain.SavedSearch
+		if err := rows.Scan(&s.ID, &s.UserID, &s.Name, &s.Filters, &s.CreatedAt, &s.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan saved search: %w", err)
+		}
+		out = append(out, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating saved searches: %w", err)
+	}
+	return out, nil
+}
+
+// Update returns false when no row owned by the user matched.
+func (r *SavedSearchRepository) Update(ctx context.Context, s *domain.SavedSearch) (bool, error) {
+	query := `
+		UPDATE saved_searches SET name = $1, filters = $2, updated_at = NOW()
+		WHERE id = $3 AND user_id = $4
+		RETURNING updated_at
+	`
+	err := r.db.QueryRowContext(ctx, query, s.Name, s.Filters, s.ID, s.UserID).Scan(&s.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to update saved search: %w", err)
+	}
+	return true, nil
+}
+
+// Delete returns false when no row owned by the user matched.
+func (r *SavedSearchRepository) Delete(ctx context.Context, userID, id int64) (bool, error) {
+	query := "DELETE FROM saved_searches WHERE id = $1 AND user_id = $2"
+	res, err := r.db.ExecContext(ctx, query, id, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete saved search: %w", err)
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to read rows affected: %w", err)
+	}
+	return ra > 0, nil
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/alex/opengov-go/internal/db"
+	"github.com/alex/opengov-go/internal/domain"
+)
+
+type ScrapeStateRepository struct {
+	db *db.DB
+}
+
+func NewScrapeStateRepository(db *db.DB) *ScrapeStateRepository {
+	return &ScrapeStateRepository{db: db}
+}
+
+// Get returns nil when sourceKey has never completed a scrape.
+func (r *ScrapeStateRepository) Get(ctx context.Context, sourceKey string) (*domain.ScrapeState, error) {
+	query := `
+		SELECT source_key, last_published_at, last_run_at, last_run_documents, created_at, updated_at
+		FROM scrape_state
+		WHERE source_key = $1
+	`
+	return scanScrapeState(r.db.QueryRowContext(ctx, query, sourceKey))
+}
+
+// GetLatestRun returns the source that most recently completed a scrape, or nil when
+// none has.
+func (r *ScrapeStateRepository) GetLatestRun(ctx context.Context) (*domain.ScrapeState, error) {
+	query := `
+		SELECT source_key, last_published_at, last_run_at, last_run_documents, created_at, updated_at
+		FROM scrape_state
+		ORDER BY last_run_at DESC
+		LIMIT 1
+	`
+	return scanScrapeState(r.db.QueryRowContext(ctx, query))
+}
+
+func scanScrapeState(row *sql.Row) (*domain.ScrapeState, error) {
+	var s domain.ScrapeState
+	var documents sql.NullInt64
+	err := row.Scan(&s.SourceKey, &s.LastPublishedAt, &s.LastRunAt, &documents, &s.CreatedAt, &s.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scrape state: %w", err)
+	}
+	if documents.Valid {
+		n := int(documents.Int64)
+		s.LastRunDocuments = &n
+	}
+	return &s, nil
+}
+
+// Set stores the high-water mark. Callers write it only after the rows it covers are
+// committed.
+func (r *ScrapeStateRepository) Set(ctx context.Context, s *domain.ScrapeState) error {
+	query := `
+		INSERT INTO scrape_state (source_key, last_published_at, last_run_at, last_run_documents)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (source_key) DO UPDATE SET
+			last_published_at = EXCLUDED.last_published_at,
+			last_run_at = EXCLUDED.last_run_at,
+			last_run_documents = EXCLUDED.last_run_documents,
+			updated_at = NOW()
+	`
+	if _, err := r.db.ExecContext(ctx, query, s.SourceKey, s.LastPublishedAt, s.LastRunAt, s.LastRunDocuments); err != nil {
+		return fmt.Errorf("failed to set scrape state: %w", err)
+	}
+	return nil
+}
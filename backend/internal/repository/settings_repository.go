@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/alex/opengov-go/internal/db"
+	"github.com/alex/opengov-go/internal/domain"
+)
+
+type SettingsRepository struct {
+	db *db.DB
+}
+
+func NewSettingsRepository(db *db.DB) *SettingsRepository {
+	return &SettingsRepository{db: db}
+}
+
+// Get returns nil when no setting is stored under key.
+func (r *SettingsRepository) Get(ctx context.Context, key string) (*domain.Setting, error) {
+	query := "SELECT id, key, value, created_at, updated_at FROM settings WHERE key = $1"
+	var s domain.Setting
+	err := r.db.QueryRowContext(ctx, query, key).Scan(&s.ID, &s.Key, &s.Value, &s.CreatedAt, &s.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get setting: %w", err)
+	}
+	return &s, nil
+}
+
+func (r *SettingsRepository) Set(ctx context.Context, key, value string) error {
+	query := `
+		INSERT INTO settings (key, value)
+		VALUES ($1, $2)
+		ON CONFLICT (key) DO UPDATE SET
+			value = EXCLUDED.value,
+			updated_at = NOW()
+	`
+	if _, err := r.db.ExecContext(ctx, query, key, value); err != nil {
+		return fmt.Errorf("failed to set setting: %w", err)
+	}
+	return nil
+}
+
+// ListByPrefix returns every setting whose key starts with prefix, ordered by key.
+func (r *SettingsRepository) ListByPrefix(ctx context.Context, prefix string) ([]domain.Setting, error) {
+	query := `
+		SELECT id, key, value, created_at, updated_at
+		FROM settings
+		WHERE starts_with(key, $1)
+		ORDER BY key
+	`
+	rows, err := r.db.QueryContext(ctx, query, prefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query settings: %w", err)
+	}
+	defer rows.Close()
+
+	var out []domain.Setting
+	for rows.Next() {
+		var s domain.Setting
+		if err := rows.Scan(&s.ID, &s.Key, &s.Value, &s.CreatedAt, &s.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan setting: %w", err)
+		}
+		out = append(out, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating settings: %w", err)
+	}
+	return out, nil
+}
@@ -34,6 +34,9 @@ func (r *UserRepository) GetByID(ctx context.Context, id int64) (*domain.User, e
 		&u.GoogleID, &u.Name, &u.PictureURL, &u.PoliticalLeaning, &u.State,
 		&u.CreatedAt, &u.UpdatedAt, &lastLoginAt,
 	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -149,6 +152,78 @@ func (r *UserRepository) VerifyPassword(user *domain.User, password string) bool
 	return err == nil
 }
 
+// List returns users newest first along with the total user count.
+func (r *UserRepository) List(ctx context.Context, limit, offset int) ([]domain.User, int, error) {
+	var total int
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM users").Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count users: %w", err)
+	}
+
+	query := `
+		SELECT id, email, hashed_password, is_active, is_superuser, is_verified,
+		       google_id, name, picture_url, political_leaning, state, created_at, updated_at, last_login_at
+		FROM users
+		ORDER BY created_at DESC, id DESC
+		LIMIT $1 OFFSET $2
+	`
+	rows, err := r.db.QueryContext(ctx, query, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query users: %w", err)
+	}
+	defer rows.Close()
+
+	var users []domain.User
+	for rows.Next() {
+		var u domain.User
+		if err := rows.Scan(
+			&u.ID, &u.Email, &u.HashedPassword, &u.IsActive, &u.IsSuperuser, &u.IsVerified,
+			&u.GoogleID, &u.Name, &u.PictureURL, &u.PoliticalLeaning, &u.State,
+			&u.CreatedAt, &u.UpdatedAt, &u.LastLoginAt,
+		); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan user: %w", err)
+		}
+		users = append(users, u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating users: %w", err)
+	}
+	return users, total, nil
+}
+
+// SetFlags updates the account flags that are non-nil and leaves the rest unchanged.
+// It reports whether the user exists.
+func (r *UserRepository) SetFlags(ctx context.Context, id int64, isActive, isSuperuser, isVerified *bool) (bool, error) {
+	query := `
+		UPDATE users SET
+			is_active = COALESCE($1, is_active),
+			is_superuser = COALESCE($2, is_superuser),
+			is_verified = COALESCE($3, is_verified),
+			updated_at = NOW()
+		WHERE id = $4
+	`
+	res, err := r.db.ExecContext(ctx, query, flagValue(isActive), flagValue(isSuperuser), flagValue(isVerified), id)
+	if err != nil {
+		return false, fmt.Errorf("failed to update user flags: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to read affected rows: %w", err)
+	}
+	return n > 0, nil
+}
+
+// flagValue maps an optional bool onto the 0/1 integer columns users stores flags in.
+func flagValue(b *bool) *int {
+	if b == nil {
+		return nil
+	}
+	v := 0
+	if *b {
+		v = 1
+	}
+	return &v
+}
+
 func (r *UserRepository) Update(ctx context.Context, user *domain.User) error {
 	query := `
 		UPDATE users SET
@@ -0,0 +1,65 @@
+package scrape
+
+import (
+	"context"
+	"time"
+
+	"github.com/alex/opengov-go/internal/client"
+	"github.com/alex/opengov-go/internal/constants"
+	"github.com/alex/opengov-go/internal/transport"
+)
+
+func init() {
+	Register(constants.SourceTypeCongress, func(deps Deps) PolicyDocumentScraper {
+		if deps.Config == nil || deps.Config.CongressAPIKey == "" {
+			return nil
+		}
+		return NewBillScraper(client.NewCongressClient(deps.Config, deps.Calls))
+	})
+}
+
+// BillScraper pulls bills from Congress.gov. Bills are listed by when they last changed,
+// so a bill shows up again whenever a new action is taken on it; the raw row stored the
+// first time is kept.
+type BillScraper struct {
+	client *client.CongressClient
+}
+
+func NewBillScraper(client *client.CongressClient) *BillScraper {
+	return &BillScraper{
+		client: client,
+	}
+}
+
+func (s *BillScraper) Scrape(ctx context.Context, since time.Time) ([]ScrapeResult, error) {
+	bills, err := s.client.Scrape(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+	return billResults(bills), nil
+}
+
+func (s *BillScraper) ScrapeRange(ctx context.Context, start, end time.Time) ([]ScrapeResult, error) {
+	bills, err := s.client.ScrapeRange(ctx, start, end)
+	if err != nil {
+		return nil, err
+	}
+	return billResults(bills), nil
+}
+
+func billResults(bills []client.CongressBillWithRaw) []ScrapeResult {
+	results := make([]ScrapeResult, len(bills))
+	for i, b := range bills {
+		results[i] = ScrapeResult{
+			PolicyDocument: transport.ScrapedPolicyDocument{
+				DocumentNumber:  b.Bill.ExternalID(),
+				Title:           b.Bill.Title,
+				Type:            b.Bill.DocumentType(),
+				HTMLURL:         b.Bill.WebURL(),
+				PublicationDate: b.Bill.PublicationDate(),
+			},
+			RawResult: b.RawJSON,
+		}
+	}
+	return results
+}
@@ -2,11 +2,19 @@ package scrape
 
 import (
 	"context"
+	"time"
 
 	"github.com/alex/opengov-go/internal/client"
+	"github.com/alex/opengov-go/internal/constants"
 	"github.com/alex/opengov-go/internal/transport"
 )
 
+func init() {
+	Register(constants.SourceTypeFederalRegister, func(deps Deps) PolicyDocumentScraper {
+		return NewFedregScraper(deps.FederalRegister)
+	})
+}
+
 type FedregScraper struct {
 	client *client.FederalRegisterClient
 }
@@ -17,12 +25,23 @@ func NewFedregScraper(client *client.FederalRegisterClient) *FedregScraper {
 	}
 }
 
-func (s *FedregScraper) Scrape(ctx context.Context, daysLookback int) ([]ScrapeResult, error) {
-	docs, err := s.client.Scrape(ctx, daysLookback)
+func (s *FedregScraper) Scrape(ctx context.Context, since time.Time) ([]ScrapeResult, error) {
+	docs, err := s.client.Scrape(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+	return scrapeResults(docs), nil
+}
+
+func (s *FedregScraper) ScrapeRange(ctx context.Context, start, end time.Time) ([]ScrapeResult, error) {
+	docs, err := s.client.ScrapeRange(ctx, start, end)
 	if err != nil {
 		return nil, err
 	}
+	return scrapeResults(docs), nil
+}
 
+func scrapeResults(docs []client.FederalRegisterDocumentWithRaw) []ScrapeResult {
 	results := make([]ScrapeResult, len(docs))
 	for i, frDoc := range docs {
 		doc := transport.ScrapedPolicyDocument{
@@ -42,7 +61,7 @@ func (s *FedregScraper) Scrape(ctx context.Context, daysLookback int) ([]ScrapeR
 			RawResult:      frDoc.RawJSON,
 		}
 	}
-	return results, nil
+	return results
 }
 
 func transformAgencies(frAgencies []client.FRAgency) []transport.ScrapedAgency {
@@ -0,0 +1,68 @@
+package scrape
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/alex/opengov-go/internal/client"
+	"github.com/alex/opengov-go/internal/config"
+)
+
+// Deps is what a scraper constructor may draw on. Clients shared with the rest of the
+// app are passed in so scrapers do not each open their own.
+type Deps struct {
+	Config          *config.Config
+	FederalRegister *client.FederalRegisterClient
+	// Calls records requests made by clients a constructor builds itself. May be nil.
+	Calls *client.CallLog
+}
+
+// Constructor builds a source's scraper. It returns nil to leave the source out of this
+// run, e.g. when its API key is not configured.
+type Constructor func(deps Deps) PolicyDocumentScraper
+
+// Source is a registered scraper and the source_key its documents are stored under.
+type Source struct {
+	Key     string
+	Scraper PolicyDocumentScraper
+}
+
+var (
+	registryMu sync.Mutex
+	registry   = map[string]Constructor{}
+)
+
+// Register makes a scraper available under key, which becomes the source_key of every
+// document it returns. It is meant to be called from the scraper's init function and
+// panics if key is empty or already registered.
+func Register(key string, ctor Constructor) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if key == "" || ctor == nil {
+		panic("scrape: Register called with an empty key or nil constructor")
+	}
+	if _, dup := registry[key]; dup {
+		panic(fmt.Sprintf("scrape: Register called twice for source %q", key))
+	}
+	registry[key] = ctor
+}
+
+// Sources builds every registered scraper that opts in, ordered by key so runs are
+// reproducible.
+func Sources(deps Deps) []Source {
+	registryMu.Lock()
+	ctors := maps.Clone(registry)
+	registryMu.Unlock()
+
+	keys := slices.Sorted(maps.Keys(ctors))
+	var sources []Source
+	for _, key := range keys {
+		if s := ctors[key](deps); s != nil {
+			sources = append(sources, Source{Key: key, Scraper: s})
+		}
+	}
+	return sources
+}
@@ -0,0 +1,49 @@
+package scrape
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alex/opengov-go/internal/constants"
+)
+
+type fakeScraper struct{}
+
+func (fakeScraper) Scrape(context.Context, time.Time) ([]ScrapeResult, error) { return nil, nil }
+
+func (fakeScraper) ScrapeRange(context.Context, time.Time, time.Time) ([]ScrapeResult, error) {
+	return nil, nil
+}
+
+func TestRegister_FakeScraper(t *testing.T) {
+	Register("aaa_fake", func(Deps) PolicyDocumentScraper { return fakeScraper{} })
+	Register("zzz_disabled", func(Deps) PolicyDocumentScraper { return nil })
+	t.Cleanup(func() {
+		registryMu.Lock()
+		defer registryMu.Unlock()
+		delete(registry, "aaa_fake")
+		delete(registry, "zzz_disabled")
+	})
+
+	sources := Sources(Deps{})
+	var keys []string
+	for _, s := range sources {
+		keys = append(keys, s.Key)
+	}
+	if len(keys) != 2 || keys[0] != "aaa_fake" || keys[1] != constants.SourceTypeFederalRegister {
+		t.Fatalf("source keys = %v, want [aaa_fake %s]", keys, constants.SourceTypeFederalRegister)
+	}
+	if _, ok := sources[0].Scraper.(fakeScraper); !ok {
+		t.Fatalf("aaa_fake scraper = %T, want fakeScraper", sources[0].Scraper)
+	}
+}
+
+func TestRegister_PanicsOnDuplicate(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("registering a source twice did not panic")
+		}
+	}()
+	Register(constants.SourceTypeFederalRegister, func(Deps) PolicyDocumentScraper { return fakeScraper{} })
+}
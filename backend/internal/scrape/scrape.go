@@ -2,6 +2,7 @@ package scrape
 
 import (
 	"context"
+	"time"
 
 	"github.com/alex/opengov-go/internal/transport"
 )
@@ -12,7 +13,10 @@ type ScrapeResult struct {
 	RawResult      []byte
 }
 
-// PolicyDocumentScraper defines the interface for document scrapers.
+// PolicyDocumentScraper defines the interface for document scrapers. Scrape returns
+// documents published on or after since's calendar day; ScrapeRange returns every
+// document published between start's and end's calendar days, both inclusive.
 type PolicyDocumentScraper interface {
-	Scrape(ctx context.Context, daysLookback int) ([]ScrapeResult, error)
+	Scrape(ctx context.Context, since time.Time) ([]ScrapeResult, error)
+	ScrapeRange(ctx context.Context, start, end time.Time) ([]ScrapeResult, error)
 }
@@ -0,0 +1,82 @@
+package services
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/alex/opengov-go/internal/client"
+	"github.com/alex/opengov-go/internal/domain"
+)
+
+// agencyLookup finds synced agency records. Both methods return nil when there is no
+// match.
+type agencyLookup interface {
+	GetByFRAgencyID(ctx context.Context, frAgencyID int64) (*domain.Agency, error)
+	GetBySlug(ctx context.Context, slug string) (*domain.Agency, error)
+}
+
+// agencyResolver maps agencies named in Federal Register payloads to agencies rows.
+// Answers, including misses, are remembered for the life of the resolver since a batch
+// of documents names only a handful of agencies.
+type agencyResolver struct {
+	lookup agencyLookup
+	cache  map[string]*int64
+}
+
+func newAgencyResolver(lookup agencyLookup) *agencyResolver {
+	return &agencyResolver{lookup: lookup, cache: map[string]*int64{}}
+}
+
+// resolve returns the agencies.id for a, matching its Federal Register id first and its
+// slug second, or nil when neither is known (the agency has not been synced yet, or the
+// payload names it only by raw_name).
+func (r *agencyResolver) resolve(ctx context.Context, a client.FRAgency) (*int64, error) {
+	key := strconv.Itoa(a.ID) + "/" + a.Slug
+	if id, ok := r.cache[key]; ok {
+		return id, nil
+	}
+
+	var agency *domain.Agency
+	var err error
+	if a.ID > 0 {
+		if agency, err = r.lookup.GetByFRAgencyID(ctx, int64(a.ID)); err != nil {
+			return nil, err
+		}
+	}
+	if agency == nil && a.Slug != "" {
+		if agency, err = r.lookup.GetBySlug(ctx, a.Slug); err != nil {
+			return nil, err
+		}
+	}
+
+	var id *int64
+	if agency != nil {
+		id = &agency.ID
+	}
+	r.cache[key] = id
+	return id, nil
+}
+
+// resolveAll returns the document agencies for the upstream list, in order. Agencies
+// without a name are dropped, as are repeats of a name already listed.
+func (r *agencyResolver) resolveAll(ctx context.Context, upstream []client.FRAgency) ([]domain.DocumentAgency, error) {
+	var out []domain.DocumentAgency
+	seen := map[string]bool{}
+	for _, a := range upstream {
+		name := a.Name
+		if name == "" {
+			name = a.RawName
+		}
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		id, err := r.resolve(ctx, a)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, domain.DocumentAgency{AgencyID: id, Name: name})
+	}
+	return out, nil
+}
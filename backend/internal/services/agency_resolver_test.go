@@ -0,0 +1,97 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/alex/opengov-go/internal/client"
+	"github.com/alex/opengov-go/internal/domain"
+)
+
+type fakeAgencyLookup struct {
+	byFRID map[int64]int64
+	bySlug map[string]int64
+	calls  int
+}
+
+func (f *fakeAgencyLookup) GetByFRAgencyID(_ context.Context, frAgencyID int64) (*domain.Agency, error) {
+	f.calls++
+	if id, ok := f.byFRID[frAgencyID]; ok {
+		return &domain.Agency{ID: id}, nil
+	}
+	return nil, nil
+}
+
+func (f *fakeAgencyLookup) GetBySlug(_ context.Context, slug string) (*domain.Agency, error) {
+	f.calls++
+	if id, ok := f.bySlug[slug]; ok {
+		return &domain.Agency{ID: id}, nil
+	}
+	return nil, nil
+}
+
+func TestAgencyResolver(t *testing.T) {
+	ctx := context.Background()
+	lookup := &fakeAgencyLookup{
+		byFRID: map[int64]int64{145: 1},
+		bySlug: map[string]int64{"energy-department": 2},
+	}
+	r := newAgencyResolver(lookup)
+
+	cases := []struct {
+		name   string
+		agency client.FRAgency
+		want   int64 // 0 means unresolved
+	}{
+		{"by id", client.FRAgency{ID: 145, Slug: "environmental-protection-agency"}, 1},
+		{"slug fallback", client.FRAgency{ID: 999, Slug: "energy-department"}, 2},
+		{"slug only", client.FRAgency{Slug: "energy-department"}, 2},
+		{"unknown", client.FRAgency{ID: 998, Slug: "unknown"}, 0},
+		{"raw name only", client.FRAgency{RawName: "OFFICE OF SOMETHING"}, 0},
+	}
+	for _, tc := range cases {
+		got, err := r.resolve(ctx, tc.agency)
+		if err != nil {
+			t.Fatalf("%s: resolve() error: %v", tc.name, err)
+		}
+		if (got == nil) != (tc.want == 0) || (got != nil && *got != tc.want) {
+			t.Errorf("%s: resolve() = %v, want %d", tc.name, got, tc.want)
+		}
+	}
+
+	// Hits and misses are both remembered.
+	calls := lookup.calls
+	for _, tc := range cases {
+		r.resolve(ctx, tc.agency)
+	}
+	if lookup.calls != calls {
+		t.Fatalf("lookups after warm-up = %d, want 0", lookup.calls-calls)
+	}
+}
+
+func TestAgencyResolver_ResolveAll(t *testing.T) {
+	r := newAgencyResolver(&fakeAgencyLookup{byFRID: map[int64]int64{145: 1, 136: 3}})
+
+	got, err := r.resolveAll(context.Background(), []client.FRAgency{
+		{ID: 145, Name: "Environmental Protection Agency"},
+		{ID: 136, Name: "Energy Department"},
+		{RawName: "OFFICE OF SOMETHING"},
+		{ID: 145, Name: "Environmental Protection Agency"},
+		{},
+	})
+	if err != nil {
+		t.Fatalf("resolveAll() error: %v", err)
+	}
+
+	var names []string
+	for _, a := range got {
+		names = append(names, a.Name)
+	}
+	if want := []string{"Environmental Protection Agency", "Energy Department", "OFFICE OF SOMETHING"}; !slices.Equal(names, want) {
+		t.Fatalf("names = %v, want %v", names, want)
+	}
+	if got[0].AgencyID == nil || *got[0].AgencyID != 1 || got[1].AgencyID == nil || *got[1].AgencyID != 3 || got[2].AgencyID != nil {
+		t.Fatalf("agency ids = %v, %v, %v", got[0].AgencyID, got[1].AgencyID, got[2].AgencyID)
+	}
+}
@@ -86,3 +86,65 @@ func (s *AgencySyncService) SyncAgencies(ctx context.Context) (int, error) {
 	log.Printf("Synced %d agencies", count)
 	return count, nil
 }
+
+// AgencyChange is an agency present both upstream and locally whose tracked fields differ.
+type AgencyChange struct {
+	Local    domain.Agency
+	Upstream client.FRAgency
+	Fields   []string // "name" and/or "parent_id"
+}
+
+// AgencyDiff describes what a sync would do to the local agencies table.
+type AgencyDiff struct {
+	Added   []client.FRAgency // upstream only
+	Removed []domain.Agency   // local only
+	Changed []AgencyChange
+}
+
+// DiffAgencies matches agencies on their Federal Register id and compares name and parent.
+// Added and Changed follow upstream order; Removed follows local order.
+func DiffAgencies(upstream []client.FRAgency, local []domain.Agency) AgencyDiff {
+	byFRID := make(map[int64]domain.Agency, len(local))
+	for _, a := range local {
+		byFRID[a.FRAgencyID] = a
+	}
+
+	var diff AgencyDiff
+	seen := make(map[int64]bool, len(upstream))
+	for _, up := range upstream {
+		id := int64(up.ID)
+		seen[id] = true
+
+		loc, ok := byFRID[id]
+		if !ok {
+			diff.Added = append(diff.Added, up)
+			continue
+		}
+
+		var fields []string
+		if loc.Name != up.Name {
+			fields = append(fields, "name")
+		}
+		if !sameParent(loc.ParentID, up.ParentID) {
+			fields = append(fields, "parent_id")
+		}
+		if len(fields) > 0 {
+			diff.Changed = append(diff.Changed, AgencyChange{Local: loc, Upstream: up, Fields: fields})
+		}
+	}
+
+	for _, a := range local {
+		if !seen[a.FRAgencyID] {
+			diff.Removed = append(diff.Removed, a)
+		}
+	}
+
+	return diff
+}
+
+func sameParent(local *int64, upstream *int) bool {
+	if local == nil || upstream == nil {
+		return local == nil && upstream == nil
+	}
+	return *local == int64(*upstream)
+}
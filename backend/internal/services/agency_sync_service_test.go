@@ -0,0 +1,57 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/alex/opengov-go/internal/client"
+	"github.com/alex/opengov-go/internal/domain"
+)
+
+func TestDiffAgencies(t *testing.T) {
+	intPtr := func(v int) *int { return &v }
+	int64Ptr := func(v int64) *int64 { return &v }
+
+	upstream := []client.FRAgency{
+		{ID: 1, Name: "Agriculture Department"},
+		{ID: 2, Name: "Forest Service", ParentID: intPtr(1)},
+		{ID: 3, Name: "Food Safety and Inspection Service (renamed)", ParentID: intPtr(1)},
+		{ID: 4, Name: "Rural Utilities Service", ParentID: intPtr(1)},
+		{ID: 6, Name: "New Office"},
+	}
+	local := []domain.Agency{
+		{ID: 10, FRAgencyID: 1, Name: "Agriculture Department"},
+		{ID: 11, FRAgencyID: 2, Name: "Forest Service", ParentID: int64Ptr(1)},
+		{ID: 12, FRAgencyID: 3, Name: "Food Safety and Inspection Service", ParentID: int64Ptr(1)},
+		{ID: 13, FRAgencyID: 4, Name: "Rural Utilities Service"},
+		{ID: 15, FRAgencyID: 5, Name: "Defunct Commission"},
+	}
+
+	diff := DiffAgencies(upstream, local)
+
+	if len(diff.Added) != 1 || diff.Added[0].ID != 6 {
+		t.Fatalf("Added = %+v, want only FR id 6", diff.Added)
+	}
+	if len(diff.Removed) != 1 || diff.Removed[0].FRAgencyID != 5 {
+		t.Fatalf("Removed = %+v, want only FR id 5", diff.Removed)
+	}
+	if len(diff.Changed) != 2 {
+		t.Fatalf("Changed = %+v, want 2 entries", diff.Changed)
+	}
+	if diff.Changed[0].Local.FRAgencyID != 3 || !reflect.DeepEqual(diff.Changed[0].Fields, []string{"name"}) {
+		t.Fatalf("Changed[0] = %+v, want FR id 3 name change", diff.Changed[0])
+	}
+	if diff.Changed[1].Local.FRAgencyID != 4 || !reflect.DeepEqual(diff.Changed[1].Fields, []string{"parent_id"}) {
+		t.Fatalf("Changed[1] = %+v, want FR id 4 parent change", diff.Changed[1])
+	}
+}
+
+func TestDiffAgencies_NoChanges(t *testing.T) {
+	upstream := []client.FRAgency{{ID: 1, Name: "Agriculture Department"}}
+	local := []domain.Agency{{FRAgencyID: 1, Name: "Agriculture Department"}}
+
+	diff := DiffAgencies(upstream, local)
+	if len(diff.Added) != 0 || len(diff.Removed) != 0 || len(diff.Changed) != 0 {
+		t.Fatalf("expected empty diff, got %+v", diff)
+	}
+}
@@ -0,0 +1,117 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alex/opengov-go/internal/domain"
+)
+
+// AnalysisPromptSettingKey is the settings key holding an admin-supplied analysis prompt.
+const AnalysisPromptSettingKey = "analysis_prompt"
+
+// analysisPromptCacheTTL bounds how long a prompt read from settings is reused.
+const analysisPromptCacheTTL = time.Minute
+
+var ErrInvalidAnalysisPrompt = errors.New("analysis prompt must contain exactly three %s placeholders (title, agency, abstract)")
+
+// SettingsReader is the read side of the settings store.
+type SettingsReader interface {
+	Get(ctx context.Context, key string) (*domain.Setting, error)
+}
+
+// DefaultAnalysisPrompt is used when no valid prompt is stored in settings. Its %s
+// placeholders are filled with the title, agency and abstract, in that order.
+const DefaultAnalysisPrompt = `You are an expert at analyzing government documents and Federal Register entries. Analyze the following document and provide a structured analysis.
+
+Document Title: %s
+Agency: %s
+Abstract: %s
+
+Provide your analysis as a JSON object with exactly these fields:
+{
+  "summary": "A short, punchy summary (1-2 sentences max, under 280 chars) that captures the essence and why it matters to everyday Americans. Be clear, accessible, avoid jargon.",
+  "keypoints": ["Key point 1", "Key point 2", "Key point 3"],
+  "impact_score": "low|medium|high",
+  "political_score": <number from -100 to 100>,
+  "categories": ["health", "environment"]
+}
+
+Guidelines:
+- summary: Focus on human impact, make it engaging and viral-worthy
+- keypoints: 3-5 bullet points of the most important takeaways
+- impact_score: "low" = routine bureaucratic update, "medium" = noteworthy policy change, "high" = major news that affects many Americans
+- political_score: -100 = strongly left/progressive, 0 = neutral/bipartisan, 100 = strongly right/conservative
+- categories: 1-3 topics the document is about, chosen only from: agriculture, defense, education, energy, environment, finance, health, housing, immigration, justice, labor, science, taxes, technology, trade, transportation
+
+Return ONLY the JSON object, no other text.`
+
+// ValidateAnalysisPrompt checks that tmpl formats cleanly with exactly the three
+// arguments Analyze passes to it.
+func ValidateAnalysisPrompt(tmpl string) error {
+	args := []interface{}{"\x00title\x00", "\x00agency\x00", "\x00abstract\x00"}
+	out := fmt.Sprintf(tmpl, args...)
+	if strings.Contains(out, "%!") {
+		return ErrInvalidAnalysisPrompt
+	}
+	for _, a := range args {
+		if strings.Count(out, a.(string)) != 1 {
+			return ErrInvalidAnalysisPrompt
+		}
+	}
+	return nil
+}
+
+// analysisPromptCache serves the analysis prompt template, refreshing it from settings
+// at most once per ttl. A nil settings reader always yields the default.
+type analysisPromptCache struct {
+	settings SettingsReader
+	ttl      time.Duration
+
+	mu        sync.Mutex
+	prompt    string
+	fetchedAt time.Time
+}
+
+func newAnalysisPromptCache(settings SettingsReader) *analysisPromptCache {
+	return &analysisPromptCache{settings: settings, ttl: analysisPromptCacheTTL}
+}
+
+// template returns the stored prompt. Missing, invalid or unreadable settings fall back
+// to DefaultAnalysisPrompt.
+func (c *analysisPromptCache) template(ctx context.Context) string {
+	if c.settings == nil {
+		return DefaultAnalysisPrompt
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.prompt != "" && time.Since(c.fetchedAt) < c.ttl {
+		return c.prompt
+	}
+
+	setting, err := c.settings.Get(ctx, AnalysisPromptSettingKey)
+	if err != nil {
+		log.Printf("Failed to load analysis prompt, using default: %v", err)
+		return DefaultAnalysisPrompt
+	}
+
+	prompt := DefaultAnalysisPrompt
+	if setting != nil {
+		if err := ValidateAnalysisPrompt(setting.Value); err != nil {
+			log.Printf("Stored analysis prompt is invalid, using default: %v", err)
+		} else {
+			prompt = setting.Value
+		}
+	}
+
+	c.prompt = prompt
+	c.fetchedAt = time.Now()
+	return prompt
+}
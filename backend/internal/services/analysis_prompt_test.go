@@ -0,0 +1,100 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alex/opengov-go/internal/domain"
+)
+
+type fakeSettings struct {
+	value *string
+	err   error
+	calls int
+}
+
+func (f *fakeSettings) Get(_ context.Context, key string) (*domain.Setting, error) {
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	if f.value == nil {
+		return nil, nil
+	}
+	return &domain.Setting{Key: key, Value: *f.value}, nil
+}
+
+func TestValidateAnalysisPrompt(t *testing.T) {
+	tests := []struct {
+		name    string
+		tmpl    string
+		wantErr bool
+	}{
+		{"default", DefaultAnalysisPrompt, false},
+		{"minimal", "T=%s A=%s B=%s", false},
+		{"escaped percent", "100%% sure: %s %s %s", false},
+		{"too few", "T=%s A=%s", true},
+		{"too many", "%s %s %s %s", true},
+		{"wrong verb", "%s %d %s", true},
+		{"none", "just text", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateAnalysisPrompt(tt.tmpl)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateAnalysisPrompt(%q) error = %v, wantErr %v", tt.tmpl, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAnalysisPromptCache(t *testing.T) {
+	custom := "Custom %s / %s / %s"
+	invalid := "Broken %s"
+
+	tests := []struct {
+		name     string
+		settings *fakeSettings
+		want     string
+	}{
+		{"stored", &fakeSettings{value: &custom}, custom},
+		{"missing", &fakeSettings{}, DefaultAnalysisPrompt},
+		{"invalid", &fakeSettings{value: &invalid}, DefaultAnalysisPrompt},
+		{"error", &fakeSettings{err: errors.New("db down")}, DefaultAnalysisPrompt},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newAnalysisPromptCache(tt.settings)
+			if got := c.template(context.Background()); got != tt.want {
+				t.Fatalf("template() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAnalysisPromptCache_RefreshesAfterTTL(t *testing.T) {
+	custom := "Custom %s / %s / %s"
+	settings := &fakeSettings{value: &custom}
+	c := newAnalysisPromptCache(settings)
+
+	c.template(context.Background())
+	c.template(context.Background())
+	if settings.calls != 1 {
+		t.Fatalf("settings read %d times within TTL, want 1", settings.calls)
+	}
+
+	updated := "Updated %s / %s / %s"
+	settings.value = &updated
+	c.fetchedAt = time.Now().Add(-2 * c.ttl)
+
+	if got := c.template(context.Background()); got != updated {
+		t.Fatalf("template() after TTL = %q, want %q", got, updated)
+	}
+	if settings.calls != 2 {
+		t.Fatalf("settings read %d times after TTL, want 2", settings.calls)
+	}
+}
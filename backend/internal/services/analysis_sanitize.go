@@ -0,0 +1,112 @@
+package services
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Bounds on the keypoints kept from a model response.
+const (
+	minKeypoints     = 3
+	maxKeypoints     = 5
+	maxKeypointChars = 200
+)
+
+// sanitizeKeypoints trims the model's keypoints, drops empty and duplicate ones, keeps at
+// most maxKeypoints and shortens any longer than maxKeypointChars. When fewer than
+// minKeypoints remain, sentences from abstract fill the gap; with no abstract the result
+// may still be short.
+func sanitizeKeypoints(keypoints []string, abstract string) []string {
+	out := make([]string, 0, maxKeypoints)
+	add := func(kp string) {
+		kp = truncateAtWord(strings.Join(strings.Fields(kp), " "), maxKeypointChars)
+		if kp == "" || len(out) == maxKeypoints {
+			return
+		}
+		for _, existing := range out {
+			if strings.EqualFold(existing, kp) {
+				return
+			}
+		}
+		out = append(out, kp)
+	}
+
+	for _, kp := range keypoints {
+		add(kp)
+	}
+	if len(out) < minKeypoints {
+		for _, sentence := range splitSentences(abstract) {
+			if len(out) == minKeypoints {
+				break
+			}
+			add(sentence)
+		}
+	}
+	return out
+}
+
+// truncateSummary shortens s to at most max characters, keeping as many whole sentences
+// as fit. When even the first sentence is too long it is cut at a word instead.
+func truncateSummary(s string, max int) string {
+	if utf8.RuneCountInString(s) <= max {
+		return s
+	}
+	var kept string
+	for _, sentence := range splitSentences(s) {
+		next := sentence
+		if kept != "" {
+			next = kept + " " + sentence
+		}
+		if utf8.RuneCountInString(next) > max {
+			break
+		}
+		kept = next
+	}
+	if kept != "" {
+		return kept
+	}
+	return truncateAtWord(s, max)
+}
+
+// splitSentences splits text after each '.', '!' or '?' that is followed by whitespace
+// or the end of the text.
+func splitSentences(text string) []string {
+	var out []string
+	start := 0
+	for i, r := range text {
+		if r != '.' && r != '!' && r != '?' {
+			continue
+		}
+		next := i + utf8.RuneLen(r)
+		if next < len(text) {
+			if nr, _ := utf8.DecodeRuneInString(text[next:]); !unicode.IsSpace(nr) {
+				continue
+			}
+		}
+		if sentence := strings.TrimSpace(text[start:next]); sentence != "" {
+			out = append(out, sentence)
+		}
+		start = next
+	}
+	if rest := strings.TrimSpace(text[start:]); rest != "" {
+		out = append(out, rest)
+	}
+	return out
+}
+
+// truncateAtWord shortens s to at most max characters, cutting at the last space and
+// ending with an ellipsis. A single word longer than max is cut mid-word.
+func truncateAtWord(s string, max int) string {
+	r := []rune(s)
+	if len(r) <= max {
+		return s
+	}
+	cut := string(r[:max-1])
+	if i := strings.LastIndexFunc(cut, unicode.IsSpace); i > 0 {
+		cut = cut[:i]
+	}
+	return strings.TrimRightFunc(cut, func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsPunct(r)
+	}) + "…"
+}
@@ -0,0 +1,73 @@
+package services
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestSanitizeKeypoints(t *testing.T) {
+	abstract := "The rule sets limits. It takes effect in May! Comments are due soon? Extra sentence."
+
+	tests := []struct {
+		name      string
+		keypoints []string
+		abstract  string
+		want      []string
+	}{
+		{
+			name:     "empty uses abstract",
+			abstract: abstract,
+			want:     []string{"The rule sets limits.", "It takes effect in May!", "Comments are due soon?"},
+		},
+		{
+			name:      "whitespace only",
+			keypoints: []string{"  ", "\n\t", " Real point "},
+			abstract:  abstract,
+			want:      []string{"Real point", "The rule sets limits.", "It takes effect in May!"},
+		},
+		{
+			name:      "too many",
+			keypoints: []string{"a", "b", "c", "d", "e", "f", "g"},
+			abstract:  abstract,
+			want:      []string{"a", "b", "c", "d", "e"},
+		},
+		{
+			name:      "duplicates dropped",
+			keypoints: []string{"Same", "same", "Other"},
+			want:      []string{"Same", "Other"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sanitizeKeypoints(tt.keypoints, tt.abstract); !slices.Equal(got, tt.want) {
+				t.Fatalf("sanitizeKeypoints() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSanitizeKeypoints_TruncatesParagraph(t *testing.T) {
+	paragraph := strings.Repeat("word ", 100)
+	got := sanitizeKeypoints([]string{paragraph, "b", "c"}, "")
+	if n := utf8.RuneCountInString(got[0]); n > maxKeypointChars {
+		t.Fatalf("keypoint is %d characters, max %d", n, maxKeypointChars)
+	}
+	if !strings.HasSuffix(got[0], "word…") {
+		t.Fatalf("keypoint should end on a whole word: %q", got[0])
+	}
+}
+
+func TestTruncateSummary(t *testing.T) {
+	if got := truncateSummary("Short.", 280); got != "Short." {
+		t.Fatalf("short summary changed: %q", got)
+	}
+	if got := truncateSummary("First one. Second one is longer.", 20); got != "First one." {
+		t.Fatalf("got %q, want the first sentence", got)
+	}
+	got := truncateSummary(strings.Repeat("word ", 80), 50)
+	if utf8.RuneCountInString(got) > 50 || !strings.HasSuffix(got, "word…") {
+		t.Fatalf("got %q, want a word-boundary cut", got)
+	}
+}
@@ -13,10 +13,23 @@ import (
 	"github.com/alex/opengov-go/internal/repository"
 )
 
+var (
+	ErrInvalidCredentials = errors.New("invalid credentials")
+	ErrUserInactive       = errors.New("user is inactive")
+)
+
+// userStore is the part of repository.UserRepository that AuthService needs.
+type userStore interface {
+	GetByID(ctx context.Context, id int64) (*domain.User, error)
+	GetByEmail(ctx context.Context, email string) (*domain.User, error)
+	VerifyPassword(user *domain.User, password string) bool
+	UpdateLoginTime(ctx context.Context, id int64) error
+}
+
 type AuthService struct {
 	jwtSecret string
 	jwtExpiry time.Duration
-	userRepo  *repository.UserRepository
+	userRepo  userStore
 }
 
 type Claims struct {
@@ -72,20 +85,19 @@ func (s *AuthService) ValidateToken(tokenString string) (*Claims, error) {
 	return claims, nil
 }
 
+// Authenticate checks an email and password. It returns ErrInvalidCredentials for an
+// unknown email or wrong password and ErrUserInactive for a deactivated account.
 func (s *AuthService) Authenticate(ctx context.Context, email, password string) (*domain.User, error) {
 	user, err := s.userRepo.GetByEmail(ctx, email)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
-	if user == nil {
-		return nil, errors.New("user not found")
+	if user == nil || !s.userRepo.VerifyPassword(user, password) {
+		return nil, ErrInvalidCredentials
 	}
+	// Checked after the password so the response doesn't reveal which accounts are disabled.
 	if !user.GetIsActive() {
-		return nil, errors.New("user is inactive")
-	}
-
-	if !s.userRepo.VerifyPassword(user, password) {
-		return nil, errors.New("invalid password")
+		return nil, ErrUserInactive
 	}
 
 	if err := s.userRepo.UpdateLoginTime(ctx, user.ID); err != nil {
@@ -0,0 +1,74 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/alex/opengov-go/internal/domain"
+)
+
+type fakeUserStore struct {
+	users     map[string]*domain.User
+	loginTime map[int64]bool
+}
+
+func (f *fakeUserStore) GetByID(_ context.Context, id int64) (*domain.User, error) {
+	for _, u := range f.users {
+		if u.ID == id {
+			return u, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakeUserStore) GetByEmail(_ context.Context, email string) (*domain.User, error) {
+	return f.users[email], nil
+}
+
+func (f *fakeUserStore) VerifyPassword(user *domain.User, password string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(user.HashedPassword), []byte(password)) == nil
+}
+
+func (f *fakeUserStore) UpdateLoginTime(_ context.Context, id int64) error {
+	f.loginTime[id] = true
+	return nil
+}
+
+func TestAuthenticate_RejectsInactiveUser(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("correct-horse"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := &fakeUserStore{
+		users: map[string]*domain.User{
+			"active@example.com":   {ID: 1, Email: "active@example.com", HashedPassword: string(hash), IsActive: 1},
+			"inactive@example.com": {ID: 2, Email: "inactive@example.com", HashedPassword: string(hash), IsActive: 0},
+		},
+		loginTime: map[int64]bool{},
+	}
+	s := &AuthService{userRepo: store}
+	ctx := context.Background()
+
+	if _, err := s.Authenticate(ctx, "inactive@example.com", "correct-horse"); !errors.Is(err, ErrUserInactive) {
+		t.Fatalf("inactive user error = %v, want %v", err, ErrUserInactive)
+	}
+	if store.loginTime[2] {
+		t.Fatal("login time recorded for inactive user")
+	}
+
+	// A wrong password must not reveal that the account is disabled.
+	if _, err := s.Authenticate(ctx, "inactive@example.com", "wrong"); !errors.Is(err, ErrInvalidCredentials) {
+		t.Fatalf("wrong password error = %v, want %v", err, ErrInvalidCredentials)
+	}
+	if _, err := s.Authenticate(ctx, "missing@example.com", "correct-horse"); !errors.Is(err, ErrInvalidCredentials) {
+		t.Fatalf("unknown email error = %v, want %v", err, ErrInvalidCredentials)
+	}
+
+	user, err := s.Authenticate(ctx, "active@example.com", "correct-horse")
+	if err != nil || user.ID != 1 {
+		t.Fatalf("active user: user = %v, err = %v", user, err)
+	}
+}
@@ -0,0 +1,83 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/alex/opengov-go/internal/constants"
+)
+
+var (
+	ErrBackfillRunning       = errors.New("backfill already running")
+	ErrBackfillRangeInvalid  = errors.New("backfill end date is before start date")
+	ErrBackfillRangeTooLong  = errors.New("backfill range too long")
+	ErrBackfillRangeInFuture = errors.New("backfill range ends in the future")
+)
+
+// BackfillRunner runs historical scrapes in the background, one at a time, recording
+// each as a backfill job.
+type BackfillRunner struct {
+	maxDays int
+	jobs    *JobRunner
+	run     func(ctx context.Context, start, end time.Time) (processed, skipped int, err error)
+	now     func() time.Time
+
+	mu      sync.Mutex
+	running bool
+}
+
+func NewBackfillRunner(maxDays int, jobs *JobRunner, run func(ctx context.Context, start, end time.Time) (processed, skipped int, err error)) *BackfillRunner {
+	return &BackfillRunner{
+		maxDays: maxDays,
+		jobs:    jobs,
+		run:     run,
+		now:     time.Now,
+	}
+}
+
+// Start validates the range and launches the backfill, returning its job id.
+func (r *BackfillRunner) Start(ctx context.Context, start, end time.Time) (id int64, err error) {
+	if err := validateBackfillRange(start, end, r.maxDays, r.now()); err != nil {
+		return 0, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.running {
+		return 0, ErrBackfillRunning
+	}
+
+	job, err := r.jobs.Start(ctx, constants.JobTypeBackfill, func(ctx context.Context, report func(processed, skipped int)) error {
+		defer func() {
+			r.mu.Lock()
+			r.running = false
+			r.mu.Unlock()
+		}()
+		processed, skipped, err := r.run(ctx, start, end)
+		report(processed, skipped)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	r.running = true
+	return job.ID, nil
+}
+
+// validateBackfillRange checks an inclusive range of publication days. maxDays counts
+// both ends, so a one-day backfill has start == end.
+func validateBackfillRange(start, end time.Time, maxDays int, now time.Time) error {
+	if end.Before(start) {
+		return ErrBackfillRangeInvalid
+	}
+	if end.After(now) {
+		return ErrBackfillRangeInFuture
+	}
+	if days := int(end.Sub(start).Hours()/24) + 1; days > maxDays {
+		return ErrBackfillRangeTooLong
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alex/opengov-go/internal/constants"
+)
+
+func TestValidateBackfillRange(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, 1, d, 0, 0, 0, 0, time.UTC) }
+	now := day(31)
+
+	tests := []struct {
+		name       string
+		start, end time.Time
+		want       error
+	}{
+		{"single day", day(5), day(5), nil},
+		{"exactly max days", day(1), day(10), nil},
+		{"one day over max", day(1), day(11), ErrBackfillRangeTooLong},
+		{"end before start", day(5), day(4), ErrBackfillRangeInvalid},
+		{"ends in the future", day(30), now.AddDate(0, 0, 1), ErrBackfillRangeInFuture},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := validateBackfillRange(tc.start, tc.end, 10, now); !errors.Is(err, tc.want) {
+				t.Fatalf("validateBackfillRange() = %v, want %v", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestBackfillRunner_RecordsJobAndRejectsWhileRunning(t *testing.T) {
+	release := make(chan struct{})
+	store := &fakeJobStore{}
+	r := NewBackfillRunner(31, NewJobRunner(t.Context(), constants.JobOriginAPI, store), func(context.Context, time.Time, time.Time) (int, int, error) {
+		<-release
+		return 5, 1, nil
+	})
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	id, err := r.Start(t.Context(), start, start)
+	if err != nil || id != 1 {
+		t.Fatalf("Start() = %d, %v; want 1, nil", id, err)
+	}
+	if _, err := r.Start(t.Context(), start, start); !errors.Is(err, ErrBackfillRunning) {
+		t.Fatalf("second Start() error = %v, want ErrBackfillRunning", err)
+	}
+	close(release)
+
+	deadline := time.Now().Add(time.Second)
+	for store.job(id).Status != constants.JobStatusSucceeded {
+		if time.Now().After(deadline) {
+			t.Fatalf("job = %+v, want succeeded", store.job(id))
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if j := store.job(id); j.Type != constants.JobTypeBackfill || j.Processed != 5 || j.Skipped != 1 {
+		t.Fatalf("job = %+v, want backfill with 5 processed and 1 skipped", j)
+	}
+}
@@ -0,0 +1,62 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/alex/opengov-go/internal/client"
+	"github.com/alex/opengov-go/internal/domain"
+	"github.com/alex/opengov-go/internal/repository"
+	"github.com/alex/opengov-go/internal/timeformat"
+)
+
+// billChambers names the chamber a bill originated in, which stands in for the agency
+// of a regulation.
+var billChambers = map[string]string{
+	"House":  "House of Representatives",
+	"Senate": "Senate",
+}
+
+// canonicalBill is canonicalDocument for a Congress.gov bill. Its date is that of the
+// latest action when it was scraped, and its placeholder summary is that action's text.
+func canonicalBill(raw repository.UnlinkedRawPolicyDocumentRow, loc *time.Location) (*canonicalRow, error) {
+	var bill client.CongressBill
+	if err := json.Unmarshal(raw.RawData, &bill); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal raw_policy_documents(%d) into congress bill: %w", raw.ID, err)
+	}
+	if bill.Title == "" || bill.Number == "" {
+		return nil, fmt.Errorf("raw_policy_documents(%d) is missing the bill title or number", raw.ID)
+	}
+
+	publishedAt, err := timeformat.ParsePublicationDate(bill.PublicationDate(), loc)
+	if err != nil {
+		return nil, fmt.Errorf("invalid action date for raw_policy_documents(%d): %w", raw.ID, err)
+	}
+
+	summary := "Pending summary."
+	if bill.LatestAction != nil && bill.LatestAction.Text != "" {
+		summary = "Latest action: " + bill.LatestAction.Text
+	}
+
+	var agencies []client.FRAgency
+	var agencyPtr *string
+	if name := billChambers[bill.OriginChamber]; name != "" {
+		agencies = []client.FRAgency{{Name: name}}
+		agencyPtr = &name
+	}
+
+	docType := bill.DocumentType()
+	doc := &domain.PolicyDocument{
+		SourceKey:    raw.SourceKey,
+		ExternalID:   raw.ExternalID,
+		FetchedAt:    raw.FetchedAt,
+		Title:        bill.Title,
+		Agency:       agencyPtr,
+		Summary:      summary,
+		SourceURL:    bill.WebURL(),
+		PublishedAt:  publishedAt,
+		DocumentType: &docType,
+	}
+	return &canonicalRow{doc: doc, agencies: agencies}, nil
+}
@@ -0,0 +1,310 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/alex/opengov-go/internal/client"
+	"github.com/alex/opengov-go/internal/constants"
+	"github.com/alex/opengov-go/internal/domain"
+)
+
+// chatCompletionClient calls an OpenAI-compatible /chat/completions endpoint. Both xAI
+// and OpenAI (and most local model servers) speak this protocol.
+type chatCompletionClient struct {
+	provider string
+	baseURL  string
+	apiKey   string
+	model    string
+	client   *http.Client
+	usage    UsageRecorder
+}
+
+func newChatCompletionClient(provider, baseURL, apiKey, model string, timeout time.Duration, usage UsageRecorder, calls *client.CallLog) *chatCompletionClient {
+	return &chatCompletionClient{
+		provider: provider,
+		baseURL:  baseURL,
+		apiKey:   apiKey,
+		model:    model,
+		client: &http.Client{
+			Timeout:   timeout,
+			Transport: calls.Transport(provider, nil),
+		},
+		usage: usage,
+	}
+}
+
+type chatRequest struct {
+	Model       string        `json:"model"`
+	Messages    []chatMessage `json:"messages"`
+	Temperature float64       `json:"temperature,omitempty"`
+	MaxTokens   int           `json:"max_tokens,omitempty"`
+}
+
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type chatResponse struct {
+	Choices []chatChoice `json:"choices"`
+	Usage   *chatUsage   `json:"usage,omitempty"`
+}
+
+type chatUsage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
+}
+
+type chatChoice struct {
+	Message chatMessage `json:"message"`
+}
+
+type analysisResponse struct {
+	Summary        string   `json:"summary"`
+	Keypoints      []string `json:"keypoints"`
+	ImpactScore    string   `json:"impact_score"`
+	PoliticalScore int      `json:"political_score"`
+	Categories     []string `json:"categories"`
+}
+
+// maxQuotedResponse caps how much of an unparseable model response is quoted in errors.
+const maxQuotedResponse = 200
+
+// decodeAnalysis finds the analysis object in the model's answer. Models wrap the JSON
+// in code fences, prose or several objects, so every balanced top-level {...} is tried
+// in order and the first that decodes to a non-empty analysis wins.
+func decodeAnalysis(content string) (*analysisResponse, error) {
+	for _, candidate := range jsonObjects(content) {
+		var analysis analysisResponse
+		if err := json.Unmarshal([]byte(candidate), &analysis); err != nil {
+			continue
+		}
+		if analysis.Summary == "" && len(analysis.Keypoints) == 0 && analysis.ImpactScore == "" &&
+			analysis.PoliticalScore == 0 && analysis.Categories == nil {
+			continue
+		}
+		return &analysis, nil
+	}
+
+	quoted := content
+	if r := []rune(quoted); len(r) > maxQuotedResponse {
+		quoted = string(r[:maxQuotedResponse]) + "..."
+	}
+	return nil, fmt.Errorf("no analysis JSON object found in AI response: %q", quoted)
+}
+
+// jsonObjects returns each balanced top-level {...} span in s, skipping braces inside
+// JSON strings. An unclosed object at the end is dropped.
+func jsonObjects(s string) []string {
+	var out []string
+	depth, start := 0, -1
+	inString, escaped := false, false
+	for i := 0; i < len(s); i++ {
+		ch := s[i]
+		if inString {
+			switch {
+			case escaped:
+				escaped = false
+			case ch == '\\':
+				escaped = true
+			case ch == '"':
+				inString = false
+			}
+			continue
+		}
+		switch ch {
+		case '"':
+			// Quotes only delimit strings inside an object; in prose they are ignored.
+			inString = depth > 0
+		case '{':
+			if depth == 0 {
+				start = i
+			}
+			depth++
+		case '}':
+			if depth == 0 {
+				continue
+			}
+			depth--
+			if depth == 0 {
+				out = append(out, s[start:i+1])
+			}
+		}
+	}
+	return out
+}
+
+// complete sends prompt as a single user message and returns the first choice's content.
+func (c *chatCompletionClient) complete(ctx context.Context, prompt string) (string, error) {
+	reqBody := chatRequest{
+		Model:       c.model,
+		Messages:    []chatMessage{{Role: "user", Content: prompt}},
+		Temperature: 0.7,
+		MaxTokens:   800,
+	}
+
+	jsonBody, err := json.Marshal(reqBody)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/chat/completions", bytes.NewReader(jsonBody))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("unexpected status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var result chatResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	c.recordUsage(ctx, result.Usage)
+
+	if len(result.Choices) == 0 {
+		return "", fmt.Errorf("no choices returned from API")
+	}
+
+	content := strings.TrimSpace(result.Choices[0].Message.Content)
+	if content == "" {
+		return "", fmt.Errorf("empty response from API")
+	}
+
+	return content, nil
+}
+
+// recordUsage stores token counts for the call. It is best-effort: failures are logged
+// and never surface to the caller.
+func (c *chatCompletionClient) recordUsage(ctx context.Context, u *chatUsage) {
+	if c.usage == nil || u == nil {
+		return
+	}
+
+	rec := &domain.AIUsage{
+		Provider:         c.provider,
+		Model:            c.model,
+		PromptTokens:     u.PromptTokens,
+		CompletionTokens: u.CompletionTokens,
+		TotalTokens:      u.TotalTokens,
+	}
+	if id, ok := documentIDFromContext(ctx); ok {
+		rec.PolicyDocumentID = &id
+	}
+
+	if err := c.usage.Create(ctx, rec); err != nil {
+		log.Printf("Failed to record AI usage: %v", err)
+	}
+}
+
+// parseAnalysis decodes the model's JSON answer and normalizes out-of-range scores.
+func parseAnalysis(content string) (*AIAnalysis, error) {
+	analysis, err := decodeAnalysis(content)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate and clamp political score
+	if analysis.PoliticalScore < -100 {
+		analysis.PoliticalScore = -100
+	}
+	if analysis.PoliticalScore > 100 {
+		analysis.PoliticalScore = 100
+	}
+
+	// Validate impact score
+	switch analysis.ImpactScore {
+	case "low", "medium", "high":
+		// valid
+	default:
+		analysis.ImpactScore = "medium"
+	}
+
+	return &AIAnalysis{
+		Summary:        analysis.Summary,
+		Keypoints:      analysis.Keypoints,
+		ImpactScore:    analysis.ImpactScore,
+		PoliticalScore: analysis.PoliticalScore,
+		Categories:     normalizeCategories(analysis.Categories),
+	}, nil
+}
+
+// maxCategories caps how many categories one document keeps.
+const maxCategories = 3
+
+// normalizeCategories lowercases the model's categories and keeps the first
+// maxCategories distinct ones on the allow-list. The result is never nil, so callers can
+// tell "no categories" apart from "not analyzed".
+func normalizeCategories(in []string) []string {
+	out := []string{}
+	for _, c := range in {
+		c = strings.ToLower(strings.TrimSpace(c))
+		if !slices.Contains(constants.Categories, c) || slices.Contains(out, c) {
+			continue
+		}
+		out = append(out, c)
+		if len(out) == maxCategories {
+			break
+		}
+	}
+	return out
+}
+
+// languageInstruction is appended to the analysis prompt to get the summary and
+// keypoints in another language. Scores and categories stay in English so they parse.
+func languageInstruction(lang string) string {
+	name := constants.Languages[lang]
+	if name == "" {
+		name = lang
+	}
+	return fmt.Sprintf("\n\nWrite the summary and keypoints in %s. Keep the JSON keys, impact_score and categories in English.", name)
+}
+
+// analyzeWithChat formats the analysis prompt and runs it through client.
+func analyzeWithChat(ctx context.Context, client *chatCompletionClient, prompts *analysisPromptCache, summaryMaxChars int, title, abstract, agency string) (*AIAnalysis, error) {
+	if abstract == "" && title == "" {
+		return nil, fmt.Errorf("title and abstract cannot both be empty")
+	}
+
+	prompt := fmt.Sprintf(prompts.template(ctx), title, agency, abstract)
+	if lang, ok := languageFromContext(ctx); ok {
+		prompt += languageInstruction(lang)
+	}
+
+	content, err := client.complete(ctx, prompt)
+	if err != nil {
+		return nil, err
+	}
+
+	analysis, err := parseAnalysis(content)
+	if err != nil {
+		return nil, err
+	}
+	analysis.Keypoints = sanitizeKeypoints(analysis.Keypoints, abstract)
+	if summaryMaxChars > 0 {
+		analysis.Summary = truncateSummary(analysis.Summary, summaryMaxChars)
+	}
+	return analysis, nil
+}
@@ -0,0 +1,44 @@
+package services
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseAnalysis_FindsObject(t *testing.T) {
+	const obj = `{"summary":"Short {braced} text","keypoints":["a"],"impact_score":"low","political_score":5}`
+
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"bare", obj},
+		{"code fence", "```json\n" + obj + "\n```"},
+		{"leading prose", "Here is the {analysis} you asked for:\n" + obj},
+		{"trailing commentary", obj + "\nNote: scores are estimates {approximate}."},
+		{"empty object first", `{} then ` + obj},
+		{"escaped quote", `{"summary":"Short {braced} text \"quoted\" }","political_score":5}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseAnalysis(tt.content)
+			if err != nil {
+				t.Fatalf("parseAnalysis() error: %v", err)
+			}
+			if !strings.HasPrefix(got.Summary, "Short {braced} text") || got.PoliticalScore != 5 {
+				t.Fatalf("unexpected analysis: %+v", got)
+			}
+		})
+	}
+}
+
+func TestParseAnalysis_NothingParses(t *testing.T) {
+	content := "I cannot analyze this document. " + strings.Repeat("x", 500) + " {not json}"
+	_, err := parseAnalysis(content)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if !strings.Contains(err.Error(), "I cannot analyze") || len(err.Error()) > 300 {
+		t.Fatalf("error should quote the truncated response: %v", err)
+	}
+}
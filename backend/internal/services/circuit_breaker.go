@@ -0,0 +1,90 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned by CircuitBreaker while it is short-circuiting calls.
+var ErrCircuitOpen = errors.New("summarizer circuit open")
+
+// CircuitBreaker wraps a Summarizer and stops calling it after threshold consecutive
+// failures, failing fast with ErrCircuitOpen for the cooldown, so a reprocess run fails
+// quickly instead of waiting on a timeout for every document. After the cooldown one
+// probe call is let through; success closes the circuit, failure reopens it.
+type CircuitBreaker struct {
+	inner     Summarizer
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+	probing   bool
+}
+
+func NewCircuitBreaker(inner Summarizer, threshold int, cooldown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{
+		inner:     inner,
+		threshold: threshold,
+		cooldown:  cooldown,
+		now:       time.Now,
+	}
+}
+
+func (b *CircuitBreaker) Analyze(ctx context.Context, title, abstract, agency string) (*AIAnalysis, error) {
+	if !b.allow() {
+		return nil, ErrCircuitOpen
+	}
+	analysis, err := b.inner.Analyze(ctx, title, abstract, agency)
+	b.record(err)
+	return analysis, err
+}
+
+// allow reports whether a call may go through, claiming the probe slot when the
+// cooldown has passed.
+func (b *CircuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures < b.threshold {
+		return true
+	}
+	if b.probing || b.now().Before(b.openUntil) {
+		return false
+	}
+	b.probing = true
+	return true
+}
+
+func (b *CircuitBreaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	wasProbe := b.probing
+	b.probing = false
+
+	// A cancelled caller says nothing about the backend's health.
+	if errors.Is(err, context.Canceled) {
+		return
+	}
+	if err == nil {
+		if b.failures >= b.threshold {
+			log.Println("Summarizer circuit closed")
+		}
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if b.failures >= b.threshold {
+		b.openUntil = b.now().Add(b.cooldown)
+		if b.failures == b.threshold || wasProbe {
+			log.Printf("Summarizer circuit open for %v after %d consecutive failures: %v", b.cooldown, b.failures, err)
+		}
+	}
+}
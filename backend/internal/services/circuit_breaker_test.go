@@ -0,0 +1,80 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type flakySummarizer struct {
+	calls int
+	fail  bool
+}
+
+func (s *flakySummarizer) Analyze(context.Context, string, string, string) (*AIAnalysis, error) {
+	s.calls++
+	if s.fail {
+		return nil, errors.New("upstream timeout")
+	}
+	return &AIAnalysis{Summary: "ok"}, nil
+}
+
+func TestCircuitBreaker_OpensAndRecovers(t *testing.T) {
+	ctx := context.Background()
+	inner := &flakySummarizer{fail: true}
+	b := NewCircuitBreaker(inner, 3, time.Minute)
+	now := time.Date(2025, 3, 3, 12, 0, 0, 0, time.UTC)
+	b.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		if _, err := b.Analyze(ctx, "t", "a", "g"); err == nil || errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("call %d: err = %v, want the upstream error", i, err)
+		}
+	}
+
+	// Open: calls fail fast without reaching the backend.
+	if _, err := b.Analyze(ctx, "t", "a", "g"); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("err = %v, want ErrCircuitOpen", err)
+	}
+	if inner.calls != 3 {
+		t.Fatalf("backend called %d times, want 3", inner.calls)
+	}
+
+	// After the cooldown a failing probe reopens the circuit.
+	now = now.Add(time.Minute)
+	if _, err := b.Analyze(ctx, "t", "a", "g"); errors.Is(err, ErrCircuitOpen) {
+		t.Fatal("probe was short-circuited")
+	}
+	if _, err := b.Analyze(ctx, "t", "a", "g"); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("err = %v, want ErrCircuitOpen after a failed probe", err)
+	}
+
+	// A successful probe closes it again.
+	now = now.Add(time.Minute)
+	inner.fail = false
+	if _, err := b.Analyze(ctx, "t", "a", "g"); err != nil {
+		t.Fatalf("probe err = %v", err)
+	}
+	if _, err := b.Analyze(ctx, "t", "a", "g"); err != nil {
+		t.Fatalf("err = %v after recovery", err)
+	}
+	if inner.calls != 6 {
+		t.Fatalf("backend called %d times, want 6", inner.calls)
+	}
+}
+
+func TestCircuitBreaker_SuccessResetsFailureCount(t *testing.T) {
+	inner := &flakySummarizer{fail: true}
+	b := NewCircuitBreaker(inner, 2, time.Minute)
+
+	b.Analyze(context.Background(), "t", "a", "g")
+	inner.fail = false
+	b.Analyze(context.Background(), "t", "a", "g")
+	inner.fail = true
+	b.Analyze(context.Background(), "t", "a", "g")
+
+	if _, err := b.Analyze(context.Background(), "t", "a", "g"); errors.Is(err, ErrCircuitOpen) {
+		t.Fatal("circuit opened on non-consecutive failures")
+	}
+}
@@ -0,0 +1,39 @@
+package services
+
+import (
+	"strings"
+
+	"github.com/alex/opengov-go/internal/config"
+	"github.com/alex/opengov-go/internal/domain"
+)
+
+// EnrichmentPolicy decides which documents are worth a full AI analysis. In selective
+// mode only the configured document types (Rules and the like) are analyzed; routine
+// notices keep the templated fallback summary to save cost. Bulk reprocess applies it.
+type EnrichmentPolicy struct {
+	selective    bool
+	analyzeTypes map[string]bool
+}
+
+func NewEnrichmentPolicy(cfg *config.Config) EnrichmentPolicy {
+	p := EnrichmentPolicy{
+		selective:    cfg.EnrichMode == config.EnrichModeSelective,
+		analyzeTypes: make(map[string]bool, len(cfg.EnrichAnalyzeTypes)),
+	}
+	for _, t := range cfg.EnrichAnalyzeTypes {
+		p.analyzeTypes[strings.ToLower(t)] = true
+	}
+	return p
+}
+
+// WantsFullAnalysis reports whether doc should go to the summarizer. Documents with no
+// type are treated as low impact in selective mode.
+func (p EnrichmentPolicy) WantsFullAnalysis(doc *domain.PolicyDocument) bool {
+	if !p.selective {
+		return true
+	}
+	if doc.DocumentType == nil {
+		return false
+	}
+	return p.analyzeTypes[strings.ToLower(strings.TrimSpace(*doc.DocumentType))]
+}
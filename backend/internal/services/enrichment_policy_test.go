@@ -0,0 +1,44 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/alex/opengov-go/internal/config"
+	"github.com/alex/opengov-go/internal/domain"
+)
+
+func strPtr(s string) *string { return &s }
+
+func TestEnrichmentPolicy_Selective(t *testing.T) {
+	policy := NewEnrichmentPolicy(&config.Config{
+		EnrichMode:         config.EnrichModeSelective,
+		EnrichAnalyzeTypes: []string{"Rule", "Proposed Rule"},
+	})
+
+	tests := []struct {
+		name    string
+		docType *string
+		want    bool
+	}{
+		{"rule is analyzed", strPtr("Rule"), true},
+		{"type match ignores case", strPtr("proposed rule"), true},
+		{"routine notice gets fallback", strPtr("Notice"), false},
+		{"missing type gets fallback", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := &domain.PolicyDocument{ID: 1, Title: "Meeting notice", DocumentType: tt.docType}
+			if got := policy.WantsFullAnalysis(doc); got != tt.want {
+				t.Fatalf("WantsFullAnalysis() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnrichmentPolicy_AllModeAnalyzesEverything(t *testing.T) {
+	policy := NewEnrichmentPolicy(&config.Config{EnrichMode: config.EnrichModeAll, EnrichAnalyzeTypes: []string{"Rule"}})
+
+	if !policy.WantsFullAnalysis(&domain.PolicyDocument{DocumentType: strPtr("Notice")}) {
+		t.Fatal("all mode skipped a Notice")
+	}
+}
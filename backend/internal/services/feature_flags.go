@@ -0,0 +1,102 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/alex/opengov-go/internal/domain"
+)
+
+// flagSettingPrefix namespaces persisted flags within the settings table.
+const flagSettingPrefix = "flag:"
+
+var ErrInvalidFlagName = errors.New("flag name must be lowercase letters, digits or underscores")
+
+var flagNameRE = regexp.MustCompile(`^[a-z0-9_]{1,64}$`)
+
+// FlagStore persists flag values. SettingsRepository satisfies it.
+type FlagStore interface {
+	Set(ctx context.Context, key, value string) error
+	ListByPrefix(ctx context.Context, prefix string) ([]domain.Setting, error)
+}
+
+// FeatureFlags is an in-memory set of runtime toggles. Values are seeded from config,
+// overlaid with persisted values by Load, and written through to the store on Set.
+// Unknown flags are disabled.
+type FeatureFlags struct {
+	store FlagStore
+
+	mu    sync.RWMutex
+	flags map[string]bool
+}
+
+// NewFeatureFlags copies seed. store may be nil to keep flags in memory only.
+func NewFeatureFlags(seed map[string]bool, store FlagStore) *FeatureFlags {
+	flags := make(map[string]bool, len(seed))
+	for name, enabled := range seed {
+		flags[name] = enabled
+	}
+	return &FeatureFlags{store: store, flags: flags}
+}
+
+// Load overlays flags persisted by earlier Set calls on top of the seed values.
+func (f *FeatureFlags) Load(ctx context.Context) error {
+	if f.store == nil {
+		return nil
+	}
+
+	settings, err := f.store.ListByPrefix(ctx, flagSettingPrefix)
+	if err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, s := range settings {
+		enabled, err := strconv.ParseBool(s.Value)
+		if err != nil {
+			continue
+		}
+		f.flags[strings.TrimPrefix(s.Key, flagSettingPrefix)] = enabled
+	}
+	return nil
+}
+
+func (f *FeatureFlags) Enabled(name string) bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.flags[name]
+}
+
+// Set updates a flag, persisting it first so memory never holds a value the store lost.
+func (f *FeatureFlags) Set(ctx context.Context, name string, enabled bool) error {
+	if !flagNameRE.MatchString(name) {
+		return ErrInvalidFlagName
+	}
+
+	if f.store != nil {
+		if err := f.store.Set(ctx, flagSettingPrefix+name, strconv.FormatBool(enabled)); err != nil {
+			return err
+		}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.flags[name] = enabled
+	return nil
+}
+
+// All returns a copy of every known flag.
+func (f *FeatureFlags) All() map[string]bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	out := make(map[string]bool, len(f.flags))
+	for name, enabled := range f.flags {
+		out[name] = enabled
+	}
+	return out
+}
@@ -0,0 +1,74 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/alex/opengov-go/internal/domain"
+)
+
+type fakeFlagStore struct {
+	settings []domain.Setting
+	setErr   error
+	set      map[string]string
+}
+
+func (f *fakeFlagStore) Set(_ context.Context, key, value string) error {
+	if f.setErr != nil {
+		return f.setErr
+	}
+	if f.set == nil {
+		f.set = map[string]string{}
+	}
+	f.set[key] = value
+	return nil
+}
+
+func (f *fakeFlagStore) ListByPrefix(context.Context, string) ([]domain.Setting, error) {
+	return f.settings, nil
+}
+
+func TestFeatureFlags_LoadOverlaysSeed(t *testing.T) {
+	store := &fakeFlagStore{settings: []domain.Setting{
+		{Key: "flag:comments", Value: "false"},
+		{Key: "flag:reactions", Value: "true"},
+		{Key: "flag:broken", Value: "maybe"},
+	}}
+	flags := NewFeatureFlags(map[string]bool{"comments": true, "sse_stream": true}, store)
+
+	if err := flags.Load(context.Background()); err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+
+	want := map[string]bool{"comments": false, "reactions": true, "sse_stream": true, "unknown": false}
+	for name, enabled := range want {
+		if got := flags.Enabled(name); got != enabled {
+			t.Fatalf("Enabled(%q) = %v, want %v", name, got, enabled)
+		}
+	}
+}
+
+func TestFeatureFlags_Set(t *testing.T) {
+	store := &fakeFlagStore{}
+	flags := NewFeatureFlags(nil, store)
+
+	if err := flags.Set(context.Background(), "comments", true); err != nil {
+		t.Fatalf("Set() error: %v", err)
+	}
+	if !flags.Enabled("comments") || store.set["flag:comments"] != "true" {
+		t.Fatalf("flag not set and persisted: enabled=%v store=%v", flags.Enabled("comments"), store.set)
+	}
+
+	if err := flags.Set(context.Background(), "Bad Name", true); !errors.Is(err, ErrInvalidFlagName) {
+		t.Fatalf("Set() with invalid name error = %v, want %v", err, ErrInvalidFlagName)
+	}
+
+	store.setErr = errors.New("db down")
+	if err := flags.Set(context.Background(), "comments", false); err == nil {
+		t.Fatal("Set() succeeded despite store failure")
+	}
+	if !flags.Enabled("comments") {
+		t.Fatal("failed Set() changed the in-memory value")
+	}
+}
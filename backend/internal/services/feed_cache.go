@@ -0,0 +1,101 @@
+package services
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/alex/opengov-go/internal/repository"
+	"github.com/alex/opengov-go/internal/transport"
+)
+
+// feedCacheMaxEntries bounds memory when clients vary filters, e.g. free-text q.
+const feedCacheMaxEntries = 1000
+
+// FeedCache keeps anonymous feed pages in memory for up to ttl, together with the ETag
+// (see FeedService.FeedETag) they were fetched under. Hits are served without touching
+// the database, so a page can be up to ttl stale; the API also drops every page when a
+// scrape it ran changes the feed. Personalized feeds are never cached. A ttl of 0
+// disables caching.
+type FeedCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]feedCacheEntry
+}
+
+type feedCacheEntry struct {
+	etag      string
+	resp      transport.FeedResponse
+	expiresAt time.Time
+}
+
+func NewFeedCache(ttl time.Duration) *FeedCache {
+	return &FeedCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[string]feedCacheEntry),
+	}
+}
+
+// Get returns a cached anonymous feed page and its ETag, if one has not expired.
+func (c *FeedCache) Get(page, limit int, sort string, filter repository.FeedFilter) (transport.FeedResponse, string, bool) {
+	if c.ttl <= 0 {
+		return transport.FeedResponse{}, "", false
+	}
+
+	c.mu.Lock()
+	e, ok := c.entries[feedCacheKey(page, limit, sort, filter)]
+	c.mu.Unlock()
+	if !ok || !c.now().Before(e.expiresAt) {
+		return transport.FeedResponse{}, "", false
+	}
+	return cloneFeedResponse(e.resp), e.etag, true
+}
+
+// Put caches an anonymous feed page fetched under etag.
+func (c *FeedCache) Put(page, limit int, sort string, filter repository.FeedFilter, etag string, resp transport.FeedResponse) {
+	if c.ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	if len(c.entries) >= feedCacheMaxEntries {
+		for k, e := range c.entries {
+			if !now.Before(e.expiresAt) {
+				delete(c.entries, k)
+			}
+		}
+		if len(c.entries) >= feedCacheMaxEntries {
+			clear(c.entries)
+		}
+	}
+	c.entries[feedCacheKey(page, limit, sort, filter)] = feedCacheEntry{
+		etag:      etag,
+		resp:      cloneFeedResponse(resp),
+		expiresAt: now.Add(c.ttl),
+	}
+}
+
+// Invalidate drops every cached page. The jobs service calls it after materializing new
+// feed entries.
+func (c *FeedCache) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	clear(c.entries)
+}
+
+func feedCacheKey(page, limit int, sort string, filter repository.FeedFilter) string {
+	return fmt.Sprintf("%d|%d|%s|%#v", page, limit, sort, filter)
+}
+
+// cloneFeedResponse copies Items so callers that rewrite entries in place, such as
+// FeedService.Translate, cannot change what the cache holds.
+func cloneFeedResponse(resp transport.FeedResponse) transport.FeedResponse {
+	resp.Items = slices.Clone(resp.Items)
+	return resp
+}
@@ -0,0 +1,62 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alex/opengov-go/internal/repository"
+	"github.com/alex/opengov-go/internal/transport"
+)
+
+func TestFeedCache_HitMissAndInvalidate(t *testing.T) {
+	c := NewFeedCache(time.Minute)
+	now := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+	c.now = func() time.Time { return now }
+
+	page := transport.FeedResponse{
+		Items: []transport.FeedEntryResponse{{ID: 1, Summary: "English"}},
+		Page:  1,
+	}
+	if _, _, ok := c.Get(1, 20, "newest", repository.FeedFilter{}); ok {
+		t.Fatal("empty cache reported a hit")
+	}
+	c.Put(1, 20, "newest", repository.FeedFilter{}, `W/"v1"`, page)
+
+	resp, etag, ok := c.Get(1, 20, "newest", repository.FeedFilter{})
+	if !ok || etag != `W/"v1"` || resp.Items[0].ID != 1 {
+		t.Fatalf("Get() = %+v, %q, %v; want the cached page under v1", resp, etag, ok)
+	}
+
+	// Callers may rewrite items in place; the cached copy must not change.
+	resp.Items[0].Summary = "Español"
+	page.Items[0].Summary = "Español"
+	if got, _, _ := c.Get(1, 20, "newest", repository.FeedFilter{}); got.Items[0].Summary != "English" {
+		t.Fatalf("cached summary = %q, want English", got.Items[0].Summary)
+	}
+
+	if _, _, ok := c.Get(2, 20, "newest", repository.FeedFilter{}); ok {
+		t.Fatal("hit for another page")
+	}
+	if _, _, ok := c.Get(1, 20, "newest", repository.FeedFilter{Agency: "EPA"}); ok {
+		t.Fatal("hit for another filter")
+	}
+
+	c.Invalidate()
+	if _, _, ok := c.Get(1, 20, "newest", repository.FeedFilter{}); ok {
+		t.Fatal("hit after Invalidate")
+	}
+
+	c.Put(1, 20, "newest", repository.FeedFilter{}, `W/"v2"`, page)
+	now = now.Add(time.Minute)
+	if _, _, ok := c.Get(1, 20, "newest", repository.FeedFilter{}); ok {
+		t.Fatal("hit after the ttl")
+	}
+}
+
+func TestFeedCache_Disabled(t *testing.T) {
+	c := NewFeedCache(0)
+	c.Put(1, 20, "newest", repository.FeedFilter{}, `W/"v1"`, transport.FeedResponse{Page: 1})
+	if _, _, ok := c.Get(1, 20, "newest", repository.FeedFilter{}); ok {
+		t.Fatal("hit with caching disabled")
+	}
+}
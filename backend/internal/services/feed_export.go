@@ -0,0 +1,54 @@
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alex/opengov-go/internal/repository"
+)
+
+// ExportMaxRows caps a feed export. Resume a JSON export with the last row's cursor, or
+// narrow the date range of a CSV one, to get older entries.
+const ExportMaxRows = 10000
+
+var ErrInvalidExportCursor = errors.New("invalid export cursor")
+
+// Export streams up to ExportMaxRows entries matching filter to fn, newest first,
+// starting after the given cursor when it is non-nil. Hidden documents are never
+// exported.
+func (s *FeedService) Export(ctx context.Context, filter repository.FeedFilter, after *repository.FeedCursor, fn func(repository.FeedExportRow) error) error {
+	filter.IncludeHidden = false
+	return s.feedRepo.Export(ctx, filter, after, ExportMaxRows, fn)
+}
+
+// EncodeExportCursor returns the opaque token that resumes an export after row.
+func EncodeExportCursor(row repository.FeedExportRow) string {
+	raw := fmt.Sprintf("%d.%d", row.PublishedAt.UnixMicro(), row.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// ParseExportCursor reads a token from EncodeExportCursor.
+func ParseExportCursor(token string) (*repository.FeedCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidExportCursor
+	}
+	micros, id, ok := strings.Cut(string(raw), ".")
+	if !ok {
+		return nil, ErrInvalidExportCursor
+	}
+	us, err := strconv.ParseInt(micros, 10, 64)
+	if err != nil {
+		return nil, ErrInvalidExportCursor
+	}
+	entryID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil || entryID <= 0 {
+		return nil, ErrInvalidExportCursor
+	}
+	return &repository.FeedCursor{PublishedAt: time.UnixMicro(us), ID: entryID}, nil
+}
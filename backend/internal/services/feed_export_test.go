@@ -0,0 +1,27 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alex/opengov-go/internal/repository"
+)
+
+func TestExportCursor_RoundTrip(t *testing.T) {
+	row := repository.FeedExportRow{ID: 42, PublishedAt: time.Date(2025, 1, 10, 5, 0, 0, 123000, time.UTC)}
+	got, err := ParseExportCursor(EncodeExportCursor(row))
+	if err != nil {
+		t.Fatalf("ParseExportCursor() error: %v", err)
+	}
+	if got.ID != 42 || !got.PublishedAt.Equal(row.PublishedAt) {
+		t.Fatalf("cursor = %+v, want id 42 at %v", got, row.PublishedAt)
+	}
+}
+
+func TestParseExportCursor_Rejects(t *testing.T) {
+	for _, token := range []string{"", "not base64!", "MTIz", "YWJjLjQy", "MTIzLjA"} {
+		if _, err := ParseExportCursor(token); err == nil {
+			t.Errorf("ParseExportCursor(%q) expected an error", token)
+		}
+	}
+}
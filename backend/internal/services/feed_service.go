@@ -2,29 +2,48 @@ package services
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
 	"github.com/alex/opengov-go/internal/repository"
 	"github.com/alex/opengov-go/internal/timeformat"
 	"github.com/alex/opengov-go/internal/transport"
 )
 
+var (
+	ErrInvalidArchiveMonth = errors.New("invalid archive month")
+	ErrFeedEntryNotFound   = errors.New("feed entry not found")
+)
+
+// archiveMinYear is the first year the Federal Register publishes online.
+const archiveMinYear = 1994
+
 type FeedService struct {
-	feedRepo *repository.FeedRepository
+	feedRepo   *repository.FeedRepository
+	followRepo *repository.AgencyFollowRepository
+	userRepo   *repository.UserRepository
+	loc        *time.Location // publication timezone for archive month boundaries
 }
 
-func NewFeedService(feedRepo *repository.FeedRepository) *FeedService {
-	return &FeedService{feedRepo: feedRepo}
+func NewFeedService(feedRepo *repository.FeedRepository, followRepo *repository.AgencyFollowRepository, userRepo *repository.UserRepository, loc *time.Location) *FeedService {
+	return &FeedService{
+		feedRepo:   feedRepo,
+		followRepo: followRepo,
+		userRepo:   userRepo,
+		loc:        loc,
+	}
 }
 
-func (s *FeedService) GetFeed(ctx context.Context, userID *int64, page, limit int, sort string) (transport.FeedResponse, error) {
+func (s *FeedService) GetFeed(ctx context.Context, userID *int64, page, limit int, sort string, filter repository.FeedFilter) (transport.FeedResponse, error) {
 	var items []repository.FeedEntryRow
 	var total int
 	var err error
 
 	if userID != nil {
-		items, total, err = s.feedRepo.GetFeedForUser(ctx, *userID, page, limit, sort)
+		items, total, err = s.feedRepo.GetFeedForUser(ctx, *userID, page, limit, sort, filter)
 	} else {
-		items, total, err = s.feedRepo.GetFeedAnon(ctx, page, limit, sort)
+		items, total, err = s.feedRepo.GetFeedAnon(ctx, page, limit, sort, filter)
 	}
 
 	if err != nil {
@@ -36,24 +55,120 @@ func (s *FeedService) GetFeed(ctx context.Context, userID *int64, page, limit in
 		responses[i] = mapFeedEntryRowToResponse(item)
 	}
 
+	emptyReason, err := feedEmptyReason(ctx, total, filter, s.feedRepo.HasEntries)
+	if err != nil {
+		return transport.FeedResponse{}, err
+	}
+
 	offset := (page - 1) * limit
 	return transport.FeedResponse{
-		Items:   responses,
-		Page:    page,
-		Limit:   limit,
-		Total:   total,
-		HasNext: offset+limit < total,
+		Items:           responses,
+		Page:            page,
+		Limit:           limit,
+		Total:           total,
+		HasNext:         offset+limit < total,
+		FeedEmptyReason: emptyReason,
 	}, nil
 }
 
-func (s *FeedService) GetItem(ctx context.Context, userID *int64, feedEntryID int64) (*transport.FeedEntryResponse, error) {
+// ParsePublicationDay reads a YYYY-MM-DD date as the start of that day in the
+// publication timezone.
+func (s *FeedService) ParsePublicationDay(day string) (time.Time, error) {
+	return timeformat.ParsePublicationDate(day, s.loc)
+}
+
+// PublicationDay formats t as a day in the publication timezone.
+func (s *FeedService) PublicationDay(t time.Time) string {
+	return timeformat.PublicationDay(t, s.loc)
+}
+
+// FeedETag returns a weak ETag for the anonymous feed. It changes with the feed's
+// contents, not with the query, so it is only meaningful per URL.
+func (s *FeedService) FeedETag(ctx context.Context) (string, error) {
+	v, err := s.feedRepo.GetVersion(ctx)
+	if err != nil {
+		return "", err
+	}
+	return feedETag(v), nil
+}
+
+func feedETag(version time.Time) string {
+	return fmt.Sprintf(`W/"%x"`, version.UnixMicro())
+}
+
+// feedEmptyReason tells an empty feed on a fresh database apart from one whose filters
+// matched nothing. hasEntries is only consulted when total is 0 and the filter narrows.
+func feedEmptyReason(ctx context.Context, total int, filter repository.FeedFilter, hasEntries func(context.Context, bool) (bool, error)) (string, error) {
+	if total > 0 {
+		return "", nil
+	}
+	if !filter.Narrows() {
+		return transport.FeedEmptyNoContent, nil
+	}
+	exists, err := hasEntries(ctx, filter.IncludeHidden)
+	if err != nil {
+		return "", err
+	}
+	if !exists {
+		return transport.FeedEmptyNoContent, nil
+	}
+	return transport.FeedEmptyFiltered, nil
+}
+
+// GetFollowingFeed returns the feed restricted to agencies the user follows.
+func (s *FeedService) GetFollowingFeed(ctx context.Context, userID int64, page, limit int, sort string) (transport.FeedResponse, error) {
+	agencies, err := s.followRepo.GetFollowedAgencyNames(ctx, userID)
+	if err != nil {
+		return transport.FeedResponse{}, err
+	}
+	return s.GetFeed(ctx, &userID, page, limit, sort, repository.FeedFilter{Agencies: agencies})
+}
+
+// ArchiveMonthRange returns the [start, end) range for a year/month archive bucket, with
+// month boundaries taken in loc.
+func ArchiveMonthRange(year, month int, now time.Time, loc *time.Location) (start, end time.Time, err error) {
+	if year < archiveMinYear || year > now.In(loc).Year() {
+		return start, end, fmt.Errorf("%w: year must be between %d and %d", ErrInvalidArchiveMonth, archiveMinYear, now.In(loc).Year())
+	}
+	if month < 1 || month > 12 {
+		return start, end, fmt.Errorf("%w: month must be between 1 and 12", ErrInvalidArchiveMonth)
+	}
+	start = time.Date(year, time.Month(month), 1, 0, 0, 0, 0, loc)
+	return start, start.AddDate(0, 1, 0), nil
+}
+
+// GetArchiveMonth returns the feed restricted to entries published in the given month.
+func (s *FeedService) GetArchiveMonth(ctx context.Context, userID *int64, year, month, page, limit int, sort string) (transport.FeedResponse, error) {
+	start, end, err := ArchiveMonthRange(year, month, time.Now(), s.loc)
+	if err != nil {
+		return transport.FeedResponse{}, err
+	}
+	return s.GetFeed(ctx, userID, page, limit, sort, repository.FeedFilter{PublishedFrom: start, PublishedBefore: end})
+}
+
+func (s *FeedService) GetArchiveBuckets(ctx context.Context) (transport.ArchiveResponse, error) {
+	rows, err := s.feedRepo.GetArchiveBuckets(ctx, s.loc.String())
+	if err != nil {
+		return transport.ArchiveResponse{}, err
+	}
+
+	buckets := make([]transport.ArchiveBucket, len(rows))
+	for i, r := range rows {
+		buckets[i] = transport.ArchiveBucket{Year: r.Year, Month: r.Month, Count: r.Count}
+	}
+	return transport.ArchiveResponse{Buckets: buckets}, nil
+}
+
+// GetItem returns the feed entry with every agency its document lists, or nil if it
+// doesn't exist or its document is hidden and includeHidden is not set.
+func (s *FeedService) GetItem(ctx context.Context, userID *int64, feedEntryID int64, includeHidden bool) (*transport.FeedEntryResponse, error) {
 	var item *repository.FeedEntryRow
 	var err error
 
 	if userID != nil {
-		item, err = s.feedRepo.GetByIDForUser(ctx, *userID, feedEntryID)
+		item, err = s.feedRepo.GetByIDForUser(ctx, *userID, feedEntryID, includeHidden)
 	} else {
-		item, err = s.feedRepo.GetByIDAnon(ctx, feedEntryID)
+		item, err = s.feedRepo.GetByIDAnon(ctx, feedEntryID, includeHidden)
 	}
 
 	if err != nil {
@@ -63,16 +178,96 @@ func (s *FeedService) GetItem(ctx context.Context, userID *int64, feedEntryID in
 		return nil, nil
 	}
 
+	agencies, err := s.feedRepo.GetAgencies(ctx, feedEntryID)
+	if err != nil {
+		return nil, err
+	}
+
 	resp := mapFeedEntryRowToResponse(*item)
+	for _, a := range agencies {
+		resp.Agencies = append(resp.Agencies, transport.FeedEntryAgency{ID: a.AgencyID, Name: a.Name})
+	}
 	return &resp, nil
 }
 
-func (s *FeedService) GetBookmarkedFeed(ctx context.Context, userID int64) ([]transport.FeedEntryResponse, error) {
-	items, err := s.feedRepo.GetBookmarkedFeed(ctx, userID)
+// GetItemFull returns the feed entry with its full abstract, or nil when GetItem would.
+func (s *FeedService) GetItemFull(ctx context.Context, userID *int64, feedEntryID int64, includeHidden bool) (*transport.FeedEntryFullResponse, error) {
+	item, err := s.GetItem(ctx, userID, feedEntryID, includeHidden)
+	if err != nil || item == nil {
+		return nil, err
+	}
+
+	abstract, found, err := s.feedRepo.GetAbstract(ctx, feedEntryID)
 	if err != nil {
 		return nil, err
 	}
+	if !found {
+		return nil, nil
+	}
+	return &transport.FeedEntryFullResponse{FeedEntryResponse: *item, Abstract: abstract}, nil
+}
+
+func (s *FeedService) GetBookmarkedFeed(ctx context.Context, userID int64, page, limit int, sort, agency string) (transport.FeedResponse, error) {
+	items, total, err := s.feedRepo.GetBookmarkedFeed(ctx, userID, page, limit, sort, agency)
+	if err != nil {
+		return transport.FeedResponse{}, err
+	}
 
+	responses := make([]transport.FeedEntryResponse, len(items))
+	for i, item := range items {
+		responses[i] = mapFeedEntryRowToResponse(item)
+	}
+	offset := (page - 1) * limit
+	return transport.FeedResponse{
+		Items:   responses,
+		Page:    page,
+		Limit:   limit,
+		Total:   total,
+		HasNext: offset+limit < total,
+	}, nil
+}
+
+// Translate replaces the summary and keypoints of items with their lang translation
+// where one exists, marking those items with Language. The rest stay in English.
+func (s *FeedService) Translate(ctx context.Context, items []transport.FeedEntryResponse, lang string) error {
+	ids := make([]int64, len(items))
+	for i, item := range items {
+		ids[i] = item.ID
+	}
+	translations, err := s.feedRepo.GetTranslations(ctx, lang, ids)
+	if err != nil {
+		return err
+	}
+	for i := range items {
+		t, ok := translations[items[i].ID]
+		if !ok {
+			continue
+		}
+		items[i].Summary = t.ShortText
+		if len(t.KeyPoints) > 0 {
+			items[i].Keypoints = t.KeyPoints
+		}
+		items[i].Language = lang
+	}
+	return nil
+}
+
+// GetRelated returns up to limit entries sharing an agency or a category with
+// feedEntryID, newest first. It returns ErrFeedEntryNotFound for an unknown id, and for
+// a hidden one unless includeHidden is set, as GetItem does.
+func (s *FeedService) GetRelated(ctx context.Context, feedEntryID int64, includeHidden bool, limit int) ([]transport.FeedEntryResponse, error) {
+	visible, err := s.feedRepo.IsVisible(ctx, feedEntryID, includeHidden)
+	if err != nil {
+		return nil, err
+	}
+	if !visible {
+		return nil, ErrFeedEntryNotFound
+	}
+
+	items, err := s.feedRepo.GetRelated(ctx, feedEntryID, includeHidden, limit)
+	if err != nil {
+		return nil, err
+	}
 	responses := make([]transport.FeedEntryResponse, len(items))
 	for i, item := range items {
 		responses[i] = mapFeedEntryRowToResponse(item)
@@ -80,6 +275,32 @@ func (s *FeedService) GetBookmarkedFeed(ctx context.Context, userID int64) ([]tr
 	return responses, nil
 }
 
+// GetActivity returns one page of the user's likes, dislikes and bookmarks, most recent
+// first.
+func (s *FeedService) GetActivity(ctx context.Context, userID int64, page, limit int) (transport.ActivityResponse, error) {
+	rows, total, err := s.feedRepo.GetActivity(ctx, userID, page, limit)
+	if err != nil {
+		return transport.ActivityResponse{}, err
+	}
+
+	items := make([]transport.ActivityItemResponse, len(rows))
+	for i, row := range rows {
+		items[i] = transport.ActivityItemResponse{
+			Action:     row.Action,
+			OccurredAt: row.OccurredAt.Format(timeformat.DBTime),
+			Entry:      mapFeedEntryRowToResponse(row.Entry),
+		}
+	}
+	offset := (page - 1) * limit
+	return transport.ActivityResponse{
+		Items:   items,
+		Page:    page,
+		Limit:   limit,
+		Total:   total,
+		HasNext: offset+limit < total,
+	}, nil
+}
+
 func mapFeedEntryRowToResponse(item repository.FeedEntryRow) transport.FeedEntryResponse {
 	return transport.FeedEntryResponse{
 		ID:             item.FeedEntryID,
@@ -89,6 +310,7 @@ func mapFeedEntryRowToResponse(item repository.FeedEntryRow) transport.FeedEntry
 		ImpactScore:    item.ImpactScore,
 		PoliticalScore: item.PoliticalScore,
 		SourceURL:      item.SourceURL,
+		Source:         item.SourceKey,
 		PublishedAt:    item.PublishedAt.Format(timeformat.DBTime),
 		IsBookmarked:   item.IsBookmarked,
 		UserLikeStatus: item.UserLikeStatus,
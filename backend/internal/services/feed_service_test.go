@@ -0,0 +1,129 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alex/opengov-go/internal/repository"
+	"github.com/alex/opengov-go/internal/transport"
+)
+
+func TestArchiveMonthRange(t *testing.T) {
+	now := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+
+	start, end, err := ArchiveMonthRange(2024, 12, now, time.UTC)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC); !start.Equal(want) {
+		t.Fatalf("start = %v, want %v", start, want)
+	}
+	if want := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC); !end.Equal(want) {
+		t.Fatalf("end = %v, want %v", end, want)
+	}
+}
+
+func TestArchiveMonthRange_UsesPublicationTimezone(t *testing.T) {
+	eastern, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("load location: %v", err)
+	}
+	now := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+
+	start, end, err := ArchiveMonthRange(2025, 3, now, eastern)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// March starts in EST and ends in EDT.
+	if want := time.Date(2025, 3, 1, 5, 0, 0, 0, time.UTC); !start.Equal(want) {
+		t.Fatalf("start = %v, want %v", start.UTC(), want)
+	}
+	if want := time.Date(2025, 4, 1, 4, 0, 0, 0, time.UTC); !end.Equal(want) {
+		t.Fatalf("end = %v, want %v", end.UTC(), want)
+	}
+}
+
+func TestArchiveMonthRange_Rejects(t *testing.T) {
+	now := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		year, month int
+	}{
+		{name: "month 13", year: 2025, month: 13},
+		{name: "month 0", year: 2025, month: 0},
+		{name: "year before archive", year: 1993, month: 1},
+		{name: "future year", year: 2026, month: 1},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, err := ArchiveMonthRange(tc.year, tc.month, now, time.UTC)
+			if !errors.Is(err, ErrInvalidArchiveMonth) {
+				t.Fatalf("expected ErrInvalidArchiveMonth, got %v", err)
+			}
+		})
+	}
+}
+
+func TestFeedEmptyReason(t *testing.T) {
+	ctx := context.Background()
+	emptyDB := func(context.Context, bool) (bool, error) { return false, nil }
+	populatedDB := func(context.Context, bool) (bool, error) { return true, nil }
+	mustNotQuery := func(context.Context, bool) (bool, error) {
+		t.Fatal("hasEntries called unexpectedly")
+		return false, nil
+	}
+
+	cases := []struct {
+		name       string
+		total      int
+		filter     repository.FeedFilter
+		hasEntries func(context.Context, bool) (bool, error)
+		want       string
+	}{
+		{"non-empty feed", 3, repository.FeedFilter{Keyword: "ozone"}, mustNotQuery, ""},
+		{"empty database, no filters", 0, repository.FeedFilter{}, mustNotQuery, transport.FeedEmptyNoContent},
+		{"empty database, filtered", 0, repository.FeedFilter{Agency: "EPA"}, emptyDB, transport.FeedEmptyNoContent},
+		{"filtered to empty", 0, repository.FeedFilter{Keyword: "ozone"}, populatedDB, transport.FeedEmptyFiltered},
+		{"followed nothing", 0, repository.FeedFilter{Agencies: []string{}}, populatedDB, transport.FeedEmptyFiltered},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := feedEmptyReason(ctx, tc.total, tc.filter, tc.hasEntries)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Fatalf("reason = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFeedEmptyReason_PassesIncludeHidden(t *testing.T) {
+	var gotIncludeHidden bool
+	_, err := feedEmptyReason(context.Background(), 0, repository.FeedFilter{Keyword: "x", IncludeHidden: true},
+		func(_ context.Context, includeHidden bool) (bool, error) {
+			gotIncludeHidden = includeHidden
+			return true, nil
+		})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !gotIncludeHidden {
+		t.Fatal("includeHidden was not passed through")
+	}
+}
+
+func TestFeedETag(t *testing.T) {
+	v := time.UnixMicro(0x5f1)
+	if got, want := feedETag(v), `W/"5f1"`; got != want {
+		t.Fatalf("feedETag() = %s, want %s", got, want)
+	}
+	if feedETag(v.Add(time.Microsecond)) == feedETag(v) {
+		t.Error("feedETag did not change with the version")
+	}
+}
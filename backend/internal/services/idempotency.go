@@ -0,0 +1,113 @@
+package services
+
+import (
+	"sync"
+	"time"
+)
+
+// idempotencyMaxEntries bounds memory when many clients send keys at once.
+const idempotencyMaxEntries = 10000
+
+// IdempotencyState is the outcome of IdempotencyStore.Begin.
+type IdempotencyState int
+
+const (
+	// IdempotencyNew means the caller holds the key and must Complete or Release it.
+	IdempotencyNew IdempotencyState = iota
+	// IdempotencyReplay means the key already finished; replay the stored response.
+	IdempotencyReplay
+	// IdempotencyInProgress means a request with the key is still running.
+	IdempotencyInProgress
+	// IdempotencyMismatch means the key was used for a different request.
+	IdempotencyMismatch
+)
+
+// IdempotentResponse is a finished response kept for replay.
+type IdempotentResponse struct {
+	Status      int
+	ContentType string
+	Body        []byte
+}
+
+// IdempotencyStore remembers the responses to requests carrying an Idempotency-Key for
+// ttl, so a client retrying after a lost response gets the original result instead of
+// repeating the mutation. Keys live in memory, so a retry that lands on another
+// instance or after a restart runs again. A ttl of 0 disables the store.
+type IdempotencyStore struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]idempotencyEntry
+}
+
+type idempotencyEntry struct {
+	fingerprint [32]byte
+	resp        *IdempotentResponse // nil while the request is in progress
+	expiresAt   time.Time
+}
+
+func NewIdempotencyStore(ttl time.Duration) *IdempotencyStore {
+	return &IdempotencyStore{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[string]idempotencyEntry),
+	}
+}
+
+// Begin claims key for the request identified by fingerprint. The stored response is
+// returned with IdempotencyReplay.
+func (s *IdempotencyStore) Begin(key string, fingerprint [32]byte) (IdempotencyState, *IdempotentResponse) {
+	if s.ttl <= 0 {
+		return IdempotencyNew, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	if e, ok := s.entries[key]; ok && now.Before(e.expiresAt) {
+		switch {
+		case e.fingerprint != fingerprint:
+			return IdempotencyMismatch, nil
+		case e.resp == nil:
+			return IdempotencyInProgress, nil
+		default:
+			return IdempotencyReplay, e.resp
+		}
+	}
+
+	if len(s.entries) >= idempotencyMaxEntries {
+		for k, e := range s.entries {
+			if !now.Before(e.expiresAt) {
+				delete(s.entries, k)
+			}
+		}
+		if len(s.entries) >= idempotencyMaxEntries {
+			// Every key is live; run the request untracked rather than evict one a
+			// client may still retry.
+			return IdempotencyNew, nil
+		}
+	}
+	s.entries[key] = idempotencyEntry{fingerprint: fingerprint, expiresAt: now.Add(s.ttl)}
+	return IdempotencyNew, nil
+}
+
+// Complete stores resp for key, claimed by Begin, to replay until ttl has passed.
+func (s *IdempotencyStore) Complete(key string, resp IdempotentResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.entries[key]
+	if !ok {
+		return
+	}
+	e.resp = &resp
+	e.expiresAt = s.now().Add(s.ttl)
+	s.entries[key] = e
+}
+
+// Release forgets key so a retry runs again, e.g. after a server error.
+func (s *IdempotencyStore) Release(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, key)
+}
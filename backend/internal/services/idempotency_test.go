@@ -0,0 +1,48 @@
+package services
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIdempotencyStore(t *testing.T) {
+	s := NewIdempotencyStore(time.Minute)
+	now := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+	s.now = func() time.Time { return now }
+	like, dislike := [32]byte{1}, [32]byte{2}
+
+	if state, _ := s.Begin("7|k1", like); state != IdempotencyNew {
+		t.Fatalf("first Begin = %v, want new", state)
+	}
+	if state, _ := s.Begin("7|k1", like); state != IdempotencyInProgress {
+		t.Fatalf("Begin while running = %v, want in progress", state)
+	}
+
+	s.Complete("7|k1", IdempotentResponse{Status: 200, Body: []byte(`{"value":1}`)})
+	state, resp := s.Begin("7|k1", like)
+	if state != IdempotencyReplay || resp == nil || string(resp.Body) != `{"value":1}` {
+		t.Fatalf("Begin after Complete = %v, %+v; want replay", state, resp)
+	}
+	if state, _ := s.Begin("7|k1", dislike); state != IdempotencyMismatch {
+		t.Fatalf("Begin with another request = %v, want mismatch", state)
+	}
+
+	now = now.Add(time.Minute)
+	if state, _ := s.Begin("7|k1", dislike); state != IdempotencyNew {
+		t.Fatalf("Begin after ttl = %v, want new", state)
+	}
+
+	s.Release("7|k1")
+	if state, _ := s.Begin("7|k1", like); state != IdempotencyNew {
+		t.Fatalf("Begin after Release = %v, want new", state)
+	}
+}
+
+func TestIdempotencyStore_Disabled(t *testing.T) {
+	s := NewIdempotencyStore(0)
+	s.Begin("7|k1", [32]byte{})
+	s.Complete("7|k1", IdempotentResponse{Status: 200})
+	if state, _ := s.Begin("7|k1", [32]byte{}); state != IdempotencyNew {
+		t.Fatalf("Begin = %v, want new when disabled", state)
+	}
+}
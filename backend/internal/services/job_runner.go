@@ -0,0 +1,108 @@
+package services
+
+import (
+	"context"
+	"log"
+
+	"github.com/alex/opengov-go/internal/constants"
+	"github.com/alex/opengov-go/internal/domain"
+)
+
+// JobStore persists job status. Implemented by repository.JobRepository.
+type JobStore interface {
+	Create(ctx context.Context, jobType, origin string) (*domain.Job, error)
+	MarkRunning(ctx context.Context, id int64) error
+	UpdateCounts(ctx context.Context, id int64, processed, skipped int) error
+	SetTotal(ctx context.Context, id int64, total int) error
+	Finish(ctx context.Context, id int64, status string, errMsg *string) error
+}
+
+// JobFunc is the work a JobRunner tracks. report records the running counts; calling it
+// is optional.
+type JobFunc func(ctx context.Context, report func(processed, skipped int)) error
+
+// JobRunner records a row in the jobs table for each operation it runs and keeps its
+// status up to date. Failures to update the row are logged, never returned, so
+// bookkeeping cannot fail the work itself.
+type JobRunner struct {
+	store  JobStore
+	base   context.Context
+	origin string
+}
+
+// NewJobRunner records jobs as coming from origin (constants.JobOriginAPI or
+// JobOriginJobs). Jobs started in the background run under base, so cancelling it at
+// shutdown stops them.
+func NewJobRunner(base context.Context, origin string, store JobStore) *JobRunner {
+	return &JobRunner{store: store, base: base, origin: origin}
+}
+
+// Run records a job and runs fn in the caller's goroutine, returning fn's error.
+func (r *JobRunner) Run(ctx context.Context, jobType string, fn JobFunc) error {
+	job, err := r.store.Create(ctx, jobType, r.origin)
+	if err != nil {
+		return err
+	}
+	return r.execute(ctx, job, fn)
+}
+
+// Start records a pending job and runs fn in the background. The job outlives the
+// caller, so fn gets the runner's base context rather than ctx.
+func (r *JobRunner) Start(ctx context.Context, jobType string, fn JobFunc) (*domain.Job, error) {
+	job, err := r.store.Create(ctx, jobType, r.origin)
+	if err != nil {
+		return nil, err
+	}
+	r.background(job, fn)
+	return job, nil
+}
+
+// StartCounted is Start for work whose size is known before it begins. total is recorded
+// on the job so its progress reads as processed of total.
+func (r *JobRunner) StartCounted(ctx context.Context, jobType string, total int, fn JobFunc) (*domain.Job, error) {
+	job, err := r.store.Create(ctx, jobType, r.origin)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.store.SetTotal(ctx, job.ID, total); err != nil {
+		log.Printf("Failed to set total for job %d: %v", job.ID, err)
+	} else {
+		job.Total = &total
+	}
+	r.background(job, fn)
+	return job, nil
+}
+
+func (r *JobRunner) background(job *domain.Job, fn JobFunc) {
+	go func() {
+		if err := r.execute(r.base, job, fn); err != nil {
+			log.Printf("Job %d (%s) failed: %v", job.ID, job.Type, err)
+		}
+	}()
+}
+
+func (r *JobRunner) execute(ctx context.Context, job *domain.Job, fn JobFunc) error {
+	if err := r.store.MarkRunning(ctx, job.ID); err != nil {
+		log.Printf("Failed to mark job %d running: %v", job.ID, err)
+	}
+
+	report := func(processed, skipped int) {
+		if err := r.store.UpdateCounts(ctx, job.ID, processed, skipped); err != nil {
+			log.Printf("Failed to update counts for job %d: %v", job.ID, err)
+		}
+	}
+	runErr := fn(ctx, report)
+
+	status := constants.JobStatusSucceeded
+	var errMsg *string
+	if runErr != nil {
+		status = constants.JobStatusFailed
+		msg := runErr.Error()
+		errMsg = &msg
+	}
+	// A cancelled job still gets its final status written.
+	if err := r.store.Finish(context.WithoutCancel(ctx), job.ID, status, errMsg); err != nil {
+		log.Printf("Failed to finish job %d: %v", job.ID, err)
+	}
+	return runErr
+}
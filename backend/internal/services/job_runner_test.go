@@ -0,0 +1,132 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/alex/opengov-go/internal/constants"
+	"github.com/alex/opengov-go/internal/domain"
+)
+
+// fakeJobStore keeps jobs in memory. Guarded by mu because JobRunner.Start updates it
+// from another goroutine.
+type fakeJobStore struct {
+	mu   sync.Mutex
+	jobs []*domain.Job
+}
+
+func (f *fakeJobStore) Create(_ context.Context, jobType, _ string) (*domain.Job, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	j := &domain.Job{ID: int64(len(f.jobs) + 1), Type: jobType, Status: constants.JobStatusPending}
+	f.jobs = append(f.jobs, j)
+	c := *j
+	return &c, nil
+}
+
+func (f *fakeJobStore) MarkRunning(_ context.Context, id int64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.jobs[id-1].Status = constants.JobStatusRunning
+	return nil
+}
+
+func (f *fakeJobStore) UpdateCounts(_ context.Context, id int64, processed, skipped int) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.jobs[id-1].Processed, f.jobs[id-1].Skipped = processed, skipped
+	return nil
+}
+
+func (f *fakeJobStore) SetTotal(_ context.Context, id int64, total int) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.jobs[id-1].Total = &total
+	return nil
+}
+
+func (f *fakeJobStore) Finish(_ context.Context, id int64, status string, errMsg *string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.jobs[id-1].Status, f.jobs[id-1].Error = status, errMsg
+	return nil
+}
+
+func (f *fakeJobStore) job(id int64) domain.Job {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return *f.jobs[id-1]
+}
+
+func TestJobRunner_Run(t *testing.T) {
+	store := &fakeJobStore{}
+	r := NewJobRunner(t.Context(), constants.JobOriginAPI, store)
+
+	err := r.Run(t.Context(), constants.JobTypeScrape, func(_ context.Context, report func(int, int)) error {
+		if got := store.job(1).Status; got != constants.JobStatusRunning {
+			t.Errorf("status while running = %q, want %q", got, constants.JobStatusRunning)
+		}
+		report(3, 2)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+	if j := store.job(1); j.Status != constants.JobStatusSucceeded || j.Processed != 3 || j.Skipped != 2 || j.Error != nil {
+		t.Fatalf("job = %+v, want succeeded with 3 processed and 2 skipped", j)
+	}
+
+	boom := errors.New("upstream down")
+	if err := r.Run(t.Context(), constants.JobTypePipeline, func(context.Context, func(int, int)) error { return boom }); !errors.Is(err, boom) {
+		t.Fatalf("Run() error = %v, want %v", err, boom)
+	}
+	if j := store.job(2); j.Status != constants.JobStatusFailed || j.Error == nil || *j.Error != "upstream down" {
+		t.Fatalf("job = %+v, want failed with the error recorded", j)
+	}
+}
+
+func TestJobRunner_FinishesCancelledJob(t *testing.T) {
+	store := &fakeJobStore{}
+	ctx, cancel := context.WithCancel(t.Context())
+
+	err := NewJobRunner(t.Context(), constants.JobOriginAPI, store).Run(ctx, constants.JobTypeScrape, func(ctx context.Context, _ func(int, int)) error {
+		cancel()
+		return ctx.Err()
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Run() error = %v, want context.Canceled", err)
+	}
+	if got := store.job(1).Status; got != constants.JobStatusFailed {
+		t.Fatalf("status = %q, want %q", got, constants.JobStatusFailed)
+	}
+}
+
+// Background jobs run under the runner's base context, not the caller's, so they stop
+// at shutdown but outlive the request that started them.
+func TestJobRunner_StartRunsUnderBaseContext(t *testing.T) {
+	store := &fakeJobStore{}
+	base, shutdown := context.WithCancel(t.Context())
+	started := make(chan struct{})
+
+	reqCtx, endRequest := context.WithCancel(t.Context())
+	job, err := NewJobRunner(base, constants.JobOriginAPI, store).Start(reqCtx, constants.JobTypeBackfill, func(ctx context.Context, _ func(int, int)) error {
+		close(started)
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	if err != nil {
+		t.Fatalf("Start() error: %v", err)
+	}
+	<-started
+	endRequest()
+	if got := store.job(job.ID).Status; got != constants.JobStatusRunning {
+		t.Fatalf("status after the request ended = %q, want %q", got, constants.JobStatusRunning)
+	}
+
+	shutdown()
+	if j := waitForJob(t, store, job.ID); j.Status != constants.JobStatusFailed || j.Error == nil || *j.Error != context.Canceled.Error() {
+		t.Fatalf("job = %+v, want failed with context canceled", j)
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/alex/opengov-go/internal/domain"
 	"github.com/alex/opengov-go/internal/repository"
 	"github.com/alex/opengov-go/internal/scrape"
+	"github.com/alex/opengov-go/internal/timeformat"
 )
 
 type JobsService struct {
@@ -24,12 +25,21 @@ type JobsService struct {
 	rawRepo    *repository.RawPolicyDocumentRepository
 	docRepo    *repository.PolicyDocumentRepository
 	feedRepo   *repository.FeedRepository
+	stateRepo  *repository.ScrapeStateRepository
 
 	fedregClient  *client.FederalRegisterClient
-	docScrapers   []scrape.PolicyDocumentScraper
+	docScrapers   []scrape.Source
 	agencySyncSvc *AgencySyncService
+
+	enrichPolicy EnrichmentPolicy
+	runner       *JobRunner
+
+	// feedChanged, when set, is called after Materialize writes feed entries.
+	feedChanged func()
 }
 
+// NewJobsService wires the pipeline. calls may be nil; when set, it records requests
+// made by scrapers that build their own clients.
 func NewJobsService(
 	cfg *config.Config,
 	database *db.DB,
@@ -37,7 +47,10 @@ func NewJobsService(
 	rawRepo *repository.RawPolicyDocumentRepository,
 	docRepo *repository.PolicyDocumentRepository,
 	feedRepo *repository.FeedRepository,
+	stateRepo *repository.ScrapeStateRepository,
+	runner *JobRunner,
 	frClient *client.FederalRegisterClient,
+	calls *client.CallLog,
 ) *JobsService {
 	agencySyncSvc := NewAgencySyncService(frClient, agencyRepo)
 
@@ -49,44 +62,204 @@ func NewJobsService(
 		rawRepo:    rawRepo,
 		docRepo:    docRepo,
 		feedRepo:   feedRepo,
+		stateRepo:  stateRepo,
 
 		fedregClient:  frClient,
-		docScrapers:   []scrape.PolicyDocumentScraper{scrape.NewFedregScraper(frClient)},
+		docScrapers:   scrape.Sources(scrape.Deps{Config: cfg, FederalRegister: frClient, Calls: calls}),
 		agencySyncSvc: agencySyncSvc,
+
+		enrichPolicy: NewEnrichmentPolicy(cfg),
+		runner:       runner,
 	}
 }
 
-func (s *JobsService) Migrate() error {
-	return s.db.RunMigrations()
+// Migrate applies pending schema migrations and returns the names of those it applied.
+func (s *JobsService) Migrate(ctx context.Context) ([]string, error) {
+	return s.db.MigrateUp(ctx)
 }
 
 func (s *JobsService) SyncAgencies(ctx context.Context) (int, error) {
 	return s.agencySyncSvc.SyncAgencies(ctx)
 }
 
-// ScrapeRaw ingests raw upstream JSON into raw_policy_documents with no policy_document_id.
+// ScrapeRaw ingests raw upstream JSON from every registered source into
+// raw_policy_documents with no policy_document_id. Each source only requests documents
+// published since its stored high-water mark, falling back to the configured lookback
+// window when it has never been scraped. The run is recorded as a scrape job.
 func (s *JobsService) ScrapeRaw(ctx context.Context) (processed int, skipped int, err error) {
+	err = s.runner.Run(ctx, constants.JobTypeScrape, func(ctx context.Context, report func(processed, skipped int)) error {
+		var err error
+		processed, skipped, err = s.scrapeRaw(ctx)
+		report(processed, skipped)
+		return err
+	})
+	return processed, skipped, err
+}
+
+func (s *JobsService) scrapeRaw(ctx context.Context) (processed int, skipped int, err error) {
 	log.Println("Starting raw ingestion scrape...")
 
-	tx, err := s.db.BeginTx(ctx, nil)
+	fetchedAt := time.Now().UTC()
+	for _, src := range s.docScrapers {
+		p, sk, err := s.scrapeSource(ctx, src, fetchedAt)
+		processed += p
+		skipped += sk
+		if err != nil {
+			return processed, skipped, err
+		}
+	}
+
+	log.Printf("Raw ingestion completed. Inserted: %d, Skipped: %d", processed, skipped)
+	return processed, skipped, nil
+}
+
+func (s *JobsService) scrapeSource(ctx context.Context, src scrape.Source, fetchedAt time.Time) (processed int, skipped int, err error) {
+	state, err := s.stateRepo.Get(ctx, src.Key)
 	if err != nil {
-		return 0, 0, fmt.Errorf("failed to begin transaction: %w", err)
+		return 0, 0, err
 	}
-	defer tx.Rollback()
+
+	since := scrapeSince(state, fetchedAt, s.cfg.ScraperDaysLookback)
+	results, err := src.Scraper.Scrape(ctx, since)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to scrape %s documents: %w", src.Key, err)
+	}
+	var mark time.Time
+	if state != nil {
+		mark = state.LastPublishedAt
+	}
+	mark = latestPublication(mark, results, s.cfg.PublicationLocation)
+
+	processed, skipped, err = s.storeRaw(ctx, src.Key, results, map[string]bool{}, fetchedAt)
+	if err != nil {
+		return processed, skipped, err
+	}
+
+	// The mark only advances once every batch it covers is committed. After a partial
+	// run the next one re-requests from the old mark and skips what was already stored.
+	// An empty run still records last_run_at, so the scraper health check can tell a
+	// quiet day (weekends, holidays) from a stall.
+	if !mark.IsZero() {
+		documents := len(results)
+		next := &domain.ScrapeState{
+			SourceKey:        src.Key,
+			LastPublishedAt:  mark,
+			LastRunAt:        fetchedAt,
+			LastRunDocuments: &documents,
+		}
+		if err := s.stateRepo.Set(ctx, next); err != nil {
+			return processed, skipped, err
+		}
+	}
+
+	if len(results) == 0 {
+		log.Printf("Scraped %s since %s. No new documents", src.Key, since.Format(timeformat.Date))
+	} else {
+		log.Printf("Scraped %s since %s. Inserted: %d, Skipped: %d", src.Key, since.Format(timeformat.Date), processed, skipped)
+	}
+	return processed, skipped, nil
+}
+
+// scrapeSince returns the earliest publication date to request. The high-water mark's
+// own day is requested again: documents can still be added to a day after a run has
+// seen part of it, and rows already ingested are skipped on conflict.
+func scrapeSince(state *domain.ScrapeState, now time.Time, lookbackDays int) time.Time {
+	if state == nil {
+		return now.AddDate(0, 0, -lookbackDays)
+	}
+	return state.LastPublishedAt
+}
+
+// latestPublication returns the later of mark and the newest publication date in
+// results. Unparseable dates are ignored; canonicalization reports them.
+func latestPublication(mark time.Time, results []scrape.ScrapeResult, loc *time.Location) time.Time {
+	for _, r := range results {
+		published, err := timeformat.ParsePublicationDate(r.PolicyDocument.PublicationDate, loc)
+		if err != nil {
+			continue
+		}
+		if published.After(mark) {
+			mark = published
+		}
+	}
+	return mark
+}
+
+// Backfill ingests every document published between start and end, inclusive, then
+// canonicalizes and materializes them so they reach the feed. Unlike ScrapeRaw it ignores
+// the page limit and leaves the scrape_state high-water mark alone.
+func (s *JobsService) Backfill(ctx context.Context, start, end time.Time) (processed int, skipped int, err error) {
+	log.Printf("Starting backfill %s to %s...", start.Format(timeformat.Date), end.Format(timeformat.Date))
 
 	fetchedAt := time.Now().UTC()
+	for _, src := range s.docScrapers {
+		results, err := src.Scraper.ScrapeRange(ctx, start, end)
+		if err != nil {
+			return processed, skipped, fmt.Errorf("failed to scrape %s documents: %w", src.Key, err)
+		}
 
-	for _, retriever := range s.docScrapers {
-		results, err := retriever.Scrape(ctx, s.cfg.ScraperDaysLookback)
+		p, sk, err := s.storeRaw(ctx, src.Key, results, map[string]bool{}, fetchedAt)
+		processed += p
+		skipped += sk
 		if err != nil {
-			return processed, skipped, fmt.Errorf("failed to scrape documents: %w", err)
+			return processed, skipped, err
 		}
+	}
 
-		for _, r := range results {
-			ins, err := s.rawRepo.Create(ctx, tx, constants.SourceTypeFederalRegister, r.PolicyDocument.DocumentNumber, r.RawResult, fetchedAt, nil)
-			if err != nil {
-				return processed, skipped, err
-			}
+	if _, _, err := s.Canonicalize(ctx, 200); err != nil {
+		return processed, skipped, err
+	}
+	if _, err := s.Materialize(ctx, 500); err != nil {
+		return processed, skipped, err
+	}
+
+	log.Printf("Backfill completed. Inserted: %d, Skipped: %d", processed, skipped)
+	return processed, skipped, nil
+}
+
+// rawCommitBatchSize is how many raw rows are inserted per transaction, so a failure
+// late in a run keeps the earlier batches and no transaction holds locks for long.
+const rawCommitBatchSize = 100
+
+// storeRaw writes results to raw_policy_documents under sourceKey, committing every
+// rawCommitBatchSize rows. Documents already in seen or already stored count as skipped.
+func (s *JobsService) storeRaw(ctx context.Context, sourceKey string, results []scrape.ScrapeResult, seen map[string]bool, fetchedAt time.Time) (processed int, skipped int, err error) {
+	batch, dups := rawBatch(results, seen)
+	processed, skipped, err = commitInBatches(ctx, batch, rawCommitBatchSize, func(ctx context.Context, rows []repository.RawPolicyDocumentInput) ([]bool, error) {
+		return s.commitRaw(ctx, sourceKey, rows, fetchedAt)
+	})
+	return processed, skipped + dups, err
+}
+
+func (s *JobsService) commitRaw(ctx context.Context, sourceKey string, rows []repository.RawPolicyDocumentInput, fetchedAt time.Time) ([]bool, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	inserted, err := s.rawRepo.CreateBatch(ctx, tx, sourceKey, rows, fetchedAt)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit raw ingestion batch: %w", err)
+	}
+	return inserted, nil
+}
+
+// commitInBatches hands rows to commit size at a time. It stops at the first error or
+// once ctx is done; batches committed before that are kept and counted.
+func commitInBatches(ctx context.Context, rows []repository.RawPolicyDocumentInput, size int, commit func(context.Context, []repository.RawPolicyDocumentInput) ([]bool, error)) (processed int, skipped int, err error) {
+	for start := 0; start < len(rows); start += size {
+		if err := ctx.Err(); err != nil {
+			return processed, skipped, err
+		}
+		inserted, err := commit(ctx, rows[start:min(start+size, len(rows))])
+		if err != nil {
+			return processed, skipped, err
+		}
+		for _, ins := range inserted {
 			if ins {
 				processed++
 			} else {
@@ -94,25 +267,40 @@ func (s *JobsService) ScrapeRaw(ctx context.Context) (processed int, skipped int
 			}
 		}
 	}
+	return processed, skipped, nil
+}
 
-	if err := tx.Commit(); err != nil {
-		return processed, skipped, fmt.Errorf("failed to commit raw ingestion: %w", err)
+// rawBatch converts scrape results to raw rows, dropping documents already in seen so a
+// document returned on overlapping pages is only sent to the database once per run.
+// Kept documents are added to seen.
+func rawBatch(results []scrape.ScrapeResult, seen map[string]bool) (batch []repository.RawPolicyDocumentInput, dups int) {
+	batch = make([]repository.RawPolicyDocumentInput, 0, len(results))
+	for _, r := range results {
+		id := r.PolicyDocument.DocumentNumber
+		if seen[id] {
+			dups++
+			continue
+		}
+		seen[id] = true
+		batch = append(batch, repository.RawPolicyDocumentInput{ExternalID: id, RawData: r.RawResult})
 	}
-
-	log.Printf("Raw ingestion completed. Inserted: %d, Skipped: %d", processed, skipped)
-	return processed, skipped, nil
+	return batch, dups
 }
 
-func (s *JobsService) Canonicalize(ctx context.Context, batchSize int) (linked int, err error) {
+// Canonicalize links unlinked raw rows to policy documents. A row whose payload cannot
+// be parsed is marked failed and skipped so it does not block the rest; database errors
+// still abort the run.
+func (s *JobsService) Canonicalize(ctx context.Context, batchSize int) (linked int, failed int, err error) {
 	if batchSize <= 0 {
 		batchSize = 200
 	}
 
 	log.Println("Starting canonicalization...")
+	agencies := newAgencyResolver(s.agencyRepo)
 	for {
 		rows, err := s.rawRepo.ListUnlinked(ctx, batchSize)
 		if err != nil {
-			return linked, err
+			return linked, failed, err
 		}
 		if len(rows) == 0 {
 			break
@@ -121,35 +309,133 @@ func (s *JobsService) Canonicalize(ctx context.Context, batchSize int) (linked i
 		for _, raw := range rows {
 			select {
 			case <-ctx.Done():
-				return linked, ctx.Err()
+				return linked, failed, ctx.Err()
 			default:
 			}
 
-			if _, err := s.canonicalizeOne(ctx, raw); err != nil {
-				return linked, err
+			row, err := canonicalDocument(raw, s.cfg.PublicationLocation, s.cfg.FallbackSummaryTemplate)
+			if err != nil {
+				log.Printf("Skipping raw_policy_documents(%d): %v", raw.ID, err)
+				if err := s.rawRepo.MarkFailed(ctx, raw.ID, err.Error()); err != nil {
+					return linked, failed, err
+				}
+				failed++
+				continue
+			}
+			if len(row.agencies) > 0 {
+				if row.doc.AgencyID, err = agencies.resolve(ctx, row.agencies[0]); err != nil {
+					return linked, failed, err
+				}
+			}
+			docAgencies, err := agencies.resolveAll(ctx, row.agencies)
+			if err != nil {
+				return linked, failed, err
+			}
+			if _, err := s.canonicalizeOne(ctx, raw.ID, row.doc, row.refs, docAgencies); err != nil {
+				return linked, failed, err
 			}
 			linked++
 		}
 	}
 
-	log.Printf("Canonicalization completed. Linked: %d", linked)
-	return linked, nil
+	log.Printf("Canonicalization completed. Linked: %d, Failed: %d", linked, failed)
+	return linked, failed, nil
 }
 
-func (s *JobsService) canonicalizeOne(ctx context.Context, raw repository.UnlinkedRawPolicyDocumentRow) (policyDocID int64, err error) {
-	var frDoc client.FederalRegisterDocument
-	if err := json.Unmarshal(raw.RawData, &frDoc); err != nil {
-		return 0, fmt.Errorf("failed to unmarshal raw_policy_documents(%d) into federal register document: %w", raw.ID, err)
+// Dedupe merges documents that arrived from more than one source. Each copy found by
+// PolicyDocumentRepository.FindSimilar has its raw rows moved to the earliest document
+// and is marked a duplicate of it, which hides it from the feed. Matching is
+// conservative, so near-misses are left alone rather than risk merging distinct policies.
+func (s *JobsService) Dedupe(ctx context.Context, batchSize int) (merged int, err error) {
+	if batchSize <= 0 {
+		batchSize = 200
+	}
+
+	log.Println("Starting dedupe...")
+	defer func() {
+		if merged > 0 && s.feedChanged != nil {
+			s.feedChanged()
+		}
+	}()
+	for {
+		dups, err := s.docRepo.FindSimilar(ctx, batchSize)
+		if err != nil {
+			return merged, err
+		}
+		if len(dups) == 0 {
+			break
+		}
+
+		for _, d := range dups {
+			select {
+			case <-ctx.Done():
+				return merged, ctx.Err()
+			default:
+			}
+
+			if err := s.mergeDuplicate(ctx, d.ID, d.CanonicalID); err != nil {
+				return merged, err
+			}
+			log.Printf("Merged policy_documents(%d) into %d", d.ID, d.CanonicalID)
+			merged++
+		}
 	}
 
-	publishedAt, err := time.Parse("2006-01-02", frDoc.PublicationDate)
+	log.Printf("Dedupe completed. Merged: %d", merged)
+	return merged, nil
+}
+
+func (s *JobsService) mergeDuplicate(ctx context.Context, id, canonicalID int64) error {
+	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
-		return 0, fmt.Errorf("invalid publication_date for raw_policy_documents(%d): %w", raw.ID, err)
+		return fmt.Errorf("failed to begin dedupe tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := s.rawRepo.RelinkPolicyDocument(ctx, tx, id, canonicalID); err != nil {
+		return err
+	}
+	if err := s.docRepo.MarkDuplicate(ctx, tx, id, canonicalID); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit dedupe tx: %w", err)
+	}
+	return nil
+}
+
+// RetryFailed requeues every raw row parked by Canonicalize and returns how many it
+// requeued. They are processed on the next canonicalization.
+func (s *JobsService) RetryFailed(ctx context.Context) (int64, error) {
+	return s.rawRepo.RetryAll(ctx)
+}
+
+// canonicalRow is a raw row parsed for canonicalization.
+type canonicalRow struct {
+	doc  *domain.PolicyDocument
+	refs []domain.CFRRef
+	// agencies are the upstream agencies in order; the first is the document's own.
+	agencies []client.FRAgency
+}
+
+// canonicalDocument builds the policy document and CFR references for a raw row. An
+// error means the payload itself is unusable. The document's AgencyID is left for the
+// caller to resolve. Federal Register documents get a summary rendered from
+// fallbackTmpl until enrichment replaces it.
+func canonicalDocument(raw repository.UnlinkedRawPolicyDocumentRow, loc *time.Location, fallbackTmpl string) (*canonicalRow, error) {
+	if raw.SourceKey == constants.SourceTypeCongress {
+		return canonicalBill(raw, loc)
+	}
+
+	var frDoc client.FederalRegisterDocument
+	if err := json.Unmarshal(raw.RawData, &frDoc); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal raw_policy_documents(%d) into federal register document: %w", raw.ID, err)
 	}
 
-	summary := derivePlaceholderSummary(frDoc)
-	if summary == "" {
-		summary = "Pending summary."
+	publishedAt, err := timeformat.ParsePublicationDate(frDoc.PublicationDate, loc)
+	if err != nil {
+		return nil, fmt.Errorf("invalid publication_date for raw_policy_documents(%d): %w", raw.ID, err)
 	}
 
 	var agencyPtr *string
@@ -164,7 +450,7 @@ func (s *JobsService) canonicalizeOne(ctx context.Context, raw repository.Unlink
 		FetchedAt:      raw.FetchedAt,
 		Title:          frDoc.Title,
 		Agency:         agencyPtr,
-		Summary:        summary,
+		Abstract:       frDoc.Abstract,
 		Keypoints:      nil,
 		ImpactScore:    nil,
 		PoliticalScore: nil,
@@ -173,7 +459,11 @@ func (s *JobsService) canonicalizeOne(ctx context.Context, raw repository.Unlink
 		DocumentType:   &frDoc.Type,
 		PDFURL:         frDoc.PDFURL,
 	}
+	doc.Summary = FallbackSummary(fallbackTmpl, doc)
+	return &canonicalRow{doc: doc, refs: cfrRefs(frDoc.CFRReferences), agencies: frDoc.Agencies}, nil
+}
 
+func (s *JobsService) canonicalizeOne(ctx context.Context, rawID int64, doc *domain.PolicyDocument, refs []domain.CFRRef, agencies []domain.DocumentAgency) (policyDocID int64, err error) {
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
 		return 0, fmt.Errorf("failed to begin canonicalization tx: %w", err)
@@ -185,7 +475,15 @@ func (s *JobsService) canonicalizeOne(ctx context.Context, raw repository.Unlink
 		return 0, err
 	}
 
-	if err := s.rawRepo.LinkToPolicyDocument(ctx, tx, raw.ID, id); err != nil {
+	if err := s.docRepo.ReplaceCFRRefs(ctx, tx, id, refs); err != nil {
+		return 0, err
+	}
+
+	if err := s.docRepo.ReplaceAgencies(ctx, tx, id, agencies); err != nil {
+		return 0, err
+	}
+
+	if err := s.rawRepo.LinkToPolicyDocument(ctx, tx, rawID, id); err != nil {
 		return 0, err
 	}
 
@@ -196,19 +494,19 @@ func (s *JobsService) canonicalizeOne(ctx context.Context, raw repository.Unlink
 	return id, nil
 }
 
-func derivePlaceholderSummary(frDoc client.FederalRegisterDocument) string {
-	// Mirror legacy behavior: prefer excerpts over abstract, truncate to ~1000 chars.
-	s := ""
-	if frDoc.Abstract != nil {
-		s = *frDoc.Abstract
-	}
-	if frDoc.Excerpts != nil && *frDoc.Excerpts != "" {
-		s = *frDoc.Excerpts
-	}
-	if len(s) > 1000 {
-		s = s[:1000]
+// cfrRefs keeps the references with a valid title (1-50) and part, dropping repeats.
+func cfrRefs(in []client.FRCFRRef) []domain.CFRRef {
+	seen := map[domain.CFRRef]bool{}
+	var out []domain.CFRRef
+	for _, r := range in {
+		ref := domain.CFRRef{Title: r.Title, Part: r.Part}
+		if ref.Title < 1 || ref.Title > 50 || ref.Part < 1 || seen[ref] {
+			continue
+		}
+		seen[ref] = true
+		out = append(out, ref)
 	}
-	return s
+	return out
 }
 
 func needsEnrichment(d *domain.PolicyDocument) bool {
@@ -223,12 +521,14 @@ func needsEnrichment(d *domain.PolicyDocument) bool {
 
 // Enrich is the enrichment stage. For now, it is implemented as a dry-run and does not
 // call any external AI APIs or write any changes. It reports how many documents would
-// be enriched based on missing AI fields.
+// be enriched based on missing AI fields, and how many of those the enrichment policy
+// would send for full analysis.
 func (s *JobsService) Enrich(ctx context.Context, batchSize int) (wouldEnrich int, err error) {
 	if batchSize <= 0 {
 		batchSize = 200
 	}
 
+	wouldAnalyze := 0
 	log.Println("Starting enrichment (dry-run; no writes)...")
 	for {
 		docs, err := s.docRepo.ListNeedingEnrichment(ctx, batchSize)
@@ -249,6 +549,9 @@ func (s *JobsService) Enrich(ctx context.Context, batchSize int) (wouldEnrich in
 			// Guardrail: ensure the in-memory predicate matches expectations too.
 			if needsEnrichment(d) {
 				wouldEnrich++
+				if s.enrichPolicy.WantsFullAnalysis(d) {
+					wouldAnalyze++
+				}
 			}
 		}
 
@@ -257,10 +560,16 @@ func (s *JobsService) Enrich(ctx context.Context, batchSize int) (wouldEnrich in
 		break
 	}
 
-	log.Printf("Enrichment dry-run completed. Would enrich: %d", wouldEnrich)
+	log.Printf("Enrichment dry-run completed. Would enrich: %d (full analysis: %d, fallback: %d)", wouldEnrich, wouldAnalyze, wouldEnrich-wouldAnalyze)
 	return wouldEnrich, nil
 }
 
+// OnFeedChanged registers fn to run after materialization writes feed entries, e.g. to
+// drop cached feed pages.
+func (s *JobsService) OnFeedChanged(fn func()) {
+	s.feedChanged = fn
+}
+
 func (s *JobsService) Materialize(ctx context.Context, batchSize int) (upserted int, err error) {
 	if batchSize <= 0 {
 		batchSize = 500
@@ -310,27 +619,55 @@ func (s *JobsService) Materialize(ctx context.Context, batchSize int) (upserted
 			_ = tx.Rollback()
 			return upserted, fmt.Errorf("failed to commit materialization tx: %w", err)
 		}
+		if s.feedChanged != nil {
+			s.feedChanged()
+		}
 	}
 
 	log.Printf("Materialization completed. Upserted: %d", upserted)
 	return upserted, nil
 }
 
-func (s *JobsService) Pipeline(ctx context.Context) error {
-	if _, err := s.SyncAgencies(ctx); err != nil {
-		return err
-	}
-	if _, _, err := s.ScrapeRaw(ctx); err != nil {
-		return err
-	}
-	if _, err := s.Canonicalize(ctx, 200); err != nil {
-		return err
-	}
-	if _, err := s.Enrich(ctx, 200); err != nil {
-		return err
-	}
-	if _, err := s.Materialize(ctx, 500); err != nil {
-		return err
-	}
-	return nil
+// PipelineMetrics counts what each pipeline stage did. On failure it holds the counts
+// from the stages that completed.
+type PipelineMetrics struct {
+	AgenciesSynced     int
+	RawInserted        int
+	RawSkipped         int
+	Canonicalized      int
+	CanonicalizeFailed int // raw rows parked as failed; see RawPolicyDocumentRepository.ListFailed
+	Deduped            int // documents merged into a copy from another source
+	Enriched           int // enrichment is a dry run, so this is the number that would be enriched
+	Materialized       int
+}
+
+// Pipeline runs every stage in order, recorded as a single pipeline job whose counts are
+// the raw documents inserted and skipped.
+func (s *JobsService) Pipeline(ctx context.Context) (PipelineMetrics, error) {
+	var m PipelineMetrics
+	err := s.runner.Run(ctx, constants.JobTypePipeline, func(ctx context.Context, report func(processed, skipped int)) error {
+		var err error
+		if m.AgenciesSynced, err = s.SyncAgencies(ctx); err != nil {
+			return err
+		}
+		m.RawInserted, m.RawSkipped, err = s.scrapeRaw(ctx)
+		report(m.RawInserted, m.RawSkipped)
+		if err != nil {
+			return err
+		}
+		if m.Canonicalized, m.CanonicalizeFailed, err = s.Canonicalize(ctx, 200); err != nil {
+			return err
+		}
+		if m.Deduped, err = s.Dedupe(ctx, 200); err != nil {
+			return err
+		}
+		if m.Enriched, err = s.Enrich(ctx, 200); err != nil {
+			return err
+		}
+		if m.Materialized, err = s.Materialize(ctx, 500); err != nil {
+			return err
+		}
+		return nil
+	})
+	return m, err
 }
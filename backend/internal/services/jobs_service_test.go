@@ -1,38 +1,21 @@
 package services
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/alex/opengov-go/internal/client"
+	"github.com/alex/opengov-go/internal/constants"
 	"github.com/alex/opengov-go/internal/domain"
+	"github.com/alex/opengov-go/internal/repository"
+	"github.com/alex/opengov-go/internal/scrape"
+	"github.com/alex/opengov-go/internal/transport"
 )
 
-func TestDerivePlaceholderSummary_PrefersExcerptsOverAbstract(t *testing.T) {
-	abs := "abstract text"
-	ex := "excerpts text"
-	doc := client.FederalRegisterDocument{
-		Abstract: &abs,
-		Excerpts: &ex,
-	}
-	if got := derivePlaceholderSummary(doc); got != ex {
-		t.Fatalf("expected excerpts %q, got %q", ex, got)
-	}
-}
-
-func TestDerivePlaceholderSummary_Truncates(t *testing.T) {
-	long := make([]byte, 1500)
-	for i := range long {
-		long[i] = 'a'
-	}
-	s := string(long)
-	doc := client.FederalRegisterDocument{Excerpts: &s}
-	got := derivePlaceholderSummary(doc)
-	if len(got) != 1000 {
-		t.Fatalf("expected 1000 chars, got %d", len(got))
-	}
-}
-
 func TestCanonicalize_UnmarshalCompatibility(t *testing.T) {
 	// Guardrail: the raw stored JSON created by client.Scrape (marshaled FederalRegisterDocument)
 	// must still unmarshal into FederalRegisterDocument for canonicalization.
@@ -104,3 +87,195 @@ func TestNeedsEnrichment(t *testing.T) {
 		})
 	}
 }
+
+func TestRawBatch_SkipsDuplicatesWithinRun(t *testing.T) {
+	result := func(num string) scrape.ScrapeResult {
+		return scrape.ScrapeResult{
+			PolicyDocument: transport.ScrapedPolicyDocument{DocumentNumber: num},
+			RawResult:      []byte(`{"document_number":"` + num + `"}`),
+		}
+	}
+	seen := map[string]bool{}
+
+	// Overlapping pages returned 2025-00002 twice.
+	batch, dups := rawBatch([]scrape.ScrapeResult{result("2025-00001"), result("2025-00002"), result("2025-00002")}, seen)
+	if len(batch) != 2 || dups != 1 {
+		t.Fatalf("batch = %d rows with %d dups, want 2 rows and 1 dup", len(batch), dups)
+	}
+	if batch[0].ExternalID != "2025-00001" || batch[1].ExternalID != "2025-00002" {
+		t.Fatalf("unexpected batch order: %+v", batch)
+	}
+
+	// A later scraper in the same run is deduplicated against the earlier one.
+	batch, dups = rawBatch([]scrape.ScrapeResult{result("2025-00002"), result("2025-00003")}, seen)
+	if len(batch) != 1 || dups != 1 || batch[0].ExternalID != "2025-00003" {
+		t.Fatalf("batch = %+v with %d dups, want only 2025-00003", batch, dups)
+	}
+}
+
+func TestCFRRefs(t *testing.T) {
+	got := cfrRefs([]client.FRCFRRef{
+		{Title: 40, Part: 52},
+		{Title: 40, Part: 52},
+		{Title: 40, Part: 81},
+		{Title: 0, Part: 1},
+		{Title: 51, Part: 1},
+		{Title: 21, Part: 0},
+	})
+	want := []domain.CFRRef{{Title: 40, Part: 52}, {Title: 40, Part: 81}}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Fatalf("cfrRefs = %v, want %v", got, want)
+	}
+}
+
+func TestCanonicalize_UnmarshalsCFRReferences(t *testing.T) {
+	raw := []byte(`{"document_number":"2025-01234","cfr_references":[{"title":40,"part":52,"chapter":1,"citation_url":"https://example"}]}`)
+	var frDoc client.FederalRegisterDocument
+	if err := json.Unmarshal(raw, &frDoc); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if len(frDoc.CFRReferences) != 1 || frDoc.CFRReferences[0] != (client.FRCFRRef{Title: 40, Part: 52}) {
+		t.Fatalf("CFRReferences = %+v", frDoc.CFRReferences)
+	}
+}
+
+func TestScrapeSince(t *testing.T) {
+	now := time.Date(2025, 3, 10, 15, 0, 0, 0, time.UTC)
+
+	if got, want := scrapeSince(nil, now, 3), now.AddDate(0, 0, -3); !got.Equal(want) {
+		t.Fatalf("first run: scrapeSince = %v, want lookback %v", got, want)
+	}
+
+	mark := time.Date(2025, 3, 7, 0, 0, 0, 0, time.UTC)
+	state := &domain.ScrapeState{LastPublishedAt: mark, LastRunAt: now.AddDate(0, 0, -1)}
+	if got := scrapeSince(state, now, 3); !got.Equal(mark) {
+		t.Fatalf("scrapeSince = %v, want high-water mark %v", got, mark)
+	}
+}
+
+func TestLatestPublication(t *testing.T) {
+	result := func(date string) scrape.ScrapeResult {
+		return scrape.ScrapeResult{PolicyDocument: transport.ScrapedPolicyDocument{PublicationDate: date}}
+	}
+	prev := time.Date(2025, 3, 7, 0, 0, 0, 0, time.UTC)
+
+	got := latestPublication(prev, []scrape.ScrapeResult{result("2025-03-09"), result("not-a-date"), result("2025-03-08")}, time.UTC)
+	if want := time.Date(2025, 3, 9, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Fatalf("latestPublication = %v, want %v", got, want)
+	}
+
+	// The mark never moves backwards.
+	if got := latestPublication(prev, []scrape.ScrapeResult{result("2025-03-01")}, time.UTC); !got.Equal(prev) {
+		t.Fatalf("latestPublication = %v, want unchanged %v", got, prev)
+	}
+}
+
+func TestCanonicalDocument(t *testing.T) {
+	row := func(raw string) repository.UnlinkedRawPolicyDocumentRow {
+		return repository.UnlinkedRawPolicyDocumentRow{ID: 9, SourceKey: "federal_register", ExternalID: "2025-01234", RawData: []byte(raw)}
+	}
+
+	got, err := canonicalDocument(row(`{"title":"Ozone","publication_date":"2025-03-10","cfr_references":[{"title":40,"part":52}],"agencies":[{"id":145,"name":"Environmental Protection Agency","slug":"environmental-protection-agency"}]}`), time.UTC, "{agency} issued {type}: {title}.")
+	if err != nil {
+		t.Fatalf("canonicalDocument() error: %v", err)
+	}
+	doc := got.doc
+	if doc.Title != "Ozone" || !doc.PublishedAt.Equal(time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)) || len(got.refs) != 1 {
+		t.Fatalf("doc = %+v, refs = %v", doc, got.refs)
+	}
+	if len(got.agencies) != 1 || got.agencies[0].ID != 145 || doc.Agency == nil || *doc.Agency != "Environmental Protection Agency" {
+		t.Fatalf("agencies = %+v, doc.Agency = %v", got.agencies, doc.Agency)
+	}
+	if doc.Summary != "Environmental Protection Agency issued a document: Ozone." {
+		t.Fatalf("summary = %q", doc.Summary)
+	}
+
+	for name, raw := range map[string]string{
+		"malformed json":       `{"title":`,
+		"bad publication_date": `{"title":"Ozone","publication_date":"03/10/2025"}`,
+	} {
+		if _, err := canonicalDocument(row(raw), time.UTC, "{agency} issued {type}: {title}."); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestCommitInBatches_KeepsBatchesBeforeError(t *testing.T) {
+	rows := make([]repository.RawPolicyDocumentInput, 250)
+	for i := range rows {
+		rows[i].ExternalID = fmt.Sprintf("2025-%05d", i)
+	}
+
+	// The third batch fails; the first two stay committed.
+	var committed []string
+	boom := errors.New("connection reset")
+	processed, skipped, err := commitInBatches(t.Context(), rows, 100, func(_ context.Context, batch []repository.RawPolicyDocumentInput) ([]bool, error) {
+		if len(committed) == 200 {
+			return nil, boom
+		}
+		inserted := make([]bool, len(batch))
+		for i, r := range batch {
+			committed = append(committed, r.ExternalID)
+			inserted[i] = i%10 != 0 // every tenth row already existed
+		}
+		return inserted, nil
+	})
+	if !errors.Is(err, boom) {
+		t.Fatalf("err = %v, want %v", err, boom)
+	}
+	if len(committed) != 200 || processed != 180 || skipped != 20 {
+		t.Fatalf("committed %d rows, processed=%d skipped=%d; want 200, 180, 20", len(committed), processed, skipped)
+	}
+}
+
+func TestCommitInBatches_StopsAfterCancellation(t *testing.T) {
+	rows := make([]repository.RawPolicyDocumentInput, 30)
+	ctx, cancel := context.WithCancel(t.Context())
+
+	calls := 0
+	processed, _, err := commitInBatches(ctx, rows, 10, func(_ context.Context, batch []repository.RawPolicyDocumentInput) ([]bool, error) {
+		calls++
+		cancel()
+		inserted := make([]bool, len(batch))
+		for i := range inserted {
+			inserted[i] = true
+		}
+		return inserted, nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+	if calls != 1 || processed != 10 {
+		t.Fatalf("calls = %d, processed = %d; want the first batch kept and no more attempted", calls, processed)
+	}
+}
+
+func TestCanonicalDocument_CongressBill(t *testing.T) {
+	raw := repository.UnlinkedRawPolicyDocumentRow{
+		ID: 11, SourceKey: constants.SourceTypeCongress, ExternalID: "119-hr-1234",
+		RawData: []byte(`{"congress":119,"type":"HR","number":"1234","title":"Clean Water Act Amendments","originChamber":"House","latestAction":{"actionDate":"2025-03-04","text":"Referred to the Committee on Transportation."},"updateDate":"2025-03-05"}`),
+	}
+
+	got, err := canonicalDocument(raw, time.UTC, "{agency} issued {type}: {title}.")
+	if err != nil {
+		t.Fatalf("canonicalDocument() error: %v", err)
+	}
+	doc := got.doc
+	if doc.Title != "Clean Water Act Amendments" || !doc.PublishedAt.Equal(time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("doc = %+v", doc)
+	}
+	if doc.SourceURL != "https://www.congress.gov/bill/119th-congress/house-bill/1234" || doc.DocumentType == nil || *doc.DocumentType != "Bill" {
+		t.Fatalf("source_url = %q, document_type = %v", doc.SourceURL, doc.DocumentType)
+	}
+	if doc.Summary != "Latest action: Referred to the Committee on Transportation." {
+		t.Fatalf("summary = %q", doc.Summary)
+	}
+	if doc.Agency == nil || *doc.Agency != "House of Representatives" || len(got.agencies) != 1 {
+		t.Fatalf("agency = %v, agencies = %+v", doc.Agency, got.agencies)
+	}
+
+	raw.RawData = []byte(`{"congress":119,"type":"S","title":"No number"}`)
+	if _, err := canonicalDocument(raw, time.UTC, "{agency} issued {type}: {title}."); err == nil {
+		t.Error("bill without a number: expected an error")
+	}
+}
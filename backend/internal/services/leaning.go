@@ -0,0 +1,44 @@
+package services
+
+import (
+	"context"
+	"errors"
+)
+
+var ErrNoPoliticalLeaning = errors.New("profile has no left or right political leaning")
+
+// leaningSides places each profile political_leaning on the political_score scale:
+// -1 for left, 1 for right. Leanings missing here, such as libertarian, have no clear
+// side.
+var leaningSides = map[string]int{
+	"democrat":      -1,
+	"socialist":     -1,
+	"republican":    1,
+	"maga":          1,
+	"america_first": 1,
+}
+
+func leaningSide(leaning *string) int {
+	if leaning == nil {
+		return 0
+	}
+	return leaningSides[*leaning]
+}
+
+// OppositeSide returns the side of the political_score scale counter to the user's
+// stated leaning, for use as repository.FeedFilter.PoliticalSide. It returns
+// ErrNoPoliticalLeaning when the profile's leaning has no clear side.
+func (s *FeedService) OppositeSide(ctx context.Context, userID int64) (int, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	if user == nil {
+		return 0, ErrNoPoliticalLeaning
+	}
+	side := leaningSide(user.PoliticalLeaning)
+	if side == 0 {
+		return 0, ErrNoPoliticalLeaning
+	}
+	return -side, nil
+}
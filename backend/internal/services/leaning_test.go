@@ -0,0 +1,30 @@
+package services
+
+import "testing"
+
+func TestLeaningSide(t *testing.T) {
+	str := func(s string) *string { return &s }
+
+	cases := []struct {
+		leaning *string
+		want    int
+	}{
+		{nil, 0},
+		{str(""), 0},
+		{str("libertarian"), 0},
+		{str("democrat"), -1},
+		{str("socialist"), -1},
+		{str("republican"), 1},
+		{str("maga"), 1},
+		{str("america_first"), 1},
+	}
+	for _, tc := range cases {
+		if got := leaningSide(tc.leaning); got != tc.want {
+			name := "<nil>"
+			if tc.leaning != nil {
+				name = *tc.leaning
+			}
+			t.Errorf("leaningSide(%q) = %d, want %d", name, got, tc.want)
+		}
+	}
+}
@@ -2,20 +2,52 @@ package services
 
 import (
 	"context"
+	"hash/fnv"
+	"slices"
+	"strings"
+
+	"github.com/alex/opengov-go/internal/constants"
 )
 
-type MockSummarizer struct{}
+// MockSummarizer returns analyses without calling a model, so the pipeline can run
+// offline and tests get stable output. The zero value is ready to use.
+type MockSummarizer struct {
+	// Responses maps a title substring to the analysis returned for titles containing
+	// it. When several keys match, the longest wins, then the alphabetically first.
+	Responses map[string]AIAnalysis
+}
+
+// NewMockSummarizer returns a MockSummarizer with the given canned responses, which may
+// be nil.
+func NewMockSummarizer(responses map[string]AIAnalysis) *MockSummarizer {
+	return &MockSummarizer{Responses: responses}
+}
 
 func (s *MockSummarizer) Analyze(ctx context.Context, title, abstract, agency string) (*AIAnalysis, error) {
+	if key, ok := s.match(title); ok {
+		canned := s.Responses[key]
+		canned.Keypoints = slices.Clone(canned.Keypoints)
+		canned.Categories = slices.Clone(canned.Categories)
+		return &canned, nil
+	}
+
 	summary := "This document relates to government activity."
 	if abstract != "" {
-		if len(abstract) <= 100 {
-			summary = "This document relates to government activity. " + abstract + "..."
-		} else {
-			summary = "This document relates to government activity. " + abstract[:100] + "..."
+		if r := []rune(abstract); len(r) > 100 {
+			abstract = string(r[:100])
 		}
+		summary += " " + abstract + "..."
+	}
+	if lang, ok := languageFromContext(ctx); ok {
+		summary = "[" + lang + "] " + summary
 	}
 
+	// Scores are derived from the title so the same document always gets the same
+	// analysis.
+	h := fnv.New32a()
+	h.Write([]byte(title))
+	sum := h.Sum32()
+
 	return &AIAnalysis{
 		Summary: summary,
 		Keypoints: []string{
@@ -23,7 +55,21 @@ func (s *MockSummarizer) Analyze(ctx context.Context, title, abstract, agency st
 			"May affect compliance requirements",
 			"Public comment period may apply",
 		},
-		ImpactScore:    "medium",
-		PoliticalScore: 0,
+		ImpactScore:    []string{"low", "medium", "high"}[sum%3],
+		PoliticalScore: int(sum%201) - 100,
+		Categories:     []string{constants.Categories[sum%uint32(len(constants.Categories))]},
 	}, nil
 }
+
+// match returns the Responses key chosen for title.
+func (s *MockSummarizer) match(title string) (key string, ok bool) {
+	for k := range s.Responses {
+		if !strings.Contains(title, k) {
+			continue
+		}
+		if !ok || len(k) > len(key) || (len(k) == len(key) && k < key) {
+			key, ok = k, true
+		}
+	}
+	return key, ok
+}
@@ -0,0 +1,65 @@
+package services
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMockSummarizer_CannedResponses(t *testing.T) {
+	s := NewMockSummarizer(map[string]AIAnalysis{
+		"Food":        {Summary: "food", ImpactScore: "low", PoliticalScore: -10},
+		"Food Safety": {Summary: "food safety", ImpactScore: "high", PoliticalScore: 40, Categories: []string{"health"}},
+	})
+
+	got, err := s.Analyze(context.Background(), "Rule: Food Safety Standards", "", "FDA")
+	if err != nil {
+		t.Fatalf("Analyze() error: %v", err)
+	}
+	if got.Summary != "food safety" || got.ImpactScore != "high" || got.PoliticalScore != 40 {
+		t.Fatalf("expected the longest matching key, got %+v", got)
+	}
+
+	got.Categories[0] = "changed"
+	again, _ := s.Analyze(context.Background(), "Rule: Food Safety Standards", "", "FDA")
+	if again.Categories[0] != "health" {
+		t.Fatal("mutating a result changed the canned response")
+	}
+}
+
+func TestMockSummarizer_DefaultIsDeterministic(t *testing.T) {
+	var s MockSummarizer
+	abstract := strings.Repeat("é", 150)
+
+	a, err := s.Analyze(context.Background(), "Notice of Meeting", abstract, "EPA")
+	if err != nil {
+		t.Fatalf("Analyze() error: %v", err)
+	}
+	b, _ := s.Analyze(context.Background(), "Notice of Meeting", abstract, "EPA")
+	if !reflect.DeepEqual(a, b) {
+		t.Fatalf("analyses differ: %+v vs %+v", a, b)
+	}
+	if a.PoliticalScore < -100 || a.PoliticalScore > 100 || len(a.Categories) != 1 {
+		t.Fatalf("unexpected analysis: %+v", a)
+	}
+	if want := "This document relates to government activity. " + strings.Repeat("é", 100) + "..."; a.Summary != want {
+		t.Fatalf("summary = %q", a.Summary)
+	}
+
+	short, err := s.Analyze(context.Background(), "T", "x", "EPA")
+	if err != nil || !strings.HasSuffix(short.Summary, " x...") {
+		t.Fatalf("short abstract: %+v, %v", short, err)
+	}
+}
+
+func TestMockSummarizer_MarksLanguage(t *testing.T) {
+	var s MockSummarizer
+	a, err := s.Analyze(WithLanguage(context.Background(), "es"), "Notice of Meeting", "", "EPA")
+	if err != nil {
+		t.Fatalf("Analyze() error: %v", err)
+	}
+	if !strings.HasPrefix(a.Summary, "[es] ") {
+		t.Fatalf("summary = %q, want [es] prefix", a.Summary)
+	}
+}
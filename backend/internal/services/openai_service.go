@@ -0,0 +1,29 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/alex/opengov-go/internal/client"
+	"github.com/alex/opengov-go/internal/config"
+)
+
+// OpenAISummarizer talks to OpenAI, or any OpenAI-compatible server such as a local
+// model, depending on OPENAI_API_URL.
+type OpenAISummarizer struct {
+	chat            *chatCompletionClient
+	prompts         *analysisPromptCache
+	summaryMaxChars int
+}
+
+func NewOpenAISummarizer(cfg *config.Config, settings SettingsReader, usage UsageRecorder, calls *client.CallLog) *OpenAISummarizer {
+	return &OpenAISummarizer{
+		chat:            newChatCompletionClient(config.SummarizerProviderOpenAI, cfg.OpenAIAPIURL, cfg.OpenAIAPIKey, cfg.OpenAIModel, time.Duration(cfg.GrokTimeout)*time.Second, usage, calls),
+		prompts:         newAnalysisPromptCache(settings),
+		summaryMaxChars: cfg.SummaryMaxChars,
+	}
+}
+
+func (s *OpenAISummarizer) Analyze(ctx context.Context, title, abstract, agency string) (*AIAnalysis, error) {
+	return analyzeWithChat(ctx, s.chat, s.prompts, s.summaryMaxChars, title, abstract, agency)
+}
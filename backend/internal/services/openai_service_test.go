@@ -0,0 +1,62 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/alex/opengov-go/internal/config"
+)
+
+func TestOpenAISummarizer_ParsesJSONWithProse(t *testing.T) {
+	content := "Here is the analysis:\n{\"summary\":\"Short\",\"keypoints\":[\"a\"],\"impact_score\":\"extreme\",\"political_score\":-20}"
+	var req chatRequest
+	srv := newChatServer(t, content, &req)
+
+	s := NewOpenAISummarizer(&config.Config{OpenAIAPIURL: srv.URL, OpenAIModel: "gpt-test", GrokTimeout: 5}, nil, nil, nil)
+	got, err := s.Analyze(context.Background(), "Title", "Abstract", "EPA")
+	if err != nil {
+		t.Fatalf("Analyze() error: %v", err)
+	}
+
+	if got.Summary != "Short" || got.PoliticalScore != -20 {
+		t.Fatalf("unexpected analysis: %+v", got)
+	}
+	if got.ImpactScore != "medium" {
+		t.Fatalf("ImpactScore = %q, want unknown values normalized to medium", got.ImpactScore)
+	}
+	if req.Model != "gpt-test" {
+		t.Fatalf("model = %q, want gpt-test", req.Model)
+	}
+}
+
+func TestOpenAISummarizer_RejectsNonJSON(t *testing.T) {
+	var req chatRequest
+	srv := newChatServer(t, "I cannot help with that.", &req)
+
+	s := NewOpenAISummarizer(&config.Config{OpenAIAPIURL: srv.URL, GrokTimeout: 5}, nil, nil, nil)
+	if _, err := s.Analyze(context.Background(), "Title", "Abstract", "EPA"); err == nil {
+		t.Fatal("expected error for non-JSON response")
+	}
+}
+
+func TestNewSummarizer_SelectsProvider(t *testing.T) {
+	tests := []struct {
+		provider string
+		want     string
+	}{
+		{config.SummarizerProviderMock, "*services.MockSummarizer"},
+		{config.SummarizerProviderOpenAI, "*services.OpenAISummarizer"},
+		{config.SummarizerProviderXAI, "*services.XAISummarizer"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			cfg := &config.Config{SummarizerProvider: tt.provider, GrokAPIKey: "k", OpenAIAPIKey: "k"}
+			got := NewSummarizer(cfg, nil, nil, nil)
+			if fmt.Sprintf("%T", got) != tt.want {
+				t.Fatalf("NewSummarizer() = %T, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,266 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/alex/opengov-go/internal/config"
+	"github.com/alex/opengov-go/internal/db"
+	"github.com/alex/opengov-go/internal/domain"
+	"github.com/alex/opengov-go/internal/repository"
+)
+
+var (
+	ErrPolicyDocumentNotFound = errors.New("policy document not found")
+	ErrSummarizerUnavailable  = errors.New("summarizer is not configured")
+	ErrAnalysisFailed         = errors.New("AI analysis failed")
+	// ErrAnalysisNotWanted is returned by ReprocessSelective for a document the
+	// enrichment policy leaves on its fallback summary.
+	ErrAnalysisNotWanted = errors.New("document type is not analyzed in selective enrichment mode")
+)
+
+// documentGetter loads one document, returning sql.ErrNoRows when it does not exist.
+// Implemented by PolicyDocumentRepository.
+type documentGetter interface {
+	GetByID(ctx context.Context, id int64) (*domain.PolicyDocument, error)
+}
+
+type PolicyDocumentService struct {
+	db         *db.DB
+	docRepo    *repository.PolicyDocumentRepository
+	docs       documentGetter
+	feedRepo   *repository.FeedRepository
+	summarizer Summarizer
+	// fallbackTemplate renders the summary of a never-analyzed document that a
+	// reprocess leaves without an analysis.
+	fallbackTemplate string
+	// enrichPolicy picks the documents ReprocessSelective sends to the summarizer.
+	enrichPolicy EnrichmentPolicy
+}
+
+// NewPolicyDocumentService wires document updates to the feed. summarizer may be nil, in
+// which case Reprocess returns ErrSummarizerUnavailable.
+func NewPolicyDocumentService(cfg *config.Config, database *db.DB, docRepo *repository.PolicyDocumentRepository, feedRepo *repository.FeedRepository, summarizer Summarizer) *PolicyDocumentService {
+	return &PolicyDocumentService{
+		db:               database,
+		docRepo:          docRepo,
+		docs:             docRepo,
+		feedRepo:         feedRepo,
+		summarizer:       summarizer,
+		fallbackTemplate: cfg.FallbackSummaryTemplate,
+		enrichPolicy:     NewEnrichmentPolicy(cfg),
+	}
+}
+
+// Update saves doc and re-materializes its feed entry in the same transaction.
+func (s *PolicyDocumentService) Update(ctx context.Context, doc *domain.PolicyDocument) error {
+	return s.update(ctx, doc, nil)
+}
+
+// update is Update that also replaces the document's categories, unless categories is
+// nil.
+func (s *PolicyDocumentService) update(ctx context.Context, doc *domain.PolicyDocument, categories []string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := s.docRepo.Update(ctx, tx, doc); err != nil {
+		return err
+	}
+	if categories != nil {
+		if err := s.docRepo.ReplaceCategories(ctx, tx, doc.ID, categories); err != nil {
+			return err
+		}
+	}
+
+	impactScore := ""
+	if doc.ImpactScore != nil {
+		impactScore = *doc.ImpactScore
+	}
+	if err := s.feedRepo.UpsertFeedEntryByPolicyDocID(
+		ctx, tx, doc.ID,
+		doc.Title, doc.Summary, doc.Keypoints,
+		doc.PoliticalScore, impactScore,
+		doc.SourceURL, doc.PublishedAt,
+	); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// CanReprocess reports whether a summarizer is configured for Reprocess to use.
+func (s *PolicyDocumentService) CanReprocess() bool {
+	return s.summarizer != nil
+}
+
+// Reprocess re-runs AI analysis for one document, replacing any existing AI fields and
+// categories, and updates its feed entry. When the analysis fails ErrAnalysisFailed is
+// returned and an analyzed document is left untouched; one that has never been analyzed
+// gets the templated fallback summary in place of its ingest placeholder.
+func (s *PolicyDocumentService) Reprocess(ctx context.Context, id int64) (*domain.PolicyDocument, error) {
+	return s.reprocess(ctx, id, false)
+}
+
+// ReprocessSelective is Reprocess for bulk runs, applying the enrichment policy: a
+// document it does not want analyzed is not sent to the summarizer and ErrAnalysisNotWanted
+// is returned. Such a document keeps its AI fields if an earlier run analyzed it, and
+// otherwise gets the templated fallback summary.
+func (s *PolicyDocumentService) ReprocessSelective(ctx context.Context, id int64) (*domain.PolicyDocument, error) {
+	return s.reprocess(ctx, id, true)
+}
+
+func (s *PolicyDocumentService) reprocess(ctx context.Context, id int64, selective bool) (*domain.PolicyDocument, error) {
+	if s.summarizer == nil {
+		return nil, ErrSummarizerUnavailable
+	}
+
+	doc, err := s.docs.GetByID(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrPolicyDocumentNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get policy document: %w", err)
+	}
+
+	if selective && !s.enrichPolicy.WantsFullAnalysis(doc) {
+		if applyFallbackSummary(doc, s.fallbackTemplate) {
+			if err := s.update(ctx, doc, nil); err != nil {
+				return nil, err
+			}
+		}
+		return doc, ErrAnalysisNotWanted
+	}
+
+	agency := ""
+	if doc.Agency != nil {
+		agency = *doc.Agency
+	}
+	analysis, err := s.summarizer.Analyze(WithDocumentID(ctx, doc.ID), doc.Title, analysisAbstract(doc), agency)
+	if err != nil {
+		if applyFallbackSummary(doc, s.fallbackTemplate) {
+			if uerr := s.update(ctx, doc, nil); uerr != nil {
+				log.Printf("Failed to store fallback summary for policy_documents(%d): %v", doc.ID, uerr)
+			}
+		}
+		return nil, fmt.Errorf("%w: %v", ErrAnalysisFailed, err)
+	}
+
+	applyAnalysis(doc, analysis)
+	if err := s.update(ctx, doc, analysis.Categories); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// applyFallbackSummary gives a document that has never been analyzed the summary
+// rendered from tmpl, reporting whether that changed it. Analyzed documents keep their
+// AI summary.
+func applyFallbackSummary(doc *domain.PolicyDocument, tmpl string) bool {
+	if !needsEnrichment(doc) {
+		return false
+	}
+	summary := FallbackSummary(tmpl, doc)
+	if summary == doc.Summary {
+		return false
+	}
+	doc.Summary = summary
+	return true
+}
+
+// analysisAbstract is the text Reprocess analyzes: the upstream abstract, or the title
+// when the source had none. The summary is never used, since after the first analysis
+// it is the model's own output.
+func analysisAbstract(doc *domain.PolicyDocument) string {
+	if doc.Abstract != nil && *doc.Abstract != "" {
+		return *doc.Abstract
+	}
+	return doc.Title
+}
+
+// KeypointValidationError lists every way a manual keypoint edit broke the limits.
+type KeypointValidationError struct {
+	Problems []string
+}
+
+func (e *KeypointValidationError) Error() string {
+	return "invalid keypoints: " + strings.Join(e.Problems, "; ")
+}
+
+// ValidateAdminKeypoints checks keypoints entered by an admin against the configured
+// count and per-item character limits. AI output is not run through this; it is trusted
+// to follow the prompt.
+func ValidateAdminKeypoints(keypoints []string, maxCount, maxChars int) error {
+	var problems []string
+	if len(keypoints) > maxCount {
+		problems = append(problems, fmt.Sprintf("at most %d keypoints allowed, got %d", maxCount, len(keypoints)))
+	}
+	for i, kp := range keypoints {
+		if strings.TrimSpace(kp) == "" {
+			problems = append(problems, fmt.Sprintf("keypoint %d is empty", i+1))
+			continue
+		}
+		if n := utf8.RuneCountInString(kp); n > maxChars {
+			problems = append(problems, fmt.Sprintf("keypoint %d is %d characters, max %d", i+1, n, maxChars))
+		}
+	}
+	if len(problems) > 0 {
+		return &KeypointValidationError{Problems: problems}
+	}
+	return nil
+}
+
+// Edit applies a manual admin correction to a document's summary and/or keypoints and
+// updates its feed entry. Nil arguments leave the field unchanged; keypoints are expected
+// to have passed ValidateAdminKeypoints.
+func (s *PolicyDocumentService) Edit(ctx context.Context, id int64, summary *string, keypoints []string) (*domain.PolicyDocument, error) {
+	doc, err := s.docs.GetByID(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrPolicyDocumentNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get policy document: %w", err)
+	}
+
+	if summary != nil {
+		doc.Summary = *summary
+	}
+	if keypoints != nil {
+		doc.Keypoints = make([]string, len(keypoints))
+		for i, kp := range keypoints {
+			doc.Keypoints[i] = strings.TrimSpace(kp)
+		}
+	}
+
+	if err := s.Update(ctx, doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// applyAnalysis overwrites doc's AI fields with analysis. An empty summary keeps the
+// existing one so a partial response never blanks the feed text.
+func applyAnalysis(doc *domain.PolicyDocument, analysis *AIAnalysis) {
+	if analysis.Summary != "" {
+		doc.Summary = analysis.Summary
+	}
+	doc.Keypoints = analysis.Keypoints
+
+	doc.ImpactScore = nil
+	if analysis.ImpactScore != "" {
+		impact := analysis.ImpactScore
+		doc.ImpactScore = &impact
+	}
+	political := analysis.PoliticalScore
+	doc.PoliticalScore = &political
+}
@@ -0,0 +1,190 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/alex/opengov-go/internal/config"
+	"github.com/alex/opengov-go/internal/domain"
+)
+
+func TestApplyAnalysis_OverwritesExistingAIFields(t *testing.T) {
+	oldImpact := "high"
+	oldPolitical := 80
+	doc := &domain.PolicyDocument{
+		Summary:        "garbage summary",
+		Keypoints:      []string{"stale"},
+		ImpactScore:    &oldImpact,
+		PoliticalScore: &oldPolitical,
+	}
+
+	applyAnalysis(doc, &AIAnalysis{
+		Summary:        "New rule caps PFAS in drinking water.",
+		Keypoints:      []string{"caps PFAS", "effective 2027"},
+		ImpactScore:    "medium",
+		PoliticalScore: -10,
+	})
+
+	if doc.Summary != "New rule caps PFAS in drinking water." {
+		t.Fatalf("Summary = %q", doc.Summary)
+	}
+	if len(doc.Keypoints) != 2 || doc.Keypoints[0] != "caps PFAS" {
+		t.Fatalf("Keypoints = %v", doc.Keypoints)
+	}
+	if doc.ImpactScore == nil || *doc.ImpactScore != "medium" {
+		t.Fatalf("ImpactScore = %v, want medium", doc.ImpactScore)
+	}
+	if doc.PoliticalScore == nil || *doc.PoliticalScore != -10 {
+		t.Fatalf("PoliticalScore = %v, want -10", doc.PoliticalScore)
+	}
+}
+
+func TestApplyAnalysis_KeepsSummaryWhenEmpty(t *testing.T) {
+	doc := &domain.PolicyDocument{Summary: "original"}
+	applyAnalysis(doc, &AIAnalysis{Keypoints: []string{"point"}})
+
+	if doc.Summary != "original" {
+		t.Fatalf("Summary = %q, want original", doc.Summary)
+	}
+	if doc.ImpactScore != nil {
+		t.Fatalf("ImpactScore = %v, want nil", *doc.ImpactScore)
+	}
+}
+
+func TestValidateAdminKeypoints(t *testing.T) {
+	within := []string{"Caps PFAS at 4 ppt", "Effective in 2027", "Applies to public water systems"}
+	if err := ValidateAdminKeypoints(within, 3, 40); err != nil {
+		t.Fatalf("within bounds: unexpected error %v", err)
+	}
+	if err := ValidateAdminKeypoints([]string{"naïve café ünïcode"}, 1, 18); err != nil {
+		t.Fatalf("length should count characters, not bytes: %v", err)
+	}
+
+	err := ValidateAdminKeypoints(append(within, "   ", strings.Repeat("x", 41)), 3, 40)
+	var verr *KeypointValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("error = %v, want *KeypointValidationError", err)
+	}
+	want := []string{
+		"at most 3 keypoints allowed, got 5",
+		"keypoint 4 is empty",
+		"keypoint 5 is 41 characters, max 40",
+	}
+	if len(verr.Problems) != len(want) {
+		t.Fatalf("problems = %q, want %q", verr.Problems, want)
+	}
+	for i := range want {
+		if verr.Problems[i] != want[i] {
+			t.Fatalf("problem %d = %q, want %q", i, verr.Problems[i], want[i])
+		}
+	}
+}
+
+type missingDocuments struct{}
+
+func (missingDocuments) GetByID(context.Context, int64) (*domain.PolicyDocument, error) {
+	return nil, sql.ErrNoRows
+}
+
+// The transaction and feed dependencies are nil, so going past the lookup would panic.
+func TestPolicyDocumentService_NotFound(t *testing.T) {
+	s := &PolicyDocumentService{docs: missingDocuments{}, summarizer: NewMockSummarizer(nil)}
+
+	if _, err := s.Reprocess(t.Context(), 42); !errors.Is(err, ErrPolicyDocumentNotFound) {
+		t.Errorf("Reprocess() error = %v, want ErrPolicyDocumentNotFound", err)
+	}
+	summary := "corrected"
+	if _, err := s.Edit(t.Context(), 42, &summary, nil); !errors.Is(err, ErrPolicyDocumentNotFound) {
+		t.Errorf("Edit() error = %v, want ErrPolicyDocumentNotFound", err)
+	}
+}
+
+// analyzedDocuments serves already-analyzed documents, so a skipped or failed reprocess
+// has nothing to write.
+type analyzedDocuments map[int64]string
+
+func (d analyzedDocuments) GetByID(_ context.Context, id int64) (*domain.PolicyDocument, error) {
+	docType, ok := d[id]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	impact, political := "low", 0
+	return &domain.PolicyDocument{
+		ID: id, Title: "Document", Summary: "AI summary", DocumentType: &docType,
+		Keypoints: []string{"point"}, ImpactScore: &impact, PoliticalScore: &political,
+	}, nil
+}
+
+// countingSummarizer counts its calls and fails each one.
+type countingSummarizer struct {
+	calls int
+}
+
+func (s *countingSummarizer) Analyze(context.Context, string, string, string) (*AIAnalysis, error) {
+	s.calls++
+	return nil, errors.New("upstream unavailable")
+}
+
+func TestPolicyDocumentService_ReprocessSelective(t *testing.T) {
+	summarizer := &countingSummarizer{}
+	s := NewPolicyDocumentService(&config.Config{
+		EnrichMode:         config.EnrichModeSelective,
+		EnrichAnalyzeTypes: []string{"Rule"},
+	}, nil, nil, nil, summarizer)
+	s.docs = analyzedDocuments{1: "Notice", 2: "Rule"}
+
+	doc, err := s.ReprocessSelective(t.Context(), 1)
+	if !errors.Is(err, ErrAnalysisNotWanted) || summarizer.calls != 0 {
+		t.Fatalf("notice: err = %v, calls = %d; want ErrAnalysisNotWanted without analysis", err, summarizer.calls)
+	}
+	if doc.Summary != "AI summary" {
+		t.Fatalf("notice summary = %q, want the earlier analysis kept", doc.Summary)
+	}
+
+	if _, err := s.ReprocessSelective(t.Context(), 2); !errors.Is(err, ErrAnalysisFailed) || summarizer.calls != 1 {
+		t.Fatalf("rule: err = %v, calls = %d; want one failed analysis", err, summarizer.calls)
+	}
+
+	// A single-document reprocess is an explicit request and ignores the policy.
+	if _, err := s.Reprocess(t.Context(), 1); !errors.Is(err, ErrAnalysisFailed) || summarizer.calls != 2 {
+		t.Fatalf("Reprocess: err = %v, calls = %d; want the notice analyzed", err, summarizer.calls)
+	}
+}
+
+func TestAnalysisAbstract(t *testing.T) {
+	abstract := "EPA sets maximum contaminant levels for six PFAS."
+	doc := &domain.PolicyDocument{Title: "PFAS National Primary Drinking Water Regulation", Summary: "AI summary", Abstract: &abstract}
+	if got := analysisAbstract(doc); got != abstract {
+		t.Fatalf("analysisAbstract() = %q, want the upstream abstract", got)
+	}
+
+	empty := ""
+	for _, a := range []*string{nil, &empty} {
+		doc.Abstract = a
+		if got := analysisAbstract(doc); got != doc.Title {
+			t.Fatalf("analysisAbstract() = %q, want the title", got)
+		}
+	}
+}
+
+func TestApplyFallbackSummary(t *testing.T) {
+	agency, docType := "Coast Guard", "Notice"
+	tmpl := "{agency} issued {type}: {title}."
+	doc := &domain.PolicyDocument{Title: "Safety Zone; Ohio River", Agency: &agency, DocumentType: &docType, Summary: "Excerpt from the upstream payload"}
+
+	if !applyFallbackSummary(doc, tmpl) || doc.Summary != "Coast Guard issued Notice: Safety Zone; Ohio River." {
+		t.Fatalf("summary = %q, want the fallback", doc.Summary)
+	}
+	if applyFallbackSummary(doc, tmpl) {
+		t.Error("second call reported a change")
+	}
+
+	impact, political := "low", 0
+	doc.Summary, doc.ImpactScore, doc.PoliticalScore, doc.Keypoints = "AI summary", &impact, &political, []string{"Closes mile 470-471"}
+	if applyFallbackSummary(doc, tmpl) || doc.Summary != "AI summary" {
+		t.Fatalf("summary = %q, want an analyzed document left alone", doc.Summary)
+	}
+}
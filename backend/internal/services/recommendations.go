@@ -0,0 +1,83 @@
+package services
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sort"
+
+	"github.com/alex/opengov-go/internal/repository"
+	"github.com/alex/opengov-go/internal/transport"
+)
+
+// recommendationPool is how many recent matching entries are scored per request.
+// Ranking happens in Go, so the pool is much larger than any page.
+const recommendationPool = 500
+
+// GetRecommended returns feed entries that share agencies or document types with the
+// user's bookmarks, excluding entries already bookmarked. Users with no bookmarks get
+// an empty list.
+func (s *FeedService) GetRecommended(ctx context.Context, userID int64, limit int) ([]transport.FeedEntryResponse, error) {
+	aff, err := s.feedRepo.GetBookmarkAffinity(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(aff.Agencies) == 0 && len(aff.DocumentTypes) == 0 {
+		return []transport.FeedEntryResponse{}, nil
+	}
+
+	candidates, err := s.feedRepo.GetRecommendationCandidates(ctx, userID, slices.Collect(maps.Keys(aff.Agencies)), slices.Collect(maps.Keys(aff.DocumentTypes)), recommendationPool)
+	if err != nil {
+		return nil, err
+	}
+
+	ranked := rankRecommendations(aff, candidates, limit)
+	responses := make([]transport.FeedEntryResponse, len(ranked))
+	for i, item := range ranked {
+		responses[i] = mapFeedEntryRowToResponse(item)
+	}
+	return responses, nil
+}
+
+// rankRecommendations scores each candidate by how many bookmarks list each of its
+// agencies plus how many share its document type, and returns the top limit by score, then newest
+// first. Bookmarked and non-overlapping candidates are dropped.
+func rankRecommendations(aff repository.BookmarkAffinity, candidates []repository.RecommendationCandidate, limit int) []repository.FeedEntryRow {
+	type scored struct {
+		row   repository.FeedEntryRow
+		score int
+	}
+	var out []scored
+	for _, c := range candidates {
+		if aff.FeedEntryIDs[c.Entry.FeedEntryID] {
+			continue
+		}
+		score := aff.DocumentTypes[c.DocumentType]
+		for _, agency := range c.Agencies {
+			score += aff.Agencies[agency]
+		}
+		if score == 0 {
+			continue
+		}
+		out = append(out, scored{row: c.Entry, score: score})
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].score != out[j].score {
+			return out[i].score > out[j].score
+		}
+		if !out[i].row.PublishedAt.Equal(out[j].row.PublishedAt) {
+			return out[i].row.PublishedAt.After(out[j].row.PublishedAt)
+		}
+		return out[i].row.FeedEntryID > out[j].row.FeedEntryID
+	})
+
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	rows := make([]repository.FeedEntryRow, len(out))
+	for i, s := range out {
+		rows[i] = s.row
+	}
+	return rows
+}
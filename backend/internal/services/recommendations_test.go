@@ -0,0 +1,62 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alex/opengov-go/internal/repository"
+)
+
+func TestRankRecommendations(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, 3, d, 0, 0, 0, 0, time.UTC) }
+	candidate := func(id int64, agencies []string, docType string, published time.Time) repository.RecommendationCandidate {
+		return repository.RecommendationCandidate{
+			Entry:        repository.FeedEntryRow{FeedEntryID: id, PublishedAt: published},
+			Agencies:     agencies,
+			DocumentType: docType,
+		}
+	}
+	epa, fda, dot := []string{"EPA"}, []string{"FDA"}, []string{"DOT"}
+
+	// Three bookmarks: two EPA rules and one FDA notice.
+	aff := repository.BookmarkAffinity{
+		FeedEntryIDs:  map[int64]bool{1: true, 2: true, 3: true},
+		Agencies:      map[string]int{"EPA": 2, "FDA": 1},
+		DocumentTypes: map[string]int{"Rule": 2, "Notice": 1},
+	}
+	candidates := []repository.RecommendationCandidate{
+		candidate(1, epa, "Rule", day(20)),                             // already bookmarked
+		candidate(10, epa, "Rule", day(5)),                             // score 4
+		candidate(11, fda, "Notice", day(18)),                          // score 2
+		candidate(12, epa, "Notice", day(10)),                          // score 3
+		candidate(13, dot, "Rule", day(12)),                            // score 2, older than 11
+		candidate(14, dot, "Proposed Rule", day(19)),                   // no overlap
+		candidate(15, []string{"DOT", "FDA"}, "Proposed Rule", day(1)), // score 1, co-issued
+	}
+
+	got := rankRecommendations(aff, candidates, 10)
+	want := []int64{10, 12, 11, 13, 15}
+	if len(got) != len(want) {
+		t.Fatalf("got %d recommendations, want %d", len(got), len(want))
+	}
+	for i, row := range got {
+		if row.FeedEntryID != want[i] {
+			t.Fatalf("position %d = entry %d, want %d", i, row.FeedEntryID, want[i])
+		}
+	}
+
+	if got := rankRecommendations(aff, candidates, 2); len(got) != 2 || got[0].FeedEntryID != 10 {
+		t.Fatalf("limit not applied: %+v", got)
+	}
+}
+
+func TestRankRecommendations_NoBookmarks(t *testing.T) {
+	aff := repository.BookmarkAffinity{}
+	candidates := []repository.RecommendationCandidate{{
+		Entry:    repository.FeedEntryRow{FeedEntryID: 1},
+		Agencies: []string{"EPA"},
+	}}
+	if got := rankRecommendations(aff, candidates, 10); len(got) != 0 {
+		t.Fatalf("got %d recommendations for a user without bookmarks", len(got))
+	}
+}
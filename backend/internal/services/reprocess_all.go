@@ -0,0 +1,185 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/alex/opengov-go/internal/constants"
+	"github.com/alex/opengov-go/internal/domain"
+	"github.com/alex/opengov-go/internal/repository"
+)
+
+var (
+	ErrReprocessRunning    = errors.New("reprocess already running")
+	ErrReprocessNotRunning = errors.New("no reprocess running")
+)
+
+const (
+	// reprocessBatchSize is how many document ids are fetched per query.
+	reprocessBatchSize = 100
+	// reprocessMaxConsecutiveFailures stops a run whose analyses keep failing, e.g. when
+	// the AI provider is down, instead of skipping through the whole corpus.
+	reprocessMaxConsecutiveFailures = 10
+)
+
+// reprocessScope is the slice of PolicyDocumentRepository a reprocess run walks.
+type reprocessScope interface {
+	CountInScope(ctx context.Context, scope repository.DocumentScope) (total int, maxID int64, err error)
+	ListIDsInScope(ctx context.Context, scope repository.DocumentScope, afterID, maxID int64, limit int) ([]int64, error)
+}
+
+// documentReprocessor re-runs AI analysis for one document, returning
+// ErrAnalysisNotWanted for documents the enrichment policy skips. Implemented by
+// PolicyDocumentService.
+type documentReprocessor interface {
+	CanReprocess() bool
+	ReprocessSelective(ctx context.Context, id int64) (*domain.PolicyDocument, error)
+}
+
+// ReprocessRunner re-runs AI analysis over every document in a scope as a background
+// job, one run at a time. It is for refreshing the corpus after the analysis prompt
+// changes.
+type ReprocessRunner struct {
+	docs     reprocessScope
+	reproc   documentReprocessor
+	jobs     *JobRunner
+	interval time.Duration
+
+	mu     sync.Mutex
+	cancel context.CancelFunc // non-nil while a run is in progress
+}
+
+// NewReprocessRunner returns a runner that waits interval between analyses to stay under
+// the AI provider's rate limits. An interval of 0 does not wait.
+func NewReprocessRunner(docs reprocessScope, reproc documentReprocessor, jobs *JobRunner, interval time.Duration) *ReprocessRunner {
+	return &ReprocessRunner{
+		docs:     docs,
+		reproc:   reproc,
+		jobs:     jobs,
+		interval: interval,
+	}
+}
+
+// Start counts the documents in scope and launches the run, returning its job. Documents
+// added after Start are not included.
+func (r *ReprocessRunner) Start(ctx context.Context, scope repository.DocumentScope) (*domain.Job, error) {
+	if !r.reproc.CanReprocess() {
+		return nil, ErrSummarizerUnavailable
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.cancel != nil {
+		return nil, ErrReprocessRunning
+	}
+
+	total, maxID, err := r.docs.CountInScope(ctx, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	// Cancel needs the run's cancel func before the job starts, so the run gets its own
+	// context, tied to the job's so shutdown still stops it.
+	runCtx, cancel := context.WithCancel(context.Background())
+	job, err := r.jobs.StartCounted(ctx, constants.JobTypeReprocess, total, func(jobCtx context.Context, report func(processed, skipped int)) error {
+		stop := context.AfterFunc(jobCtx, cancel)
+		defer stop()
+		defer func() {
+			r.mu.Lock()
+			r.cancel = nil
+			r.mu.Unlock()
+			cancel()
+		}()
+		return r.run(runCtx, scope, maxID, report)
+	})
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	r.cancel = cancel
+	return job, nil
+}
+
+// Cancel stops the run in progress. The document being analyzed is left as it was and
+// the job is recorded as failed.
+func (r *ReprocessRunner) Cancel() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.cancel == nil {
+		return ErrReprocessNotRunning
+	}
+	r.cancel()
+	return nil
+}
+
+func (r *ReprocessRunner) run(ctx context.Context, scope repository.DocumentScope, maxID int64, report func(processed, skipped int)) error {
+	var processed, skipped, failures int
+	var afterID int64
+	// wait is set once the summarizer has been called, so the interval only paces
+	// actual analyses.
+	wait := false
+	for {
+		ids, err := r.docs.ListIDsInScope(ctx, scope, afterID, maxID, reprocessBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+
+		for _, id := range ids {
+			if wait {
+				if err := sleepCtx(ctx, r.interval); err != nil {
+					return err
+				}
+			}
+
+			_, err := r.reproc.ReprocessSelective(ctx, id)
+			wait = !errors.Is(err, ErrAnalysisNotWanted) && !errors.Is(err, ErrPolicyDocumentNotFound)
+			switch {
+			case err == nil:
+				processed++
+				failures = 0
+			case ctx.Err() != nil:
+				return ctx.Err()
+			case errors.Is(err, ErrAnalysisNotWanted):
+				// Left on its fallback summary by ENRICH_MODE=selective.
+				skipped++
+			case errors.Is(err, ErrPolicyDocumentNotFound):
+				// Deleted since the run was counted.
+				skipped++
+			default:
+				log.Printf("Reprocess of document %d failed: %v", id, err)
+				skipped++
+				failures++
+				if failures >= reprocessMaxConsecutiveFailures {
+					report(processed, skipped)
+					return fmt.Errorf("stopped after %d consecutive failures: %w", failures, err)
+				}
+			}
+			report(processed, skipped)
+		}
+		afterID = ids[len(ids)-1]
+	}
+}
+
+// sleepCtx waits for d, returning early with ctx's error if it is cancelled first.
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
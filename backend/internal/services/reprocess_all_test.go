@@ -0,0 +1,201 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alex/opengov-go/internal/constants"
+	"github.com/alex/opengov-go/internal/domain"
+	"github.com/alex/opengov-go/internal/repository"
+)
+
+// fakeReprocessScope serves ids from a sorted slice.
+type fakeReprocessScope struct {
+	ids []int64
+}
+
+func (f *fakeReprocessScope) CountInScope(context.Context, repository.DocumentScope) (int, int64, error) {
+	if len(f.ids) == 0 {
+		return 0, 0, nil
+	}
+	return len(f.ids), f.ids[len(f.ids)-1], nil
+}
+
+func (f *fakeReprocessScope) ListIDsInScope(_ context.Context, _ repository.DocumentScope, afterID, maxID int64, limit int) ([]int64, error) {
+	var out []int64
+	for _, id := range f.ids {
+		if id > afterID && id <= maxID && len(out) < limit {
+			out = append(out, id)
+		}
+	}
+	return out, nil
+}
+
+// fakeReprocessor records the ids it was asked to reprocess and fails those in fail.
+// block, when set, holds each call until it is closed or the context is cancelled.
+type fakeReprocessor struct {
+	mu    sync.Mutex
+	seen  []int64
+	fail  map[int64]error
+	block chan struct{}
+}
+
+func (f *fakeReprocessor) CanReprocess() bool { return true }
+
+func (f *fakeReprocessor) ReprocessSelective(ctx context.Context, id int64) (*domain.PolicyDocument, error) {
+	if f.block != nil {
+		select {
+		case <-f.block:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.seen = append(f.seen, id)
+	if err := f.fail[id]; err != nil {
+		return nil, err
+	}
+	return &domain.PolicyDocument{ID: id}, nil
+}
+
+func waitForJob(t *testing.T, store *fakeJobStore, id int64) domain.Job {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for {
+		j := store.job(id)
+		if j.Status == constants.JobStatusSucceeded || j.Status == constants.JobStatusFailed {
+			return j
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("job = %+v, want a final status", j)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestReprocessRunner_WalksScopeInBatches(t *testing.T) {
+	ids := make([]int64, reprocessBatchSize+5)
+	for i := range ids {
+		ids[i] = int64(i*2 + 1)
+	}
+	reproc := &fakeReprocessor{fail: map[int64]error{3: ErrPolicyDocumentNotFound, 5: ErrAnalysisNotWanted, 7: ErrAnalysisFailed}}
+	store := &fakeJobStore{}
+	r := NewReprocessRunner(&fakeReprocessScope{ids: ids}, reproc, NewJobRunner(t.Context(), constants.JobOriginAPI, store), 0)
+
+	job, err := r.Start(t.Context(), repository.DocumentScope{})
+	if err != nil {
+		t.Fatalf("Start() error: %v", err)
+	}
+	j := waitForJob(t, store, job.ID)
+
+	if j.Type != constants.JobTypeReprocess || j.Status != constants.JobStatusSucceeded {
+		t.Fatalf("job = %+v, want succeeded reprocess", j)
+	}
+	if j.Total == nil || *j.Total != len(ids) || j.Processed != len(ids)-3 || j.Skipped != 3 {
+		t.Fatalf("job = %+v, want total %d with %d processed and 3 skipped", j, len(ids), len(ids)-3)
+	}
+	if len(reproc.seen) != len(ids) {
+		t.Fatalf("reprocessed %d documents, want %d", len(reproc.seen), len(ids))
+	}
+}
+
+func TestReprocessRunner_StopsAfterConsecutiveFailures(t *testing.T) {
+	ids := make([]int64, reprocessMaxConsecutiveFailures+5)
+	fail := map[int64]error{}
+	for i := range ids {
+		ids[i] = int64(i + 1)
+		fail[ids[i]] = ErrAnalysisFailed
+	}
+	reproc := &fakeReprocessor{fail: fail}
+	store := &fakeJobStore{}
+	r := NewReprocessRunner(&fakeReprocessScope{ids: ids}, reproc, NewJobRunner(t.Context(), constants.JobOriginAPI, store), 0)
+
+	job, err := r.Start(t.Context(), repository.DocumentScope{})
+	if err != nil {
+		t.Fatalf("Start() error: %v", err)
+	}
+	j := waitForJob(t, store, job.ID)
+
+	if j.Status != constants.JobStatusFailed || j.Skipped != reprocessMaxConsecutiveFailures {
+		t.Fatalf("job = %+v, want failed after %d skipped", j, reprocessMaxConsecutiveFailures)
+	}
+}
+
+// Documents the enrichment policy skips are neither failures nor paced like analyses.
+func TestReprocessRunner_SkipsUnwantedWithoutPacing(t *testing.T) {
+	ids := make([]int64, reprocessMaxConsecutiveFailures+5)
+	fail := map[int64]error{}
+	for i := range ids {
+		ids[i] = int64(i + 1)
+		fail[ids[i]] = ErrAnalysisNotWanted
+	}
+	store := &fakeJobStore{}
+	r := NewReprocessRunner(&fakeReprocessScope{ids: ids}, &fakeReprocessor{fail: fail}, NewJobRunner(t.Context(), constants.JobOriginAPI, store), time.Hour)
+
+	job, err := r.Start(t.Context(), repository.DocumentScope{})
+	if err != nil {
+		t.Fatalf("Start() error: %v", err)
+	}
+	j := waitForJob(t, store, job.ID)
+
+	if j.Status != constants.JobStatusSucceeded || j.Processed != 0 || j.Skipped != len(ids) {
+		t.Fatalf("job = %+v, want succeeded with all %d skipped", j, len(ids))
+	}
+}
+
+func TestReprocessRunner_CancelAndRejectWhileRunning(t *testing.T) {
+	reproc := &fakeReprocessor{block: make(chan struct{})}
+	store := &fakeJobStore{}
+	r := NewReprocessRunner(&fakeReprocessScope{ids: []int64{1, 2, 3}}, reproc, NewJobRunner(t.Context(), constants.JobOriginAPI, store), 0)
+
+	if err := r.Cancel(); !errors.Is(err, ErrReprocessNotRunning) {
+		t.Fatalf("Cancel() before Start error = %v, want ErrReprocessNotRunning", err)
+	}
+	job, err := r.Start(t.Context(), repository.DocumentScope{})
+	if err != nil {
+		t.Fatalf("Start() error: %v", err)
+	}
+	if _, err := r.Start(t.Context(), repository.DocumentScope{}); !errors.Is(err, ErrReprocessRunning) {
+		t.Fatalf("second Start() error = %v, want ErrReprocessRunning", err)
+	}
+	if err := r.Cancel(); err != nil {
+		t.Fatalf("Cancel() error: %v", err)
+	}
+
+	j := waitForJob(t, store, job.ID)
+	if j.Status != constants.JobStatusFailed || j.Error == nil || *j.Error != context.Canceled.Error() {
+		t.Fatalf("job = %+v, want failed with context canceled", j)
+	}
+	if len(reproc.seen) != 0 {
+		t.Fatalf("reprocessed %v after cancel, want none", reproc.seen)
+	}
+
+	// The runner clears its running state before the job's final status is written.
+	if _, err := r.Start(t.Context(), repository.DocumentScope{}); err != nil {
+		t.Fatalf("Start() after cancel error: %v", err)
+	}
+	if err := r.Cancel(); err != nil {
+		t.Fatalf("Cancel() error: %v", err)
+	}
+}
+
+func TestReprocessRunner_StopsOnShutdown(t *testing.T) {
+	reproc := &fakeReprocessor{block: make(chan struct{})}
+	store := &fakeJobStore{}
+	base, shutdown := context.WithCancel(t.Context())
+	r := NewReprocessRunner(&fakeReprocessScope{ids: []int64{1, 2, 3}}, reproc, NewJobRunner(base, constants.JobOriginAPI, store), 0)
+
+	job, err := r.Start(t.Context(), repository.DocumentScope{})
+	if err != nil {
+		t.Fatalf("Start() error: %v", err)
+	}
+	shutdown()
+
+	if j := waitForJob(t, store, job.ID); j.Status != constants.JobStatusFailed || len(reproc.seen) != 0 {
+		t.Fatalf("job = %+v, reprocessed %v; want failed before any document", j, reproc.seen)
+	}
+}
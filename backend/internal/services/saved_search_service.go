@@ -0,0 +1,128 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/alex/opengov-go/internal/db/dbtypes"
+	"github.com/alex/opengov-go/internal/domain"
+	"github.com/alex/opengov-go/internal/repository"
+	"github.com/alex/opengov-go/internal/transport"
+)
+
+var ErrInvalidSavedSearchFilter = errors.New("invalid saved search filter")
+
+// savedSearchFilterKeys are the only keys a stored filter may use.
+var savedSearchFilterKeys = []string{"agency", "document_type", "keyword"}
+
+type SavedSearchService struct {
+	savedSearchRepo *repository.SavedSearchRepository
+	feedService     *FeedService
+}
+
+func NewSavedSearchService(savedSearchRepo *repository.SavedSearchRepository, feedService *FeedService) *SavedSearchService {
+	return &SavedSearchService{
+		savedSearchRepo: savedSearchRepo,
+		feedService:     feedService,
+	}
+}
+
+// ParseSavedSearchFilter validates stored filter params and converts them to a feed filter.
+// Unknown keys and non-string values are rejected with ErrInvalidSavedSearchFilter.
+func ParseSavedSearchFilter(filters map[string]interface{}) (repository.FeedFilter, error) {
+	var f repository.FeedFilter
+
+	keys := make([]string, 0, len(filters))
+	for k := range filters {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		v, ok := filters[k].(string)
+		if !ok {
+			return f, fmt.Errorf("%w: %q must be a string", ErrInvalidSavedSearchFilter, k)
+		}
+		switch k {
+		case "agency":
+			f.Agency = v
+		case "document_type":
+			f.DocumentType = v
+		case "keyword":
+			f.Keyword = v
+		default:
+			return f, fmt.Errorf("%w: unknown key %q (allowed: %v)", ErrInvalidSavedSearchFilter, k, savedSearchFilterKeys)
+		}
+	}
+	return f, nil
+}
+
+func (s *SavedSearchService) List(ctx context.Context, userID int64) ([]domain.SavedSearch, error) {
+	return s.savedSearchRepo.ListByUser(ctx, userID)
+}
+
+func (s *SavedSearchService) Get(ctx context.Context, userID, id int64) (*domain.SavedSearch, error) {
+	return s.savedSearchRepo.GetByIDForUser(ctx, userID, id)
+}
+
+func (s *SavedSearchService) Create(ctx context.Context, userID int64, name string, filters map[string]interface{}) (*domain.SavedSearch, error) {
+	if _, err := ParseSavedSearchFilter(filters); err != nil {
+		return nil, err
+	}
+
+	search := &domain.SavedSearch{
+		UserID:  userID,
+		Name:    name,
+		Filters: dbtypes.JSONMap(filters),
+	}
+	if err := s.savedSearchRepo.Create(ctx, search); err != nil {
+		return nil, err
+	}
+	return search, nil
+}
+
+// Update returns nil when the saved search does not exist for the user.
+func (s *SavedSearchService) Update(ctx context.Context, userID, id int64, name string, filters map[string]interface{}) (*domain.SavedSearch, error) {
+	if _, err := ParseSavedSearchFilter(filters); err != nil {
+		return nil, err
+	}
+
+	search, err := s.savedSearchRepo.GetByIDForUser(ctx, userID, id)
+	if err != nil || search == nil {
+		return nil, err
+	}
+	search.Name = name
+	search.Filters = dbtypes.JSONMap(filters)
+
+	ok, err := s.savedSearchRepo.Update(ctx, search)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return search, nil
+}
+
+func (s *SavedSearchService) Delete(ctx context.Context, userID, id int64) (bool, error) {
+	return s.savedSearchRepo.Delete(ctx, userID, id)
+}
+
+// Results runs the stored filter against the feed. It returns nil when the saved search
+// does not exist for the user.
+func (s *SavedSearchService) Results(ctx context.Context, userID, id int64, page, limit int, sort string) (*transport.FeedResponse, error) {
+	search, err := s.savedSearchRepo.GetByIDForUser(ctx, userID, id)
+	if err != nil || search == nil {
+		return nil, err
+	}
+
+	filter, err := ParseSavedSearchFilter(search.Filters)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.feedService.GetFeed(ctx, &userID, page, limit, sort, filter)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
@@ -0,0 +1,49 @@
+package services
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseSavedSearchFilter_KnownKeys(t *testing.T) {
+	f, err := ParseSavedSearchFilter(map[string]interface{}{
+		"agency":        "Food and Drug Administration",
+		"document_type": "Rule",
+		"keyword":       "safety",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if f.Agency != "Food and Drug Administration" || f.DocumentType != "Rule" || f.Keyword != "safety" {
+		t.Fatalf("unexpected filter: %#v", f)
+	}
+}
+
+func TestParseSavedSearchFilter_Empty(t *testing.T) {
+	f, err := ParseSavedSearchFilter(map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if f.Agency != "" || f.DocumentType != "" || f.Keyword != "" {
+		t.Fatalf("expected empty filter, got %#v", f)
+	}
+}
+
+func TestParseSavedSearchFilter_Rejects(t *testing.T) {
+	tests := []struct {
+		name    string
+		filters map[string]interface{}
+	}{
+		{name: "unknown key", filters: map[string]interface{}{"sort": "newest"}},
+		{name: "non-string value", filters: map[string]interface{}{"agency": 42.0}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseSavedSearchFilter(tc.filters)
+			if !errors.Is(err, ErrInvalidSavedSearchFilter) {
+				t.Fatalf("expected ErrInvalidSavedSearchFilter, got %v", err)
+			}
+		})
+	}
+}
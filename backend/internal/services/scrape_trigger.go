@@ -0,0 +1,70 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+)
+
+var (
+	ErrScrapeCooldown = errors.New("scrape triggered too recently")
+	ErrScrapeRunning  = errors.New("scrape already running")
+)
+
+// ScrapeTrigger launches manual scrapes in the background, refusing a new one while the
+// previous is still running or started less than cooldown ago.
+type ScrapeTrigger struct {
+	base     context.Context
+	cooldown time.Duration
+	run      func(ctx context.Context) error
+	now      func() time.Time
+
+	mu      sync.Mutex
+	lastRun time.Time
+	running bool
+}
+
+// NewScrapeTrigger runs each scrape under base, so cancelling it at shutdown stops a
+// scrape in progress.
+func NewScrapeTrigger(base context.Context, cooldown time.Duration, run func(ctx context.Context) error) *ScrapeTrigger {
+	return &ScrapeTrigger{
+		base:     base,
+		cooldown: cooldown,
+		run:      run,
+		now:      time.Now,
+	}
+}
+
+// Trigger starts a scrape. On ErrScrapeCooldown, remaining is how long the caller must
+// wait before the next trigger will be accepted.
+func (t *ScrapeTrigger) Trigger() (remaining time.Duration, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.running {
+		return 0, ErrScrapeRunning
+	}
+	if !t.lastRun.IsZero() {
+		if wait := t.cooldown - t.now().Sub(t.lastRun); wait > 0 {
+			return wait, ErrScrapeCooldown
+		}
+	}
+
+	t.lastRun = t.now()
+	t.running = true
+
+	// The scrape outlives the request that triggered it, so it runs under the trigger's
+	// base context instead.
+	go func() {
+		if err := t.run(t.base); err != nil {
+			log.Printf("Triggered scrape failed: %v", err)
+		}
+		t.mu.Lock()
+		t.running = false
+		t.mu.Unlock()
+	}()
+
+	return 0, nil
+}
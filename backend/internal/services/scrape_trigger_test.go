@@ -0,0 +1,72 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestScrapeTrigger_Cooldown(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	done := make(chan struct{}, 2)
+
+	trigger := NewScrapeTrigger(t.Context(), 10*time.Minute, func(context.Context) error {
+		done <- struct{}{}
+		return nil
+	})
+	trigger.now = func() time.Time { return now }
+
+	if _, err := trigger.Trigger(); err != nil {
+		t.Fatalf("first Trigger() error = %v", err)
+	}
+	<-done
+	waitIdle(t, trigger)
+
+	now = now.Add(4 * time.Minute)
+	remaining, err := trigger.Trigger()
+	if !errors.Is(err, ErrScrapeCooldown) {
+		t.Fatalf("Trigger() within cooldown error = %v, want %v", err, ErrScrapeCooldown)
+	}
+	if remaining != 6*time.Minute {
+		t.Fatalf("remaining = %v, want %v", remaining, 6*time.Minute)
+	}
+
+	now = now.Add(6 * time.Minute)
+	if _, err := trigger.Trigger(); err != nil {
+		t.Fatalf("Trigger() after cooldown error = %v", err)
+	}
+	<-done
+}
+
+func TestScrapeTrigger_RejectsWhileRunning(t *testing.T) {
+	release := make(chan struct{})
+	trigger := NewScrapeTrigger(t.Context(), 0, func(context.Context) error {
+		<-release
+		return nil
+	})
+
+	if _, err := trigger.Trigger(); err != nil {
+		t.Fatalf("first Trigger() error = %v", err)
+	}
+	if _, err := trigger.Trigger(); !errors.Is(err, ErrScrapeRunning) {
+		t.Fatalf("Trigger() while running error = %v, want %v", err, ErrScrapeRunning)
+	}
+	close(release)
+}
+
+// waitIdle blocks until the background run has cleared the running flag.
+func waitIdle(t *testing.T, trigger *ScrapeTrigger) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		trigger.mu.Lock()
+		running := trigger.running
+		trigger.mu.Unlock()
+		if !running {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatal("scrape still running after 1s")
+}
@@ -0,0 +1,29 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/alex/opengov-go/internal/constants"
+)
+
+var ErrNoProfileState = errors.New("profile has no US state")
+
+// ProfileStateName returns the name of the US state on the user's profile, for use as
+// repository.FeedFilter.StateName. It returns ErrNoProfileState when the profile has no
+// state or an unrecognized code.
+func (s *FeedService) ProfileStateName(ctx context.Context, userID int64) (string, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	if user == nil || user.State == nil {
+		return "", ErrNoProfileState
+	}
+	name, ok := constants.USStates[strings.ToUpper(*user.State)]
+	if !ok {
+		return "", ErrNoProfileState
+	}
+	return name, nil
+}
@@ -3,8 +3,11 @@ package services
 import (
 	"context"
 	"log"
+	"strings"
 
+	"github.com/alex/opengov-go/internal/client"
 	"github.com/alex/opengov-go/internal/config"
+	"github.com/alex/opengov-go/internal/domain"
 )
 
 // AIAnalysis contains all AI-generated fields for an article
@@ -13,18 +16,87 @@ type AIAnalysis struct {
 	Keypoints      []string // Key takeaways from the document
 	ImpactScore    string   // low, medium, high
 	PoliticalScore int      // -100 (left) to 100 (right)
+	Categories     []string // topics from constants.Categories
 }
 
 type Summarizer interface {
 	Analyze(ctx context.Context, title, abstract, agency string) (*AIAnalysis, error)
 }
 
-func NewSummarizer(cfg *config.Config) Summarizer {
-	if cfg.UseMockGrok {
+// UsageRecorder persists token usage for AI calls.
+type UsageRecorder interface {
+	Create(ctx context.Context, u *domain.AIUsage) error
+}
+
+type documentIDKey struct{}
+
+// WithDocumentID tags ctx with the policy document being analyzed so recorded AI usage
+// can be attributed to it.
+func WithDocumentID(ctx context.Context, id int64) context.Context {
+	return context.WithValue(ctx, documentIDKey{}, id)
+}
+
+func documentIDFromContext(ctx context.Context) (int64, bool) {
+	id, ok := ctx.Value(documentIDKey{}).(int64)
+	return id, ok
+}
+
+type languageKey struct{}
+
+// WithLanguage asks summarizers to write the summary and keypoints in lang, a key of
+// constants.Languages. Without it they write English.
+func WithLanguage(ctx context.Context, lang string) context.Context {
+	return context.WithValue(ctx, languageKey{}, lang)
+}
+
+func languageFromContext(ctx context.Context) (string, bool) {
+	lang, ok := ctx.Value(languageKey{}).(string)
+	return lang, ok && lang != ""
+}
+
+// NewSummarizer returns the backend selected by cfg.SummarizerProvider, wrapped in a
+// CircuitBreaker unless cfg.BreakerThreshold is 0. settings, usage and calls may be nil.
+func NewSummarizer(cfg *config.Config, settings SettingsReader, usage UsageRecorder, calls *client.CallLog) Summarizer {
+	s := newProviderSummarizer(cfg, settings, usage, calls)
+	if cfg.BreakerThreshold > 0 {
+		return NewCircuitBreaker(s, cfg.BreakerThreshold, cfg.BreakerCooldown)
+	}
+	return s
+}
+
+func newProviderSummarizer(cfg *config.Config, settings SettingsReader, usage UsageRecorder, calls *client.CallLog) Summarizer {
+	switch cfg.SummarizerProvider {
+	case config.SummarizerProviderMock:
 		return &MockSummarizer{}
+	case config.SummarizerProviderOpenAI:
+		if cfg.OpenAIAPIKey == "" {
+			log.Fatal("OPENAI_API_KEY is required when SUMMARIZER_PROVIDER=openai")
+		}
+		return NewOpenAISummarizer(cfg, settings, usage, calls)
+	default:
+		if cfg.GrokAPIKey == "" {
+			log.Fatal("GROK_API_KEY is required when SUMMARIZER_PROVIDER=xai")
+		}
+		return NewXAISummarizer(cfg, settings, usage, calls)
 	}
-	if cfg.GrokAPIKey == "" {
-		log.Fatal("GROK_API_KEY is required when USE_MOCK_GROK=false")
+}
+
+// FallbackSummary renders tmpl from a document's structured fields. Missing agency or
+// type values are replaced with generic wording so the result still reads cleanly.
+func FallbackSummary(tmpl string, doc *domain.PolicyDocument) string {
+	agency := "A federal agency"
+	if doc.Agency != nil && *doc.Agency != "" {
+		agency = *doc.Agency
 	}
-	return NewXAISummarizer(cfg)
+	docType := "a document"
+	if doc.DocumentType != nil && *doc.DocumentType != "" {
+		docType = *doc.DocumentType
+	}
+
+	r := strings.NewReplacer(
+		"{agency}", agency,
+		"{type}", docType,
+		"{title}", strings.TrimSpace(doc.Title),
+	)
+	return r.Replace(tmpl)
 }
@@ -0,0 +1,17 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/alex/opengov-go/internal/domain"
+)
+
+func TestFallbackSummary_MissingFields(t *testing.T) {
+	doc := &domain.PolicyDocument{Title: "  Notice of Meeting "}
+
+	got := FallbackSummary("{agency} issued {type}: {title}. Read more.", doc)
+	want := "A federal agency issued a document: Notice of Meeting. Read more."
+	if got != want {
+		t.Fatalf("summary = %q, want %q", got, want)
+	}
+}
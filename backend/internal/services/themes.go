@@ -0,0 +1,84 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/alex/opengov-go/internal/transport"
+)
+
+// themeStopwords are words too common in key points to say anything about a theme.
+var themeStopwords = map[string]bool{
+	"a": true, "about": true, "after": true, "all": true, "also": true, "an": true, "and": true,
+	"any": true, "are": true, "as": true, "at": true, "be": true, "been": true, "being": true,
+	"by": true, "can": true, "could": true, "for": true, "from": true, "has": true, "have": true,
+	"into": true, "is": true, "it": true, "its": true, "may": true, "more": true, "must": true,
+	"new": true, "not": true, "of": true, "on": true, "or": true, "other": true, "over": true,
+	"such": true, "than": true, "that": true, "the": true, "their": true, "these": true,
+	"this": true, "those": true, "through": true, "to": true, "under": true, "up": true,
+	"was": true, "were": true, "which": true, "who": true, "will": true, "with": true,
+	"would": true,
+}
+
+// themeTerms normalizes a key point into lowercase word tokens, dropping stopwords,
+// numbers and tokens shorter than three letters.
+func themeTerms(keyPoint string) []string {
+	words := strings.FieldsFunc(strings.ToLower(keyPoint), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	terms := words[:0]
+	for _, w := range words {
+		if len(w) < 3 || themeStopwords[w] || strings.IndexFunc(w, unicode.IsLetter) == -1 {
+			continue
+		}
+		terms = append(terms, w)
+	}
+	return terms
+}
+
+// RankThemes counts, for each term, how many documents mention it in any key point, and
+// returns the top limit terms by count (ties broken alphabetically).
+func RankThemes(docs [][]string, limit int) []transport.Theme {
+	counts := make(map[string]int)
+	for _, keyPoints := range docs {
+		seen := make(map[string]bool)
+		for _, kp := range keyPoints {
+			for _, term := range themeTerms(kp) {
+				if !seen[term] {
+					seen[term] = true
+					counts[term]++
+				}
+			}
+		}
+	}
+
+	themes := make([]transport.Theme, 0, len(counts))
+	for term, n := range counts {
+		themes = append(themes, transport.Theme{Theme: term, Count: n})
+	}
+	sort.Slice(themes, func(i, j int) bool {
+		if themes[i].Count != themes[j].Count {
+			return themes[i].Count > themes[j].Count
+		}
+		return themes[i].Theme < themes[j].Theme
+	})
+
+	if len(themes) > limit {
+		themes = themes[:limit]
+	}
+	return themes
+}
+
+// GetThemes ranks themes across key points of entries published in the last days days.
+func (s *FeedService) GetThemes(ctx context.Context, days, limit int) (transport.ThemesResponse, error) {
+	since := time.Now().AddDate(0, 0, -days)
+	docs, err := s.feedRepo.GetRecentKeyPoints(ctx, since)
+	if err != nil {
+		return transport.ThemesResponse{}, err
+	}
+	return transport.ThemesResponse{Days: days, Themes: RankThemes(docs, limit)}, nil
+}
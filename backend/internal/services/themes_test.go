@@ -0,0 +1,44 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/alex/opengov-go/internal/transport"
+)
+
+func TestThemeTerms(t *testing.T) {
+	got := themeTerms("The EPA will tighten PFAS limits in drinking-water by 2026.")
+	want := []string{"epa", "tighten", "pfas", "limits", "drinking", "water"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("themeTerms() = %v, want %v", got, want)
+	}
+}
+
+func TestRankThemes(t *testing.T) {
+	docs := [][]string{
+		{"New PFAS limits for drinking water", "Utilities must test for PFAS"},
+		{"PFAS reporting deadline extended", "Affects chemical manufacturers"},
+		{"Drinking water grants expanded", "States receive funding"},
+		{"PFAS cleanup guidance issued"},
+		{"Medicare payment rates updated"},
+	}
+
+	// pfas appears twice in the first document but counts once per document;
+	// drinking and water tie and are ordered alphabetically.
+	got := RankThemes(docs, 3)
+	want := []transport.Theme{
+		{Theme: "pfas", Count: 3},
+		{Theme: "drinking", Count: 2},
+		{Theme: "water", Count: 2},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("RankThemes() = %+v, want %+v", got, want)
+	}
+}
+
+func TestRankThemes_Empty(t *testing.T) {
+	if got := RankThemes(nil, 10); len(got) != 0 {
+		t.Fatalf("RankThemes(nil) = %+v, want empty", got)
+	}
+}
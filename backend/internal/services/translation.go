@@ -0,0 +1,69 @@
+package services
+
+import (
+	"context"
+	"log"
+
+	"github.com/alex/opengov-go/internal/repository"
+)
+
+// TranslationService writes document summaries and keypoints in other languages by
+// re-running analysis with WithLanguage.
+type TranslationService struct {
+	docRepo    *repository.PolicyDocumentRepository
+	summarizer Summarizer
+	languages  []string
+}
+
+func NewTranslationService(docRepo *repository.PolicyDocumentRepository, summarizer Summarizer, languages []string) *TranslationService {
+	return &TranslationService{
+		docRepo:    docRepo,
+		summarizer: summarizer,
+		languages:  languages,
+	}
+}
+
+// Translate fills in missing or stale translations for every configured language, in
+// batches of batchSize. A document whose analysis fails is logged and skipped for the
+// rest of the run; it is retried on the next one.
+func (s *TranslationService) Translate(ctx context.Context, batchSize int) (translated, failed int, err error) {
+	if batchSize <= 0 {
+		batchSize = 50
+	}
+
+	for _, lang := range s.languages {
+		var skip []int64
+		for {
+			docs, err := s.docRepo.ListNeedingTranslation(ctx, lang, skip, batchSize)
+			if err != nil {
+				return translated, failed, err
+			}
+			if len(docs) == 0 {
+				break
+			}
+
+			for _, d := range docs {
+				if err := ctx.Err(); err != nil {
+					return translated, failed, err
+				}
+
+				agency := ""
+				if d.Agency != nil {
+					agency = *d.Agency
+				}
+				analysis, err := s.summarizer.Analyze(WithLanguage(WithDocumentID(ctx, d.PolicyDocumentID), lang), d.Title, d.Summary, agency)
+				if err != nil || analysis.Summary == "" {
+					log.Printf("Failed to translate policy_documents(%d) to %s: %v", d.PolicyDocumentID, lang, err)
+					skip = append(skip, d.PolicyDocumentID)
+					failed++
+					continue
+				}
+				if err := s.docRepo.UpsertTranslation(ctx, d.PolicyDocumentID, lang, analysis.Summary, analysis.Keypoints); err != nil {
+					return translated, failed, err
+				}
+				translated++
+			}
+		}
+	}
+	return translated, failed, nil
+}
@@ -0,0 +1,150 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/alex/opengov-go/internal/config"
+	"github.com/alex/opengov-go/internal/constants"
+	"github.com/alex/opengov-go/internal/domain"
+)
+
+// newChatServer serves a single chat completion whose message content is content, and
+// records the request body it received.
+func newChatServer(t *testing.T, content string, got *chatRequest) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/chat/completions" {
+			t.Errorf("path = %q, want /chat/completions", r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(got); err != nil {
+			t.Errorf("decode request: %v", err)
+		}
+		json.NewEncoder(w).Encode(chatResponse{
+			Choices: []chatChoice{{Message: chatMessage{Role: "assistant", Content: content}}},
+			Usage:   &chatUsage{PromptTokens: 120, CompletionTokens: 40, TotalTokens: 160},
+		})
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestXAISummarizer_ParsesFencedJSON(t *testing.T) {
+	content := "```json\n{\"summary\":\"Short\",\"keypoints\":[\"a\",\"b\"],\"impact_score\":\"high\",\"political_score\":250}\n```"
+	var req chatRequest
+	srv := newChatServer(t, content, &req)
+
+	s := NewXAISummarizer(&config.Config{GrokAPIURL: srv.URL, GrokModel: "grok-test", GrokTimeout: 5}, nil, nil, nil)
+	got, err := s.Analyze(context.Background(), "Title", "Abstract", "EPA")
+	if err != nil {
+		t.Fatalf("Analyze() error: %v", err)
+	}
+
+	// Two keypoints are below the minimum, so the abstract supplies a third.
+	if got.Summary != "Short" || !slices.Equal(got.Keypoints, []string{"a", "b", "Abstract"}) || got.ImpactScore != "high" {
+		t.Fatalf("unexpected analysis: %+v", got)
+	}
+	if got.PoliticalScore != 100 {
+		t.Fatalf("PoliticalScore = %d, want clamped 100", got.PoliticalScore)
+	}
+	if req.Model != "grok-test" || !strings.Contains(req.Messages[0].Content, "Agency: EPA") {
+		t.Fatalf("unexpected request: %+v", req)
+	}
+}
+
+func TestXAISummarizer_TruncatesLongSummary(t *testing.T) {
+	sentence := "The agency is changing how benefits are calculated for millions of retirees across the country. "
+	long := strings.TrimSpace(strings.Repeat(sentence, 7))
+	if len(long) < 600 {
+		t.Fatalf("test summary is only %d characters", len(long))
+	}
+	content, _ := json.Marshal(analysisResponse{Summary: long, Keypoints: []string{"a", "b", "c"}})
+	var req chatRequest
+	srv := newChatServer(t, string(content), &req)
+
+	s := NewXAISummarizer(&config.Config{GrokAPIURL: srv.URL, GrokTimeout: 5, SummaryMaxChars: 280}, nil, nil, nil)
+	got, err := s.Analyze(context.Background(), "Title", "Abstract", "SSA")
+	if err != nil {
+		t.Fatalf("Analyze() error: %v", err)
+	}
+	if n := len([]rune(got.Summary)); n > 280 || n == 0 {
+		t.Fatalf("summary is %d characters, want 1-280", n)
+	}
+	if !strings.HasSuffix(got.Summary, "country.") {
+		t.Fatalf("summary should end on a whole sentence: %q", got.Summary)
+	}
+}
+
+func TestNormalizeCategories(t *testing.T) {
+	got := normalizeCategories([]string{" Health ", "aliens", "health", "taxes", "energy", "trade"})
+	if want := []string{"health", "taxes", "energy"}; !slices.Equal(got, want) {
+		t.Fatalf("normalizeCategories() = %v, want %v", got, want)
+	}
+	if got := normalizeCategories(nil); got == nil || len(got) != 0 {
+		t.Fatalf("normalizeCategories(nil) = %#v, want empty non-nil", got)
+	}
+}
+
+func TestDefaultAnalysisPrompt_ListsEveryCategory(t *testing.T) {
+	for _, c := range constants.Categories {
+		if !strings.Contains(DefaultAnalysisPrompt, c) {
+			t.Errorf("DefaultAnalysisPrompt does not list category %q", c)
+		}
+	}
+}
+
+type fakeUsageRecorder struct {
+	recorded []domain.AIUsage
+	err      error
+}
+
+func (f *fakeUsageRecorder) Create(_ context.Context, u *domain.AIUsage) error {
+	f.recorded = append(f.recorded, *u)
+	return f.err
+}
+
+func TestXAISummarizer_RecordsUsage(t *testing.T) {
+	var req chatRequest
+	srv := newChatServer(t, `{"summary":"Short","impact_score":"low"}`, &req)
+	usage := &fakeUsageRecorder{}
+
+	s := NewXAISummarizer(&config.Config{GrokAPIURL: srv.URL, GrokModel: "grok-test", GrokTimeout: 5}, nil, usage, nil)
+	if _, err := s.Analyze(WithDocumentID(context.Background(), 42), "Title", "Abstract", "EPA"); err != nil {
+		t.Fatalf("Analyze() error: %v", err)
+	}
+
+	if len(usage.recorded) != 1 {
+		t.Fatalf("recorded %d usage rows, want 1", len(usage.recorded))
+	}
+	got := usage.recorded[0]
+	if got.Provider != config.SummarizerProviderXAI || got.Model != "grok-test" {
+		t.Fatalf("unexpected provider/model: %+v", got)
+	}
+	if got.PromptTokens != 120 || got.CompletionTokens != 40 || got.TotalTokens != 160 {
+		t.Fatalf("unexpected token counts: %+v", got)
+	}
+	if got.PolicyDocumentID == nil || *got.PolicyDocumentID != 42 {
+		t.Fatalf("PolicyDocumentID = %v, want 42", got.PolicyDocumentID)
+	}
+}
+
+func TestXAISummarizer_UsageFailureDoesNotFailAnalysis(t *testing.T) {
+	var req chatRequest
+	srv := newChatServer(t, `{"summary":"Short","impact_score":"low"}`, &req)
+	usage := &fakeUsageRecorder{err: errors.New("db down")}
+
+	s := NewXAISummarizer(&config.Config{GrokAPIURL: srv.URL, GrokTimeout: 5}, nil, usage, nil)
+	got, err := s.Analyze(context.Background(), "Title", "Abstract", "EPA")
+	if err != nil {
+		t.Fatalf("Analyze() error: %v", err)
+	}
+	if got.Summary != "Short" {
+		t.Fatalf("Summary = %q, want Short", got.Summary)
+	}
+}
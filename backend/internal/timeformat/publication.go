@@ -0,0 +1,17 @@
+package timeformat
+
+import "time"
+
+// Date is the layout of Federal Register publication dates.
+const Date = "2006-01-02"
+
+// ParsePublicationDate interprets a bare publication date as midnight in loc, the
+// timezone the source publishes in.
+func ParsePublicationDate(s string, loc *time.Location) (time.Time, error) {
+	return time.ParseInLocation(Date, s, loc)
+}
+
+// PublicationDay returns the calendar day t falls on in loc.
+func PublicationDay(t time.Time, loc *time.Location) string {
+	return t.In(loc).Format(Date)
+}
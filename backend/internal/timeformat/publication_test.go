@@ -0,0 +1,48 @@
+package timeformat
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPublicationDay_EasternNearUTCMidnight(t *testing.T) {
+	eastern, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("load location: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		t    time.Time
+		want string
+	}{
+		// 2025-03-11 01:30 UTC is still the evening of 2025-03-10 in Eastern.
+		{name: "after UTC midnight, same Eastern day", t: time.Date(2025, 3, 11, 1, 30, 0, 0, time.UTC), want: "2025-03-10"},
+		{name: "start of Eastern day", t: time.Date(2025, 3, 10, 4, 0, 0, 0, time.UTC), want: "2025-03-10"},
+		// 2025-03-10 03:30 UTC is still 2025-03-09 in Eastern.
+		{name: "before Eastern midnight", t: time.Date(2025, 3, 10, 3, 30, 0, 0, time.UTC), want: "2025-03-09"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := PublicationDay(tc.t, eastern); got != tc.want {
+				t.Fatalf("PublicationDay(%v) = %q, want %q", tc.t, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParsePublicationDate_UsesSourceTimezone(t *testing.T) {
+	eastern, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("load location: %v", err)
+	}
+
+	got, err := ParsePublicationDate("2025-01-15", eastern)
+	if err != nil {
+		t.Fatalf("ParsePublicationDate() error: %v", err)
+	}
+	if want := time.Date(2025, 1, 15, 5, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Fatalf("ParsePublicationDate() = %v, want %v", got.UTC(), want)
+	}
+}
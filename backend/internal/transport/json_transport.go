@@ -67,6 +67,64 @@ type UserResponse struct {
 	LastLoginAt      *string `json:"last_login_at,omitempty"`
 }
 
+// AdminUserResponse is a user as seen by superusers, including account flags.
+type AdminUserResponse struct {
+	UserResponse
+	IsSuperuser bool `json:"is_superuser"`
+}
+
+type AdminUserListResponse struct {
+	Users  []AdminUserResponse `json:"users"`
+	Total  int                 `json:"total"`
+	Limit  int                 `json:"limit"`
+	Offset int                 `json:"offset"`
+}
+
+type JobResponse struct {
+	ID         int64      `json:"id"`
+	Type       string     `json:"type"`
+	Status     string     `json:"status"`
+	Processed  int        `json:"processed"`
+	Skipped    int        `json:"skipped"`
+	Total      *int       `json:"total,omitempty"`
+	Error      *string    `json:"error,omitempty"`
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+type JobListResponse struct {
+	Jobs []JobResponse `json:"jobs"`
+}
+
+// FailedRawDocumentResponse is a raw upstream row that canonicalization could not parse.
+type FailedRawDocumentResponse struct {
+	ID         int64     `json:"id"`
+	SourceKey  string    `json:"source_key"`
+	ExternalID string    `json:"external_id"`
+	Error      string    `json:"error"`
+	Attempts   int       `json:"attempts"`
+	FetchedAt  time.Time `json:"fetched_at"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// RawDocumentResponse is an upstream payload as stored for a policy document.
+type RawDocumentResponse struct {
+	ID         int64          `json:"id"`
+	SourceKey  string         `json:"source_key"`
+	ExternalID string         `json:"external_id"`
+	FetchedAt  time.Time      `json:"fetched_at"`
+	CreatedAt  time.Time      `json:"created_at"`
+	RawData    map[string]any `json:"raw_data"`
+}
+
+type AdminUserUpdateRequest struct {
+	IsActive    *bool `json:"is_active"`
+	IsSuperuser *bool `json:"is_superuser"`
+	IsVerified  *bool `json:"is_verified"`
+}
+
 type UpdateUserRequest struct {
 	Name             *string `json:"name,omitempty"`
 	PictureURL       *string `json:"picture_url,omitempty"`
@@ -90,8 +148,16 @@ type AuthUserResponse struct {
 }
 
 // Likes
+// ToggleLikeRequest sets a reaction: value 1 likes, -1 dislikes and 0 clears. Older
+// clients send is_positive instead; value wins when both are present.
 type ToggleLikeRequest struct {
-	Value int `json:"value"`
+	Value      *int  `json:"value"`
+	IsPositive *bool `json:"is_positive"`
+}
+
+type LikeCountsResponse struct {
+	Likes    int `json:"likes"`
+	Dislikes int `json:"dislikes"`
 }
 
 // Feed
@@ -103,19 +169,195 @@ type FeedEntryResponse struct {
 	ImpactScore    *string  `json:"impact_score,omitempty"`
 	PoliticalScore *int     `json:"political_score,omitempty"`
 	SourceURL      string   `json:"source_url"`
+	Source         string   `json:"source"`
 	PublishedAt    string   `json:"published_at"`
 	IsBookmarked   *bool    `json:"is_bookmarked,omitempty"`
 	UserLikeStatus *int     `json:"user_like_status,omitempty"`
 	LikesCount     int      `json:"likes_count"`
 	DislikesCount  int      `json:"dislikes_count"`
+	// Language is set when Summary and Keypoints are a translation rather than English.
+	Language string `json:"language,omitempty"`
+	// Agencies lists every agency behind the document, primary first. Only the
+	// single-entry endpoints set it.
+	Agencies []FeedEntryAgency `json:"agencies,omitempty"`
+}
+
+// FeedEntryAgency is an agency a feed entry's document lists. ID is the agencies.id,
+// or null when the agency has not been synced.
+type FeedEntryAgency struct {
+	ID   *int64 `json:"id"`
+	Name string `json:"name"`
+}
+
+// FeedExportItem is one line of the NDJSON feed export. Cursor resumes the export after
+// this item.
+type FeedExportItem struct {
+	ID             int64   `json:"id"`
+	Title          string  `json:"title"`
+	Agency         *string `json:"agency"`
+	Summary        string  `json:"summary"`
+	ImpactScore    *string `json:"impact_score"`
+	PoliticalScore *int    `json:"political_score"`
+	PublishedAt    string  `json:"published_at"`
+	SourceURL      string  `json:"source_url"`
+	Cursor         string  `json:"cursor"`
+}
+
+// FeedEntryFullResponse is a feed entry together with the full upstream abstract, which
+// the short summary only excerpts.
+type FeedEntryFullResponse struct {
+	FeedEntryResponse
+	Abstract *string `json:"abstract"`
 }
 
+// Values of FeedResponse.FeedEmptyReason.
+const (
+	FeedEmptyNoContent = "no_content" // nothing has been published yet, e.g. before the first scrape
+	FeedEmptyFiltered  = "filtered"   // entries exist but none match the filters
+)
+
 type FeedResponse struct {
 	Items   []FeedEntryResponse `json:"items"`
 	Page    int                 `json:"page"`
 	Limit   int                 `json:"limit"`
 	Total   int                 `json:"total"`
 	HasNext bool                `json:"has_next"`
+	// FeedEmptyReason is set only when Total is 0.
+	FeedEmptyReason string `json:"feed_empty_reason,omitempty"`
+	// ServerTime is set by GET /api/feed; pass it back as since to ask what is new.
+	ServerTime string `json:"server_time,omitempty"`
+}
+
+// ActivityItemResponse is one of the user's interactions with a feed entry. Action is
+// liked, disliked or bookmarked.
+type ActivityItemResponse struct {
+	Action     string            `json:"action"`
+	OccurredAt string            `json:"occurred_at"`
+	Entry      FeedEntryResponse `json:"entry"`
+}
+
+type ActivityResponse struct {
+	Items   []ActivityItemResponse `json:"items"`
+	Page    int                    `json:"page"`
+	Limit   int                    `json:"limit"`
+	Total   int                    `json:"total"`
+	HasNext bool                   `json:"has_next"`
+}
+
+type Theme struct {
+	Theme string `json:"theme"`
+	Count int    `json:"count"`
+}
+
+type ThemesResponse struct {
+	Days   int     `json:"days"`
+	Themes []Theme `json:"themes"`
+}
+
+type ArchiveBucket struct {
+	Year  int `json:"year"`
+	Month int `json:"month"`
+	Count int `json:"count"`
+}
+
+type ArchiveResponse struct {
+	Buckets []ArchiveBucket `json:"buckets"`
+}
+
+// Agencies
+type AgencyRef struct {
+	ID        int64   `json:"id"`
+	Name      string  `json:"name"`
+	ShortName *string `json:"short_name,omitempty"`
+	Slug      string  `json:"slug"`
+}
+
+type AgencyResponse struct {
+	ID          int64      `json:"id"`
+	FRAgencyID  int64      `json:"fr_agency_id"`
+	Name        string     `json:"name"`
+	ShortName   *string    `json:"short_name,omitempty"`
+	Slug        string     `json:"slug"`
+	Description *string    `json:"description,omitempty"`
+	URL         *string    `json:"url,omitempty"`
+	ParentID    *int64     `json:"parent_id,omitempty"`
+	Parent      *AgencyRef `json:"parent,omitempty"`
+	CreatedAt   string     `json:"created_at"`
+	UpdatedAt   string     `json:"updated_at"`
+}
+
+type AgencyTreeNode struct {
+	ID         int64            `json:"id"`
+	FRAgencyID int64            `json:"fr_agency_id"`
+	Name       string           `json:"name"`
+	ShortName  *string          `json:"short_name,omitempty"`
+	Slug       string           `json:"slug"`
+	Children   []AgencyTreeNode `json:"children"`
+}
+
+type AgencyEngagement struct {
+	ID        int64   `json:"id"`
+	Name      string  `json:"name"`
+	ShortName *string `json:"short_name,omitempty"`
+	Slug      string  `json:"slug"`
+	Likes     int     `json:"likes"`
+	Bookmarks int     `json:"bookmarks"`
+	Total     int     `json:"total"`
+}
+
+type AgencyEngagementResponse struct {
+	Window   string             `json:"window"`
+	Agencies []AgencyEngagement `json:"agencies"`
+}
+
+type CategoryCount struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+type CategoriesResponse struct {
+	Categories []CategoryCount `json:"categories"`
+}
+
+type AgencyDiffEntry struct {
+	FRAgencyID int64  `json:"fr_agency_id"`
+	Name       string `json:"name"`
+	Slug       string `json:"slug"`
+	ParentID   *int64 `json:"parent_id"`
+}
+
+type AgencyDiffChange struct {
+	FRAgencyID int64           `json:"fr_agency_id"`
+	Fields     []string        `json:"fields"`
+	Local      AgencyDiffEntry `json:"local"`
+	Upstream   AgencyDiffEntry `json:"upstream"`
+}
+
+type AgencyDiffResponse struct {
+	Added   []AgencyDiffEntry  `json:"added"`
+	Removed []AgencyDiffEntry  `json:"removed"`
+	Changed []AgencyDiffChange `json:"changed"`
+}
+
+type AgencyStatsResponse struct {
+	Agency          AgencyRef      `json:"agency"`
+	TotalDocuments  int            `json:"total_documents"`
+	ByDocumentType  map[string]int `json:"by_document_type"`
+	LastPublishedAt *string        `json:"last_published_at"`
+}
+
+// Saved searches
+type SavedSearchRequest struct {
+	Name    string                 `json:"name" binding:"required"`
+	Filters map[string]interface{} `json:"filters" binding:"required"`
+}
+
+type SavedSearchResponse struct {
+	ID        int64                  `json:"id"`
+	Name      string                 `json:"name"`
+	Filters   map[string]interface{} `json:"filters"`
+	CreatedAt string                 `json:"created_at"`
+	UpdatedAt string                 `json:"updated_at"`
 }
 
 // Admin
@@ -123,4 +365,92 @@ type StatsResponse struct {
 	TotalArticles  int        `json:"total_articles"`
 	LastScrapeTime *time.Time `json:"last_scrape_time,omitempty"`
 	LastScrapeAge  string     `json:"last_scrape_human,omitempty"`
+	// ByDocumentType and ByImpactScore use "Unknown" and "unscored" for unset values.
+	ByDocumentType map[string]int `json:"by_document_type"`
+	ByImpactScore  map[string]int `json:"by_impact_score"`
+	AddedLast24h   int            `json:"added_last_24h"`
+	AddedLast7d    int            `json:"added_last_7d"`
+	// Enriched and Unenriched split TotalArticles by whether AI analysis has run.
+	Enriched   int `json:"enriched"`
+	Unenriched int `json:"unenriched"`
+}
+
+type FlagUpdateRequest struct {
+	Enabled *bool `json:"enabled"`
+}
+
+// BackfillRequest is an inclusive range of publication dates, formatted YYYY-MM-DD.
+type BackfillRequest struct {
+	StartDate string `json:"start_date" binding:"required"`
+	EndDate   string `json:"end_date" binding:"required"`
+}
+
+// ReprocessAllRequest narrows a bulk reprocess. Every field is optional; an empty request
+// reprocesses every document. Dates are inclusive publication dates, formatted YYYY-MM-DD.
+type ReprocessAllRequest struct {
+	Agency    string `json:"agency,omitempty"`
+	StartDate string `json:"start_date,omitempty"`
+	EndDate   string `json:"end_date,omitempty"`
+}
+
+type AnalysisPromptRequest struct {
+	Template string `json:"template" binding:"required"`
+}
+
+type AnalysisPromptResponse struct {
+	Template  string `json:"template"`
+	IsDefault bool   `json:"is_default"`
+}
+
+type DocumentEditRequest struct {
+	Summary   *string  `json:"summary"`
+	Keypoints []string `json:"keypoints"`
+}
+
+// AdminDocumentResponse is a policy document's AI-derived fields after an admin change.
+type AdminDocumentResponse struct {
+	ID             int64     `json:"id"`
+	Summary        string    `json:"summary"`
+	Keypoints      []string  `json:"keypoints"`
+	ImpactScore    *string   `json:"impact_score"`
+	PoliticalScore *int      `json:"political_score"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+type ExternalCall struct {
+	Service   string    `json:"service"`
+	Method    string    `json:"method"`
+	Endpoint  string    `json:"endpoint"`
+	Status    int       `json:"status"`
+	LatencyMS int64     `json:"latency_ms"`
+	Error     string    `json:"error,omitempty"`
+	At        time.Time `json:"at"`
+}
+
+type AIUsageDay struct {
+	Date             string `json:"date"`
+	Calls            int    `json:"calls"`
+	PromptTokens     int    `json:"prompt_tokens"`
+	CompletionTokens int    `json:"completion_tokens"`
+	TotalTokens      int    `json:"total_tokens"`
+}
+
+type AIUsageResponse struct {
+	Days []AIUsageDay `json:"days"`
+}
+
+// ScraperConfigResponse is the effective scraper configuration. It must never carry
+// credentials such as API keys or database passwords.
+type ScraperConfigResponse struct {
+	IntervalMinutes         int    `json:"interval_minutes"`
+	DaysLookback            int    `json:"days_lookback"`
+	PublicationTimezone     string `json:"publication_timezone"`
+	PerPage                 int    `json:"per_page"`
+	MaxPages                int    `json:"max_pages"`
+	FederalRegisterAPIURL   string `json:"federal_register_api_url"`
+	FederalRegisterTimeout  int    `json:"federal_register_timeout_seconds"`
+	AIMode                  string `json:"ai_mode"`
+	AIModel                 string `json:"ai_model"`
+	AITimeout               int    `json:"ai_timeout_seconds"`
+	FallbackSummaryTemplate string `json:"fallback_summary_template"`
 }
@@ -0,0 +1,15 @@
+-- 010_create_user_agency_follows.sql
+-- user_agency_follows
+
+CREATE TABLE IF NOT EXISTS user_agency_follows (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    agency_id BIGINT NOT NULL REFERENCES agencies(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE(user_id, agency_id)
+);
+
+-- Indexes
+CREATE INDEX IF NOT EXISTS idx_user_agency_follows_user_id ON user_agency_follows(user_id);
+CREATE INDEX IF NOT EXISTS idx_agencies_slug ON agencies(slug);
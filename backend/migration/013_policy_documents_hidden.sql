@@ -0,0 +1,8 @@
+-- 013_policy_documents_hidden.sql
+-- Let admins hide spammy or duplicate documents from the feed without deleting them.
+
+ALTER TABLE policy_documents
+    ADD COLUMN IF NOT EXISTS hidden BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX IF NOT EXISTS idx_policy_documents_hidden
+    ON policy_documents(id) WHERE hidden;
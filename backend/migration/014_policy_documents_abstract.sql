@@ -0,0 +1,12 @@
+-- 014_policy_documents_abstract.sql
+-- Keep the full upstream abstract; summary holds only a truncated placeholder or the AI summary.
+
+ALTER TABLE policy_documents
+    ADD COLUMN IF NOT EXISTS abstract TEXT;
+
+UPDATE policy_documents pd
+SET abstract = r.raw_data->>'abstract'
+FROM raw_policy_documents r
+WHERE r.policy_document_id = pd.id
+  AND pd.abstract IS NULL
+  AND r.raw_data->>'abstract' IS NOT NULL;
@@ -0,0 +1,14 @@
+-- 015_create_document_cfr_refs.sql
+-- CFR title/part references parsed from the Federal Register cfr_references field.
+
+CREATE TABLE IF NOT EXISTS document_cfr_refs (
+    policy_document_id BIGINT NOT NULL REFERENCES policy_documents(id) ON DELETE CASCADE,
+    cfr_title INTEGER NOT NULL,
+    cfr_part INTEGER NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (policy_document_id, cfr_title, cfr_part)
+);
+
+CREATE INDEX IF NOT EXISTS idx_document_cfr_refs_title
+    ON document_cfr_refs(cfr_title, policy_document_id);
@@ -0,0 +1,10 @@
+-- 016_create_scrape_state.sql
+-- Per-source high-water mark so raw ingestion only asks for documents it has not seen.
+
+CREATE TABLE IF NOT EXISTS scrape_state (
+    source_key TEXT PRIMARY KEY,
+    last_published_at TIMESTAMPTZ NOT NULL,
+    last_run_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
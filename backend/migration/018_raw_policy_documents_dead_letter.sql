@@ -0,0 +1,9 @@
+-- 018_raw_policy_documents_dead_letter.sql
+-- Park raw rows that fail canonicalization instead of aborting the whole run.
+
+ALTER TABLE raw_policy_documents
+    ADD COLUMN IF NOT EXISTS error TEXT,
+    ADD COLUMN IF NOT EXISTS attempts INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_raw_policy_documents_failed
+    ON raw_policy_documents(id) WHERE error IS NOT NULL;
@@ -0,0 +1,13 @@
+-- 019_create_document_categories.sql
+-- Topic categories assigned to a policy document by AI analysis.
+
+CREATE TABLE IF NOT EXISTS document_categories (
+    policy_document_id BIGINT NOT NULL REFERENCES policy_documents(id) ON DELETE CASCADE,
+    category TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (policy_document_id, category)
+);
+
+CREATE INDEX IF NOT EXISTS idx_document_categories_category
+    ON document_categories(category, policy_document_id);
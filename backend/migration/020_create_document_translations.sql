@@ -0,0 +1,12 @@
+-- 020_create_document_translations.sql
+-- AI-generated summaries and keypoints in languages other than English.
+
+CREATE TABLE IF NOT EXISTS document_translations (
+    policy_document_id BIGINT NOT NULL REFERENCES policy_documents(id) ON DELETE CASCADE,
+    lang TEXT NOT NULL,
+    summary TEXT NOT NULL,
+    keypoints JSONB,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (policy_document_id, lang)
+);
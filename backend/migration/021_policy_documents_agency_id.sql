@@ -0,0 +1,16 @@
+-- 021_policy_documents_agency_id.sql
+-- Link each policy document to its primary agency record; agency keeps the display name.
+
+ALTER TABLE policy_documents
+    ADD COLUMN IF NOT EXISTS agency_id BIGINT REFERENCES agencies(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_policy_documents_agency_id ON policy_documents(agency_id);
+
+UPDATE policy_documents pd
+SET agency_id = a.id
+FROM raw_policy_documents r
+JOIN agencies a ON a.fr_agency_id = CASE
+    WHEN r.raw_data->'agencies'->0->>'id' ~ '^[0-9]+$' THEN (r.raw_data->'agencies'->0->>'id')::BIGINT
+END
+WHERE r.policy_document_id = pd.id
+  AND pd.agency_id IS NULL;
@@ -0,0 +1,39 @@
+-- 022_create_document_agencies.sql
+-- Every agency a policy document lists, in upstream order; position 0 is the primary agency.
+
+CREATE TABLE IF NOT EXISTS document_agencies (
+    policy_document_id BIGINT NOT NULL REFERENCES policy_documents(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    agency_id BIGINT REFERENCES agencies(id) ON DELETE SET NULL,
+    name TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (policy_document_id, position)
+);
+
+CREATE INDEX IF NOT EXISTS idx_document_agencies_name
+    ON document_agencies(name, policy_document_id);
+
+CREATE INDEX IF NOT EXISTS idx_document_agencies_agency_id
+    ON document_agencies(agency_id);
+
+INSERT INTO document_agencies (policy_document_id, position, agency_id, name)
+SELECT DISTINCT ON (r.policy_document_id, e.name)
+    r.policy_document_id,
+    e.ord - 1,
+    a.id,
+    e.name
+FROM raw_policy_documents r
+CROSS JOIN LATERAL (
+    SELECT COALESCE(NULLIF(x.value->>'name', ''), NULLIF(x.value->>'raw_name', '')) AS name,
+           x.value->>'id' AS fr_id,
+           x.ordinality AS ord
+    FROM jsonb_array_elements(
+        CASE WHEN jsonb_typeof(r.raw_data->'agencies') = 'array' THEN r.raw_data->'agencies' ELSE '[]'::jsonb END
+    ) WITH ORDINALITY AS x(value, ordinality)
+) e
+LEFT JOIN agencies a ON a.fr_agency_id = CASE WHEN e.fr_id ~ '^[0-9]+$' THEN e.fr_id::BIGINT END
+WHERE r.policy_document_id IS NOT NULL
+  AND e.name IS NOT NULL
+ORDER BY r.policy_document_id, e.name, e.ord
+ON CONFLICT DO NOTHING;
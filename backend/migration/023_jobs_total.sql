@@ -0,0 +1,5 @@
+-- 023_jobs_total.sql
+-- Number of items a job expects to handle, when known up front, so progress reads as processed of total.
+
+ALTER TABLE jobs
+    ADD COLUMN IF NOT EXISTS total INTEGER;
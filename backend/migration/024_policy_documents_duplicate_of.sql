@@ -0,0 +1,8 @@
+-- 024_policy_documents_duplicate_of.sql
+-- Mark documents merged into an earlier copy of the same policy from another source.
+
+ALTER TABLE policy_documents
+    ADD COLUMN IF NOT EXISTS duplicate_of BIGINT REFERENCES policy_documents(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_policy_documents_duplicate_of
+    ON policy_documents(duplicate_of) WHERE duplicate_of IS NOT NULL;
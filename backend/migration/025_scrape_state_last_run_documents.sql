@@ -0,0 +1,5 @@
+-- 025_scrape_state_last_run_documents.sql
+-- How many documents a source returned on its last successful scrape, so an empty run reads as "no new documents" rather than a stall.
+
+ALTER TABLE scrape_state
+    ADD COLUMN IF NOT EXISTS last_run_documents INTEGER;
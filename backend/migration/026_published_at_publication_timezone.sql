@@ -0,0 +1,22 @@
+-- 026_published_at_publication_timezone.sql
+-- Move publication dates stored as UTC midnight to midnight in PUBLICATION_TIMEZONE, matching how new rows are stored, so older documents land on the right archive day.
+-- MigrateUp sets opengov.publication_timezone from the config; America/New_York is the config default.
+-- Only values at exactly 00:00 UTC are legacy; rows already stored at local midnight are left alone.
+
+UPDATE policy_documents
+SET published_at = (published_at AT TIME ZONE 'UTC')::date::timestamp
+        AT TIME ZONE COALESCE(NULLIF(current_setting('opengov.publication_timezone', true), ''), 'America/New_York'),
+    updated_at = NOW()
+WHERE published_at = (published_at AT TIME ZONE 'UTC')::date::timestamp AT TIME ZONE 'UTC';
+
+UPDATE feed_entries
+SET published_at = (published_at AT TIME ZONE 'UTC')::date::timestamp
+        AT TIME ZONE COALESCE(NULLIF(current_setting('opengov.publication_timezone', true), ''), 'America/New_York'),
+    updated_at = NOW()
+WHERE published_at = (published_at AT TIME ZONE 'UTC')::date::timestamp AT TIME ZONE 'UTC';
+
+UPDATE scrape_state
+SET last_published_at = (last_published_at AT TIME ZONE 'UTC')::date::timestamp
+        AT TIME ZONE COALESCE(NULLIF(current_setting('opengov.publication_timezone', true), ''), 'America/New_York'),
+    updated_at = NOW()
+WHERE last_published_at = (last_published_at AT TIME ZONE 'UTC')::date::timestamp AT TIME ZONE 'UTC';
@@ -0,0 +1,12 @@
+-- 027_jobs_origin.sql
+-- Which binary recorded a job (api or jobs), so the API can fail the jobs a restart interrupted without touching a pipeline the jobs binary is still running.
+-- Backfills and bulk reprocesses only ever run in the API; older rows of other types are attributed to the jobs binary.
+
+ALTER TABLE jobs
+    ADD COLUMN IF NOT EXISTS origin TEXT NOT NULL DEFAULT 'jobs';
+
+UPDATE jobs
+SET origin = 'api',
+    updated_at = NOW()
+WHERE type IN ('backfill', 'reprocess')
+  AND origin <> 'api';
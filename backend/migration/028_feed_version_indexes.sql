@@ -0,0 +1,7 @@
+-- 028_feed_version_indexes.sql
+-- Index updated_at on every table the anonymous feed ETag reads, so its MAX(updated_at) lookups read one index entry each instead of scanning.
+
+CREATE INDEX IF NOT EXISTS idx_feed_entries_updated_at ON feed_entries(updated_at);
+CREATE INDEX IF NOT EXISTS idx_policy_documents_updated_at ON policy_documents(updated_at);
+CREATE INDEX IF NOT EXISTS idx_likes_updated_at ON likes(updated_at);
+CREATE INDEX IF NOT EXISTS idx_document_translations_updated_at ON document_translations(updated_at);
@@ -6,6 +6,7 @@ import (
 	"io/fs"
 	"regexp"
 	"sort"
+	"strconv"
 )
 
 //go:embed *.sql
@@ -24,13 +25,34 @@ func List() ([]string, error) {
 	}
 	sort.Strings(files)
 
+	if err := validateNames(files); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// validateNames checks that each name follows NNN_description.sql and that no two
+// share a version, since schema_migrations tracks versions rather than filenames.
+func validateNames(files []string) error {
+	seen := make(map[int]string, len(files))
 	for _, f := range files {
 		if !migrationNameRE.MatchString(f) {
-			return nil, fmt.Errorf("invalid migration filename %q (expected NNN_description.sql)", f)
+			return fmt.Errorf("invalid migration filename %q (expected NNN_description.sql)", f)
 		}
+		v := Version(f)
+		if prev, ok := seen[v]; ok {
+			return fmt.Errorf("migrations %q and %q share version %d", prev, f, v)
+		}
+		seen[v] = f
 	}
+	return nil
+}
 
-	return files, nil
+// Version returns the numeric prefix of a migration filename, e.g. 13 for
+// "013_policy_documents_hidden.sql". The name must already match NNN_description.sql.
+func Version(name string) int {
+	v, _ := strconv.Atoi(name[:3])
+	return v
 }
 
 func ReadFile(name string) ([]byte, error) {
@@ -0,0 +1,27 @@
+package migration
+
+import "testing"
+
+func TestList_EmbeddedMigrationsAreValid(t *testing.T) {
+	files, err := List()
+	if err != nil {
+		t.Fatalf("List() error: %v", err)
+	}
+	for i, f := range files {
+		if Version(f) != i {
+			t.Fatalf("migration %q has version %d, want %d (versions must be contiguous)", f, Version(f), i)
+		}
+	}
+}
+
+func TestValidateNames(t *testing.T) {
+	if err := validateNames([]string{"000_a.sql", "001_b.sql"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := validateNames([]string{"001_a.sql", "001_b.sql"}); err == nil {
+		t.Fatal("duplicate versions were accepted")
+	}
+	if err := validateNames([]string{"1_a.sql"}); err == nil {
+		t.Fatal("malformed name was accepted")
+	}
+}
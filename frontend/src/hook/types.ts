@@ -37,11 +37,19 @@ export interface FeedEntryResponse {
   impact_score?: string | null;
   political_score?: number | null;
   source_url: string;
+  source: string;
   published_at: string;
   is_bookmarked?: boolean;
   user_like_status?: number | null;
   likes_count: number;
   dislikes_count: number;
+  language?: string;
+  agencies?: FeedEntryAgency[];
+}
+
+export interface FeedEntryAgency {
+  id: number | null;
+  name: string;
 }
 
 export type BookmarkedEntry = FeedEntryResponse & {
@@ -55,4 +63,6 @@ export interface FeedResponse {
   limit: number;
   total: number;
   has_next: boolean;
+  feed_empty_reason?: "no_content" | "filtered";
+  server_time?: string;
 }
@@ -13,6 +13,7 @@ interface BookmarkResponse {
 
 interface RemoveBookmarkResponse {
   success: boolean;
+  is_bookmarked: false;
 }
 
 const defaultUI = (): FeedEntryUIState => ({
@@ -12,6 +12,7 @@ export function useBookmarksQuery() {
     queryFn: async () => {
       const { data } = await client.get<{ items: FeedEntryResponse[] }>(
         "/api/bookmarks",
+        { params: { limit: 100 } },
       );
       hydrate(data.items);
       return data.items;
@@ -9,9 +9,11 @@ import { shallow } from "zustand/shallow";
 
 interface LikeResponse {
   value: number;
+  likes: number;
+  dislikes: number;
 }
 
-interface RemoveLikeResponse {
+interface RemoveLikeResponse extends LikeResponse {
   success: boolean;
 }
 